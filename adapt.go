@@ -0,0 +1,131 @@
+// Chromatic adaptation, which predicts how a color seen under one illuminant
+// looks under another one, and white balancing of images built upon it.
+
+package colorful
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// An AdaptationMethod is the cone response model a chromatic adaptation
+// scales in.
+type AdaptationMethod int
+
+const (
+	// Bradford is the most widely used model, for example by ICC profiles
+	// and CSS.
+	Bradford AdaptationMethod = iota
+	// VonKries uses the Hunt-Pointer-Estevez cone responses.
+	VonKries
+	// XyzScaling scales the XYZ values directly, which is the crudest model.
+	XyzScaling
+)
+
+// http://www.brucelindbloom.com/index.html?Eqn_ChromAdapt.html
+var coneResponses = [...]mat3{
+	Bradford: {
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	},
+	VonKries: {
+		{0.40024, 0.70760, -0.08081},
+		{-0.22630, 1.16532, 0.04570},
+		{0.00000, 0.00000, 0.91822},
+	},
+	XyzScaling: {
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	},
+}
+
+// adaptationMatrix returns the matrix adapting XYZ values from the white src
+// to the white dst.
+func adaptationMatrix(method AdaptationMethod, src, dst [3]float64) mat3 {
+	if method < Bradford || method > XyzScaling {
+		panic(fmt.Sprintf("colorful: unknown adaptation method %v", method))
+	}
+	m := &coneResponses[method]
+	sl, sm, ss := m.mul(src[0], src[1], src[2])
+	dl, dm, ds := m.mul(dst[0], dst[1], dst[2])
+	scale := mat3{{dl / sl, 0, 0}, {0, dm / sm, 0}, {0, 0, ds / ss}}
+	scaled := scale.dot(m)
+	inv := m.inverse()
+	return inv.dot(&scaled)
+}
+
+// AdaptXyz converts the XYZ values of a color seen under the white point src
+// into those of the corresponding color under the white point dst.
+func AdaptXyz(x, y, z float64, src, dst [3]float64, method AdaptationMethod) (xa, ya, za float64) {
+	m := adaptationMatrix(method, src, dst)
+	return m.mul(x, y, z)
+}
+
+// Adapt returns the color which looks under the white point dst like col
+// does under the white point src, using the Bradford method. The result may
+// be outside of the RGB gamut.
+func (col Color) Adapt(src, dst [3]float64) Color {
+	x, y, z := col.Xyz()
+	return Xyz(AdaptXyz(x, y, z, src, dst, Bradford))
+}
+
+// The degree to which viewers adapt to a change of illuminant, following the
+// CIECAM02 formula for an average surround at 64 cd/m², a typically lit room.
+var illuminantAdaptation = 1 - math.Exp((-64.0-42.0)/92.0)/3.6
+
+// UnderIlluminant returns how a surface of the color under the illuminant
+// from looks when lit by the illuminant to instead, such as a product photo
+// taken under D65 seen in a store lit by incandescent lamps. Unlike Adapt,
+// which gives the color looking the same under the other illuminant, it
+// keeps the surface and models the eye's incomplete adaptation to the new
+// light, so colors take on some of its tint. The result may be outside of the
+// RGB gamut.
+func (col Color) UnderIlluminant(from, to [3]float64) Color {
+	// The eye adapts to most of the change of white, the rest tints the
+	// color. Mixing the whites in XYZ is the same as mixing them in cone
+	// space, as the two are linearly related.
+	d := illuminantAdaptation
+	seen := [3]float64{
+		d*from[0] + (1-d)*to[0],
+		d*from[1] + (1-d)*to[1],
+		d*from[2] + (1-d)*to[2],
+	}
+	return col.Adapt(from, seen)
+}
+
+/// White balance ///
+/////////////////////
+
+// linearRgbAdaptation returns the adaptation as a matrix on linear RGB values.
+func linearRgbAdaptation(src, dst [3]float64) mat3 {
+	m := adaptationMatrix(Bradford, src, dst)
+	m = m.dot(&linearRgbToXyzMat)
+	return xyzToLinearRgbMat.dot(&m)
+}
+
+func applyLinearRgb(img image.Image, m mat3) *image.NRGBA {
+	return MapImage(img, func(c Color) Color {
+		return LinearRgb(m.mul(c.LinearRgb()))
+	})
+}
+
+// WhiteBalance corrects the colors of img, given the color of a patch of it
+// which should have been neutral gray, such as a gray card in a photo. The
+// patch's chromaticity is taken as the illuminant of the scene, and the image
+// is adapted from it to D65, the white of sRGB.
+func WhiteBalance(img image.Image, neutral Color) *image.NRGBA {
+	x, y, z := neutral.Xyz()
+	if y <= 0 {
+		panic("colorful: WhiteBalance needs a neutral patch brighter than black")
+	}
+	return applyLinearRgb(img, linearRgbAdaptation([3]float64{x / y, 1, z / y}, D65))
+}
+
+// WhiteBalanceIlluminant adapts the colors of img as if the scene had been lit
+// by the white point dst instead of src, see IlluminantWhitePoint.
+func WhiteBalanceIlluminant(img image.Image, src, dst [3]float64) *image.NRGBA {
+	return applyLinearRgb(img, linearRgbAdaptation(src, dst))
+}
@@ -0,0 +1,80 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestAdaptationMatrix(t *testing.T) {
+	m := adaptationMatrix(Bradford, D65, D50)
+	for i := range m {
+		for j := range m[i] {
+			if math.Abs(m[i][j]-bradfordD65ToD50[i][j]) > 1e-3 {
+				t.Errorf("adaptationMatrix(Bradford, D65, D50)[%v][%v] => %v, want %v", i, j, m[i][j], bradfordD65ToD50[i][j])
+			}
+		}
+	}
+
+	for _, method := range []AdaptationMethod{Bradford, VonKries, XyzScaling} {
+		// The source white maps onto the destination white.
+		x, y, z := AdaptXyz(D50[0], D50[1], D50[2], D50, D65, method)
+		if !almosteq(x, D65[0]) || !almosteq(y, D65[1]) || !almosteq(z, D65[2]) {
+			t.Errorf("%v. AdaptXyz(D50, D50, D65) => (%v, %v, %v), want %v", method, x, y, z, D65)
+		}
+	}
+
+	for _, c := range randomColors(20) {
+		if got := c.Adapt(D65, D65); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.Adapt(D65, D65) => %v, want it unchanged", c, got)
+		}
+		if got := c.Adapt(D65, D50).Adapt(D50, D65); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.Adapt(D65, D50).Adapt(D50, D65) => %v, want it unchanged", c, got)
+		}
+	}
+}
+
+func TestWhiteBalance(t *testing.T) {
+	gray := Color{0.65, 0.5, 0.35} // Gray under warm light.
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, gray)
+	img.Set(1, 0, color.NRGBA{100, 150, 120, 128})
+
+	balanced := WhiteBalance(img, gray)
+	c, _ := MakeColor(balanced.At(0, 0))
+	if _, a, b := c.Lab(); math.Abs(a) > 0.5 || math.Abs(b) > 0.5 {
+		t.Errorf("WhiteBalance => %v with a*b* (%v, %v), want neutral", c, a, b)
+	}
+	if a := balanced.NRGBAAt(1, 0).A; a != 128 {
+		t.Errorf("WhiteBalance => alpha %v, want 128", a)
+	}
+
+	// Going back and forth between illuminants keeps the image.
+	a, _ := IlluminantWhitePoint("A", Observer2)
+	there := WhiteBalanceIlluminant(img, D65, a)
+	back := WhiteBalanceIlluminant(there, a, D65)
+	for i := range img.Pix {
+		if d := int(back.Pix[i]) - int(img.Pix[i]); d < -1 || d > 1 {
+			t.Errorf("WhiteBalanceIlluminant round trip at %v => %v, want %v", i, back.Pix[i], img.Pix[i])
+		}
+	}
+}
+
+func TestUnderIlluminant(t *testing.T) {
+	a, _ := IlluminantWhitePoint("A", Observer2)
+	for _, c := range randomColors(10) {
+		if got := c.UnderIlluminant(D65, D65); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.UnderIlluminant(D65, D65) => %v", c, got)
+		}
+	}
+
+	// Under incandescent light, white looks warmer, but much less so than the
+	// full change of white.
+	white := Color{1, 1, 1}
+	got := white.UnderIlluminant(D65, a).Warmth()
+	full := white.Adapt(D65, a).Warmth()
+	if !(got > 0.01 && got < full/2) {
+		t.Errorf("white.UnderIlluminant(D65, A) => warmth %v, want between 0.01 and %v", got, full/2)
+	}
+}
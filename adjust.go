@@ -0,0 +1,207 @@
+// Adjustments of colors and images as they appear in photo and theme editors,
+// done in the spaces in which they behave the way people expect.
+
+package colorful
+
+import (
+	"image"
+	"math"
+)
+
+/// Exposure and gamma ///
+//////////////////////////
+
+// AdjustExposure changes the exposure by the given number of photographic
+// stops, each of which doubles (or, if negative, halves) the amount of light.
+// This scales the linear RGB values, so the result may exceed the gamut.
+func (col Color) AdjustExposure(stops float64) Color {
+	s := math.Exp2(stops)
+	r, g, b := col.LinearRgb()
+	return LinearRgb(r*s, g*s, b*s)
+}
+
+// AdjustGamma raises the linear RGB values to the power of 1/g, so that g
+// greater than 1 brightens the midtones and less than 1 darkens them, leaving
+// black and white as they are. Negative values are mirrored.
+func (col Color) AdjustGamma(g float64) Color {
+	f := mirrored(func(v float64) float64 {
+		return math.Pow(v, 1/g)
+	})
+	r, gr, b := col.LinearRgb()
+	return LinearRgb(f(r), f(gr), f(b))
+}
+
+// AdjustExposureImage returns a copy of img with AdjustExposure applied to
+// every pixel and clipped to the gamut.
+func AdjustExposureImage(img image.Image, stops float64) *image.NRGBA {
+	return MapImage(img, func(c Color) Color {
+		return c.AdjustExposure(stops)
+	})
+}
+
+// AdjustGammaImage returns a copy of img with AdjustGamma applied to every
+// pixel.
+func AdjustGammaImage(img image.Image, g float64) *image.NRGBA {
+	return MapImage(img, func(c Color) Color {
+		return c.AdjustGamma(g)
+	})
+}
+
+/// Saturation ///
+//////////////////
+
+// scaleOkLchChroma multiplies the OkLch chroma of the color by the factor f
+// returns, which is given the original chroma, and fits the result into the
+// gamut keeping its hue and lightness.
+func (col Color) scaleOkLchChroma(f func(c float64) float64) Color {
+	l, c, h := col.OkLch()
+	return OkLch(l, c*math.Max(0, f(c)), h).ClampedOkLch()
+}
+
+// Saturate increases the chroma of the color in OkLch space by the given
+// fraction, such that 0.2 makes it 20% more colorful, keeping its hue and
+// lightness. Colors leaving the gamut are brought back by reducing chroma, so
+// already saturated colors may not change at all.
+func (col Color) Saturate(amount float64) Color {
+	return col.scaleOkLchChroma(func(float64) float64 {
+		return 1 + amount
+	})
+}
+
+// Desaturate decreases the chroma of the color in OkLch space by the given
+// fraction, such that 1 turns it gray of the same lightness.
+func (col Color) Desaturate(amount float64) Color {
+	return col.Saturate(-amount)
+}
+
+// The largest OkLch chroma of the sRGB gamut, about that of blue.
+const maxOkLchChroma = 0.32
+
+// Vibrance is like Saturate, but scales the increase down the more colorful
+// the color already is, such that muted colors are boosted while saturated
+// ones (and skin tones, compared to Saturate) stay about the same. Negative
+// amounts mute the least colorful colors the most.
+func (col Color) Vibrance(amount float64) Color {
+	return col.scaleOkLchChroma(func(c float64) float64 {
+		return 1 + amount*(1-math.Min(c/maxOkLchChroma, 1))
+	})
+}
+
+/// Lightness ///
+/////////////////
+
+// Lighten increases the OkLch lightness of the color by amount, where 1 is the
+// full range from black to white, keeping its hue and chroma. If the result
+// is outside of the gamut, chroma is reduced until it fits, which keeps the
+// hue from shifting like it does when lightening in HSL.
+func (col Color) Lighten(amount float64) Color {
+	l, c, h := col.OkLch()
+	return OkLch(clamp01(l+amount), c, h).ClampedOkLch()
+}
+
+// Darken decreases the OkLch lightness of the color by amount, see Lighten.
+func (col Color) Darken(amount float64) Color {
+	return col.Lighten(-amount)
+}
+
+/// Hue ///
+///////////
+
+// RotateHue turns the OkLch hue of the color by the given number of degrees,
+// wrapping around the hue circle, and keeps its lightness. Chroma is reduced
+// if the rotated color doesn't fit into the gamut.
+func (col Color) RotateHue(deg float64) Color {
+	l, c, h := col.OkLch()
+	h = math.Mod(h+deg, 360.0)
+	if h < 0 {
+		h += 360.0
+	}
+	return OkLch(l, c, h).ClampedOkLch()
+}
+
+// Complement returns the color on the opposite side of the OkLch hue circle.
+func (col Color) Complement() Color {
+	return col.RotateHue(180)
+}
+
+// InvertPerceptual inverts the OkLch lightness of the color, keeping its hue
+// and as much of its chroma as fits, such that a dark red becomes a light red
+// rather than the cyan plain RGB inversion results in.
+func (col Color) InvertPerceptual() Color {
+	l, c, h := col.OkLch()
+	return OkLch(clamp01(1-l), c, h).ClampedOkLch()
+}
+
+/// Chaining ///
+////////////////
+
+// An Adjustment chains adjustments of a color in OkLch space, converting to
+// it once and back once in Done, instead of for each step, as in
+// c.Adjust().RotateHue(30).Saturate(0.1).Lighten(0.05).Done(). The steps
+// behave like the Color methods of the same names, except that intermediate
+// results may be out of gamut, only the final color is brought back into it.
+// Adjustments are values, so a chain can be branched at any step.
+type Adjustment struct {
+	l, c, h float64
+}
+
+// Adjust starts a chain of adjustments of the color.
+func (col Color) Adjust() Adjustment {
+	l, c, h := col.OkLch()
+	return Adjustment{l, c, h}
+}
+
+// Done returns the adjusted color, brought into the gamut by reducing chroma
+// as the Color methods do.
+func (a Adjustment) Done() Color {
+	return OkLch(a.l, a.c, a.h).ClampedOkLch()
+}
+
+// Saturate increases the chroma, see Color.Saturate.
+func (a Adjustment) Saturate(amount float64) Adjustment {
+	a.c *= math.Max(0, 1+amount)
+	return a
+}
+
+// Desaturate decreases the chroma, see Color.Desaturate.
+func (a Adjustment) Desaturate(amount float64) Adjustment {
+	return a.Saturate(-amount)
+}
+
+// Vibrance increases the chroma of muted colors, see Color.Vibrance.
+func (a Adjustment) Vibrance(amount float64) Adjustment {
+	a.c *= math.Max(0, 1+amount*(1-math.Min(a.c/maxOkLchChroma, 1)))
+	return a
+}
+
+// Lighten increases the lightness, see Color.Lighten.
+func (a Adjustment) Lighten(amount float64) Adjustment {
+	a.l = clamp01(a.l + amount)
+	return a
+}
+
+// Darken decreases the lightness, see Color.Darken.
+func (a Adjustment) Darken(amount float64) Adjustment {
+	return a.Lighten(-amount)
+}
+
+// RotateHue turns the hue by the given number of degrees, see
+// Color.RotateHue.
+func (a Adjustment) RotateHue(deg float64) Adjustment {
+	a.h = math.Mod(a.h+deg, 360.0)
+	if a.h < 0 {
+		a.h += 360.0
+	}
+	return a
+}
+
+// Complement turns the hue to the opposite side of the hue circle.
+func (a Adjustment) Complement() Adjustment {
+	return a.RotateHue(180)
+}
+
+// InvertPerceptual inverts the lightness, see Color.InvertPerceptual.
+func (a Adjustment) InvertPerceptual() Adjustment {
+	a.l = clamp01(1 - a.l)
+	return a
+}
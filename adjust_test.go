@@ -0,0 +1,190 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestAdjustExposure(t *testing.T) {
+	for _, c := range randomColors(20) {
+		r, g, b := c.LinearRgb()
+		r2, g2, b2 := c.AdjustExposure(1).LinearRgb()
+		if !almosteq(r2, 2*r) || !almosteq(g2, 2*g) || !almosteq(b2, 2*b) {
+			t.Errorf("%v.AdjustExposure(1) => linear (%v, %v, %v), want twice (%v, %v, %v)", c, r2, g2, b2, r, g, b)
+		}
+		if got := c.AdjustExposure(-2.5).AdjustExposure(2.5); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.AdjustExposure(-2.5).AdjustExposure(2.5) => %v", c, got)
+		}
+	}
+
+	// One stop less of linear 0.5 is 0.25, not sRGB 0.5/2.
+	if got := LinearRgb(0.5, 0.5, 0.5).AdjustExposure(-1); !got.AlmostEqualRgb(LinearRgb(0.25, 0.25, 0.25)) {
+		t.Errorf("AdjustExposure(-1) => %v, want %v", got, LinearRgb(0.25, 0.25, 0.25))
+	}
+}
+
+func TestAdjustGamma(t *testing.T) {
+	black, white := Color{0, 0, 0}, Color{1, 1, 1}
+	for _, g := range []float64{0.5, 1, 2.2} {
+		if got := black.AdjustGamma(g); !got.AlmostEqualRgb(black) {
+			t.Errorf("black.AdjustGamma(%v) => %v", g, got)
+		}
+		if got := white.AdjustGamma(g); !got.AlmostEqualRgb(white) {
+			t.Errorf("white.AdjustGamma(%v) => %v", g, got)
+		}
+	}
+
+	gray := LinearRgb(0.25, 0.25, 0.25)
+	if got := gray.AdjustGamma(2); !got.AlmostEqualRgb(LinearRgb(0.5, 0.5, 0.5)) {
+		t.Errorf("%v.AdjustGamma(2) => %v, want %v", gray, got, LinearRgb(0.5, 0.5, 0.5))
+	}
+	if got := gray.AdjustGamma(1); !got.AlmostEqualRgb(gray) {
+		t.Errorf("%v.AdjustGamma(1) => %v, want it unchanged", gray, got)
+	}
+}
+
+func TestAdjustImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{100, 150, 200, 255})
+	img.Set(1, 0, color.NRGBA{250, 250, 250, 64})
+
+	bright := AdjustExposureImage(img, 1)
+	if p := bright.NRGBAAt(1, 0); p != (color.NRGBA{255, 255, 255, 64}) {
+		t.Errorf("AdjustExposureImage => %v, want clipped white", p)
+	}
+	want := Color{100 / 255.0, 150 / 255.0, 200 / 255.0}.AdjustGamma(1.5).Clamped()
+	if c, _ := MakeColor(AdjustGammaImage(img, 1.5).At(0, 0)); c.DistanceRgb(want) > 1/255.0 {
+		t.Errorf("AdjustGammaImage => %v, want %v", c, want)
+	}
+}
+
+func TestSaturate(t *testing.T) {
+	muted := OkLch(0.6, 0.05, 30)
+	for _, c := range append(randomColors(20), muted) {
+		l, ch, h := c.OkLch()
+
+		s := c.Saturate(0.2)
+		if !s.IsValid() {
+			t.Errorf("%v.Saturate(0.2) => %v, want a valid color", c, s)
+		}
+		l2, ch2, h2 := s.OkLch()
+		if ch2 < ch-1e-6 || !almosteq_eps(l2, l, 1e-3) || (ch > 1e-3 && math.Abs(h2-h) > 0.5) {
+			t.Errorf("%v.Saturate(0.2) => OkLch (%v, %v, %v), from (%v, %v, %v)", c, l2, ch2, h2, l, ch, h)
+		}
+
+		if _, ch2, _ := c.Desaturate(1).OkLch(); ch2 > 1e-6 {
+			t.Errorf("%v.Desaturate(1) => chroma %v, want gray", c, ch2)
+		}
+		if _, ch2, _ := c.Desaturate(0.5).OkLch(); !almosteq_eps(ch2, ch/2, 1e-3) {
+			t.Errorf("%v.Desaturate(0.5) => chroma %v, want %v", c, ch2, ch/2)
+		}
+	}
+
+	if _, c, _ := muted.Saturate(0.5).OkLch(); !almosteq_eps(c, 0.075, 1e-3) {
+		t.Errorf("%v.Saturate(0.5) => chroma %v, want 0.075", muted, c)
+	}
+}
+
+func TestVibrance(t *testing.T) {
+	muted, vivid := OkLch(0.6, 0.05, 30), OkLch(0.6, 0.2, 30)
+	_, cm, _ := muted.Vibrance(0.5).OkLch()
+	_, cv, _ := vivid.Vibrance(0.5).OkLch()
+	if gm, gv := cm/0.05, cv/0.2; gm <= gv || gv < 1 {
+		t.Errorf("Vibrance(0.5) boosted chroma by %v and %v, want more for the muted color", gm, gv)
+	}
+	if got := vivid.Vibrance(0); !got.AlmostEqualRgb(vivid) {
+		t.Errorf("%v.Vibrance(0) => %v, want it unchanged", vivid, got)
+	}
+}
+
+func TestLightenDarken(t *testing.T) {
+	for _, c := range randomColors(20) {
+		l, ch, h := c.OkLch()
+		for _, amount := range []float64{0.1, -0.1, 0.3} {
+			got := c.Lighten(amount)
+			if !got.IsValid() {
+				t.Errorf("%v.Lighten(%v) => %v, want a valid color", c, amount, got)
+			}
+			l2, ch2, h2 := got.OkLch()
+			if want := clamp01(l + amount); !almosteq_eps(l2, want, 1e-3) && math.Abs(l2-want) > 1e-4 {
+				t.Errorf("%v.Lighten(%v) => lightness %v, want %v", c, amount, l2, want)
+			}
+			if ch2 > ch+1e-6 || (ch2 > 1e-3 && math.Abs(h2-h) > 0.5) {
+				t.Errorf("%v.Lighten(%v) => chroma %v and hue %v, from %v and %v", c, amount, ch2, h2, ch, h)
+			}
+		}
+		if got := c.Darken(0.2); !got.AlmostEqualRgb(c.Lighten(-0.2)) {
+			t.Errorf("%v.Darken(0.2) => %v, want %v", c, got, c.Lighten(-0.2))
+		}
+	}
+
+	if got := (Color{0.5, 0.5, 0.5}).Lighten(1); !got.AlmostEqualRgb(Color{1, 1, 1}) {
+		t.Errorf("gray.Lighten(1) => %v, want white", got)
+	}
+}
+
+func TestRotateHue(t *testing.T) {
+	for _, c := range randomColors(20) {
+		l, ch, h := c.OkLch()
+		for _, deg := range []float64{30, -90, 400} {
+			got := c.RotateHue(deg)
+			l2, ch2, h2 := got.OkLch()
+			want := math.Mod(h+deg+720, 360)
+			if d := math.Abs(h2 - want); ch2 > 1e-3 && math.Min(d, 360-d) > 0.5 {
+				t.Errorf("%v.RotateHue(%v) => hue %v, want %v", c, deg, h2, want)
+			}
+			if !got.IsValid() || !almosteq_eps(l2, l, 1e-3) || ch2 > ch+1e-6 {
+				t.Errorf("%v.RotateHue(%v) => %v with OkLch (%v, %v, %v)", c, deg, got, l2, ch2, h2)
+			}
+		}
+		if got := c.RotateHue(360); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.RotateHue(360) => %v, want it unchanged", c, got)
+		}
+		if got, want := c.Complement(), c.RotateHue(-180); !got.AlmostEqualRgb(want) {
+			t.Errorf("%v.Complement() => %v, want %v", c, got, want)
+		}
+	}
+
+	gray := Color{0.4, 0.4, 0.4}
+	if got := gray.RotateHue(123); !got.AlmostEqualRgb(gray) {
+		t.Errorf("%v.RotateHue(123) => %v, want it unchanged", gray, got)
+	}
+}
+
+func TestInvertPerceptual(t *testing.T) {
+	if got := (Color{0, 0, 0}).InvertPerceptual(); !got.AlmostEqualRgb(Color{1, 1, 1}) {
+		t.Errorf("black.InvertPerceptual() => %v, want white", got)
+	}
+
+	darkRed := OkLch(0.3, 0.1, 25)
+	l, c, h := darkRed.InvertPerceptual().OkLch()
+	if !almosteq_eps(l, 0.7, 1e-3) || !almosteq_eps(c, 0.1, 1e-3) || math.Abs(h-25) > 0.5 {
+		t.Errorf("%v.InvertPerceptual() => OkLch (%v, %v, %v), want (0.7, 0.1, 25)", darkRed, l, c, h)
+	}
+}
+
+func TestAdjustmentChain(t *testing.T) {
+	for _, c := range randomColors(20) {
+		if got := c.Adjust().Done(); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.Adjust().Done() => %v", c, got)
+		}
+
+		// Muted colors don't leave the gamut, so chaining gives the same
+		// result as the single steps.
+		muted := c.Desaturate(0.7)
+		want := muted.RotateHue(30).Saturate(0.1).Darken(0.05)
+		if got := muted.Adjust().RotateHue(30).Saturate(0.1).Darken(0.05).Done(); !got.AlmostEqualRgb(want) {
+			t.Errorf("%v.Adjust() chain => %v, want %v", muted, got, want)
+		}
+	}
+
+	// Intermediate results aren't clipped, so a step out of gamut and back
+	// doesn't lose chroma.
+	col := OkLch(0.6, 0.15, 30)
+	got := col.Adjust().Saturate(1).Desaturate(0.5).Done()
+	if !got.AlmostEqualRgb(col) {
+		t.Errorf("%v.Adjust().Saturate(1).Desaturate(0.5).Done() => %v", col, got)
+	}
+}
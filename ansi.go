@@ -0,0 +1,145 @@
+// Conversion to and from the colors of terminals.
+
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+// The six levels of each channel in xterm's 6x6x6 color cube.
+var ansiCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+func rgb255(r, g, b uint8) Color {
+	return Color{float64(r) / 255.0, float64(g) / 255.0, float64(b) / 255.0}
+}
+
+// ANSI256 returns the color of the given index of xterm's 256 color palette:
+// 0-15 are the system colors with xterm's defaults, 16-231 a 6x6x6 color cube
+// and 232-255 a ramp of 24 grays from #080808 to #eeeeee.
+func ANSI256(index uint8) Color {
+	switch {
+	case index < 16:
+		return XtermPalette[index]
+	case index < 232:
+		i := index - 16
+		return rgb255(ansiCubeLevels[i/36], ansiCubeLevels[i/6%6], ansiCubeLevels[i%6])
+	}
+	gray := 8 + 10*(index-232)
+	return rgb255(gray, gray, gray)
+}
+
+// ansi256Lab holds the Lab values of the palette, for finding the nearest entry.
+var ansi256Lab [256][3]float64
+
+func init() {
+	for i := range ansi256Lab {
+		l, a, b := ANSI256(uint8(i)).Lab()
+		ansi256Lab[i] = [3]float64{l, a, b}
+	}
+}
+
+// ANSI256 returns the index of the nearest color of xterm's 256 color palette,
+// as measured by DistanceLab. Since users commonly configure the 16 system
+// colors, only the color cube and the gray ramp (16-255) are considered.
+func (col Color) ANSI256() uint8 {
+	return uint8(nearestLab(col.Clamped(), ansi256Lab[16:]) + 16)
+}
+
+// nearestLab returns the index of the entry nearest to col in the Lab space.
+func nearestLab(col Color, labs [][3]float64) int {
+	l, a, b := col.Lab()
+	best, bestDist := 0, math.Inf(1)
+	for i, lab := range labs {
+		dl, da, db := l-lab[0], a-lab[1], b-lab[2]
+		if d := dl*dl + da*da + db*db; d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// An ANSIPalette holds the 16 system colors of a terminal, which are used by
+// the basic SGR color codes. Their order is black, red, green, yellow, blue,
+// magenta, cyan and white, followed by the bright variants in the same order.
+// Users can configure these colors in most terminals, so there are several
+// common palettes to choose from, or supply your own.
+type ANSIPalette [16]Color
+
+var (
+	// XtermPalette holds xterm's default colors.
+	XtermPalette = hexPalette([16]uint32{
+		0x000000, 0xcd0000, 0x00cd00, 0xcdcd00, 0x0000ee, 0xcd00cd, 0x00cdcd, 0xe5e5e5,
+		0x7f7f7f, 0xff0000, 0x00ff00, 0xffff00, 0x5c5cff, 0xff00ff, 0x00ffff, 0xffffff,
+	})
+
+	// VGAPalette holds the colors of the VGA text mode, used by the Linux console.
+	VGAPalette = hexPalette([16]uint32{
+		0x000000, 0xaa0000, 0x00aa00, 0xaa5500, 0x0000aa, 0xaa00aa, 0x00aaaa, 0xaaaaaa,
+		0x555555, 0xff5555, 0x55ff55, 0xffff55, 0x5555ff, 0xff55ff, 0x55ffff, 0xffffff,
+	})
+
+	// SolarizedPalette holds the colors of Solarized's (dark) terminal themes.
+	// https://ethanschoonover.com/solarized/
+	SolarizedPalette = hexPalette([16]uint32{
+		0x073642, 0xdc322f, 0x859900, 0xb58900, 0x268bd2, 0xd33682, 0x2aa198, 0xeee8d5,
+		0x002b36, 0xcb4b16, 0x586e75, 0x657b83, 0x839496, 0x6c71c4, 0x93a1a1, 0xfdf6e3,
+	})
+)
+
+func hexPalette(hexes [16]uint32) (p ANSIPalette) {
+	for i, hex := range hexes {
+		p[i] = rgb255(uint8(hex>>16), uint8(hex>>8), uint8(hex))
+	}
+	return
+}
+
+// ANSI16 returns the index (0-15) of the nearest color of the given terminal
+// palette, as measured by DistanceLab. A nil palette means XtermPalette.
+func (col Color) ANSI16(palette *ANSIPalette) uint8 {
+	if palette == nil {
+		palette = &XtermPalette
+	}
+	var labs [16][3]float64
+	for i, c := range palette {
+		labs[i][0], labs[i][1], labs[i][2] = c.Lab()
+	}
+	return uint8(nearestLab(col.Clamped(), labs[:]))
+}
+
+// SGRReset is the escape sequence resetting all colors and attributes.
+const SGRReset = "\x1b[0m"
+
+// SGRTrueColor returns the escape sequence setting the 24 bit color as
+// foreground color, or as background color if background is true.
+func (col Color) SGRTrueColor(background bool) string {
+	r, g, b := col.Clamped().RGB255()
+	return fmt.Sprintf("\x1b[%v;2;%v;%v;%vm", sgrTarget(background), r, g, b)
+}
+
+// SGR256 returns the escape sequence setting the nearest color of xterm's 256
+// color palette (see ANSI256) as foreground or background color.
+func (col Color) SGR256(background bool) string {
+	return fmt.Sprintf("\x1b[%v;5;%vm", sgrTarget(background), col.ANSI256())
+}
+
+// SGR16 returns the escape sequence setting the nearest of the 16 system
+// colors of the given palette (see ANSI16) as foreground or background color.
+func (col Color) SGR16(palette *ANSIPalette, background bool) string {
+	i := int(col.ANSI16(palette))
+	code := 30 + i
+	if i >= 8 {
+		code = 90 + i - 8
+	}
+	if background {
+		code += 10
+	}
+	return fmt.Sprintf("\x1b[%vm", code)
+}
+
+func sgrTarget(background bool) int {
+	if background {
+		return 48
+	}
+	return 38
+}
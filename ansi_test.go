@@ -0,0 +1,119 @@
+package colorful
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestANSI256Palette(t *testing.T) {
+	tests := []struct {
+		index uint8
+		hex   string
+	}{
+		{0, "#000000"},
+		{9, "#ff0000"},
+		{12, "#5c5cff"},
+		{16, "#000000"},
+		{21, "#0000ff"},
+		{196, "#ff0000"},
+		{208, "#ff8700"},
+		{231, "#ffffff"},
+		{232, "#080808"},
+		{244, "#808080"},
+		{255, "#eeeeee"},
+	}
+	for i, tt := range tests {
+		if hex := ANSI256(tt.index).Hex(); hex != tt.hex {
+			t.Errorf("%v. ANSI256(%v) => %v, want %v", i, tt.index, hex, tt.hex)
+		}
+	}
+
+	for i := 16; i < 256; i++ {
+		if got := ANSI256(uint8(i)).ANSI256(); got != uint8(i) {
+			t.Errorf("ANSI256(%v).ANSI256() => %v, want %v", i, got, i)
+		}
+	}
+}
+
+func TestANSI256Nearest(t *testing.T) {
+	tests := []struct {
+		hex   string
+		index uint8
+	}{
+		{"#ff8800", 208},
+		{"#000000", 16},
+		{"#ffffff", 231},
+		{"#7f7f7f", 244},
+		{"#333333", 236},
+		{"#f9f9f9", 231},
+		{"#5f87af", 67},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.hex).ANSI256(); got != tt.index {
+			t.Errorf("%v. %v.ANSI256() => %v, want %v", i, tt.hex, got, tt.index)
+		}
+	}
+
+	// ANSI256 finds the very nearest entry.
+	rand.Seed(1)
+	for i := 0; i < 1000; i++ {
+		col := Color{rand.Float64(), rand.Float64(), rand.Float64()}
+		best := 1e9
+		for j := 16; j < 256; j++ {
+			if d := col.DistanceLab(ANSI256(uint8(j))); d < best {
+				best = d
+			}
+		}
+		if got := col.DistanceLab(ANSI256(col.ANSI256())); got > best+1e-12 {
+			t.Errorf("%v.ANSI256() is at distance %v, but the nearest entry is at %v", col, got, best)
+		}
+	}
+}
+
+func TestANSI16(t *testing.T) {
+	var custom ANSIPalette
+	for i := range custom {
+		custom[i] = Color{1, 1, 1}
+	}
+	custom[5] = Color{0.1, 0.1, 0.1}
+
+	tests := []struct {
+		hex     string
+		palette *ANSIPalette
+		index   uint8
+	}{
+		{"#ff0000", nil, 9},
+		{"#c00000", nil, 1},
+		{"#aa5500", &VGAPalette, 3},
+		{"#ffffff", &VGAPalette, 15},
+		{"#268bd2", &SolarizedPalette, 4},
+		{"#002b36", &SolarizedPalette, 8},
+		{"#000000", &custom, 5},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.hex).ANSI16(tt.palette); got != tt.index {
+			t.Errorf("%v. %v.ANSI16() => %v, want %v", i, tt.hex, got, tt.index)
+		}
+	}
+}
+
+func TestSGR(t *testing.T) {
+	col := MustHex("#ff8700")
+	tests := []struct {
+		got, want string
+	}{
+		{col.SGRTrueColor(false), "\x1b[38;2;255;135;0m"},
+		{col.SGRTrueColor(true), "\x1b[48;2;255;135;0m"},
+		{col.SGR256(false), "\x1b[38;5;208m"},
+		{col.SGR256(true), "\x1b[48;5;208m"},
+		{Color{1, 0, 0}.SGR16(nil, false), "\x1b[91m"},
+		{Color{1, 0, 0}.SGR16(nil, true), "\x1b[101m"},
+		{Color{0, 0, 0}.SGR16(&VGAPalette, false), "\x1b[30m"},
+		{Color{0, 0, 0.7}.SGR16(&VGAPalette, true), "\x1b[44m"},
+	}
+	for i, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%v. got %q, want %q", i, tt.got, tt.want)
+		}
+	}
+}
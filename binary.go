@@ -0,0 +1,126 @@
+// Compact binary encoding of colors.
+
+package colorful
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+// Since Color implements encoding.BinaryMarshaler, encoding/gob stores it in
+// the Binary64 format rather than field by field. Data gob-encoded by versions
+// before that doesn't decode into a Color anymore, but into a struct of
+// float64 fields R, G and B, see the CHANGELOG. Registering it allows for
+// Colors in interface values, under a name which can't clash with other
+// packages named colorful.
+func init() {
+	gob.RegisterName("github.com/nullobsi/go-colorful.Color", Color{})
+}
+
+// A BinaryFormat is a fixed-size binary encoding of a Color. Its value is the
+// number of bytes per color. All formats store R, G and B in that order and
+// big-endian:
+//
+//	Binary8   3 bytes, each channel clamped and quantized to 8 bits, like Hex.
+//	Binary16  6 bytes, each channel clamped and quantized to 16 bits, like Hex16.
+//	Binary64 24 bytes, each channel as IEEE 754 float64, which is lossless.
+type BinaryFormat int
+
+const (
+	Binary8  BinaryFormat = 3
+	Binary16 BinaryFormat = 6
+	Binary64 BinaryFormat = 24
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler using the lossless
+// Binary64 format.
+func (col Color) MarshalBinary() ([]byte, error) {
+	return col.AppendBinary(nil, Binary64), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The format is
+// determined by the length of data, so any of the BinaryFormats is accepted.
+func (col *Color) UnmarshalBinary(data []byte) error {
+	format := BinaryFormat(len(data))
+	if format != Binary8 && format != Binary16 && format != Binary64 {
+		return fmt.Errorf("color: binary color has invalid length %v", len(data))
+	}
+	*col = decodeBinary(data, format)
+	return nil
+}
+
+// AppendBinary appends the color in the given format to dst and returns the
+// extended buffer. It panics if format is not one of the BinaryFormats.
+func (col Color) AppendBinary(dst []byte, format BinaryFormat) []byte {
+	switch format {
+	case Binary8:
+		c := col.Clamped()
+		return append(dst, uint8(c.R*255.0+0.5), uint8(c.G*255.0+0.5), uint8(c.B*255.0+0.5))
+	case Binary16:
+		var buf [6]byte
+		c := col.Clamped()
+		binary.BigEndian.PutUint16(buf[0:], uint16(c.R*65535.0+0.5))
+		binary.BigEndian.PutUint16(buf[2:], uint16(c.G*65535.0+0.5))
+		binary.BigEndian.PutUint16(buf[4:], uint16(c.B*65535.0+0.5))
+		return append(dst, buf[:]...)
+	case Binary64:
+		var buf [24]byte
+		binary.BigEndian.PutUint64(buf[0:], math.Float64bits(col.R))
+		binary.BigEndian.PutUint64(buf[8:], math.Float64bits(col.G))
+		binary.BigEndian.PutUint64(buf[16:], math.Float64bits(col.B))
+		return append(dst, buf[:]...)
+	}
+	panic(fmt.Sprintf("colorful: invalid binary format %v", int(format)))
+}
+
+// AppendColors appends all colors in the given format to dst and returns the
+// extended buffer, which grows by exactly len(cols) times format bytes.
+func AppendColors(dst []byte, cols []Color, format BinaryFormat) []byte {
+	if n := len(dst) + len(cols)*int(format); n > cap(dst) {
+		grown := make([]byte, len(dst), n)
+		copy(grown, dst)
+		dst = grown
+	}
+	for _, col := range cols {
+		dst = col.AppendBinary(dst, format)
+	}
+	return dst
+}
+
+// DecodeColors decodes colors written by AppendColors with the same format.
+// It returns an error if the length of data isn't a multiple of the format's size.
+func DecodeColors(data []byte, format BinaryFormat) ([]Color, error) {
+	if format != Binary8 && format != Binary16 && format != Binary64 {
+		return nil, fmt.Errorf("color: invalid binary format %v", int(format))
+	}
+	size := int(format)
+	if len(data)%size != 0 {
+		return nil, fmt.Errorf("color: %v bytes of binary colors aren't a multiple of %v", len(data), size)
+	}
+
+	cols := make([]Color, len(data)/size)
+	for i := range cols {
+		cols[i] = decodeBinary(data[i*size:(i+1)*size], format)
+	}
+	return cols, nil
+}
+
+func decodeBinary(data []byte, format BinaryFormat) Color {
+	switch format {
+	case Binary8:
+		return Color{float64(data[0]) / 255.0, float64(data[1]) / 255.0, float64(data[2]) / 255.0}
+	case Binary16:
+		return Color{
+			float64(binary.BigEndian.Uint16(data[0:])) / 65535.0,
+			float64(binary.BigEndian.Uint16(data[2:])) / 65535.0,
+			float64(binary.BigEndian.Uint16(data[4:])) / 65535.0,
+		}
+	}
+	return Color{
+		math.Float64frombits(binary.BigEndian.Uint64(data[0:])),
+		math.Float64frombits(binary.BigEndian.Uint64(data[8:])),
+		math.Float64frombits(binary.BigEndian.Uint64(data[16:])),
+	}
+}
@@ -0,0 +1,72 @@
+package colorful
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	col := Color{0.1, 0.25, 1.5} // Out of gamut on purpose.
+	data, err := col.MarshalBinary()
+	if err != nil || len(data) != 24 {
+		t.Fatalf("MarshalBinary() => (%v bytes, %v), want 24 bytes", len(data), err)
+	}
+	var got Color
+	if err := got.UnmarshalBinary(data); err != nil || got != col {
+		t.Errorf("UnmarshalBinary(MarshalBinary(%v)) => (%v, %v)", col, got, err)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	col := Color{1, 0.5, 0}
+	tests := []struct {
+		format BinaryFormat
+		want   []byte
+		back   Color
+	}{
+		{Binary8, []byte{0xff, 0x80, 0x00}, Color{1, 128.0 / 255.0, 0}},
+		{Binary16, []byte{0xff, 0xff, 0x80, 0x00, 0x00, 0x00}, Color{1, 32768.0 / 65535.0, 0}},
+	}
+	for i, tt := range tests {
+		got := col.AppendBinary([]byte{0x42}, tt.format)
+		if !bytes.Equal(got, append([]byte{0x42}, tt.want...)) {
+			t.Errorf("%v. AppendBinary(%v) => (%x), want 42%x", i, tt.format, got, tt.want)
+		}
+		var back Color
+		if err := back.UnmarshalBinary(tt.want); err != nil || back != tt.back {
+			t.Errorf("%v. UnmarshalBinary(%x) => (%v, %v), want %v", i, tt.want, back, err, tt.back)
+		}
+	}
+
+	var c Color
+	if err := c.UnmarshalBinary([]byte{1, 2, 3, 4}); err == nil {
+		t.Errorf("UnmarshalBinary of 4 bytes should have failed")
+	}
+}
+
+func TestAppendDecodeColors(t *testing.T) {
+	cols := []Color{{0, 0, 0}, {1, 1, 1}, {0.2, 0.4, 0.6}, {1, 0, 0.5}}
+	for _, format := range []BinaryFormat{Binary8, Binary16, Binary64} {
+		data := AppendColors(nil, cols, format)
+		if len(data) != len(cols)*int(format) {
+			t.Errorf("AppendColors(%v) => %v bytes, want %v", format, len(data), len(cols)*int(format))
+		}
+		got, err := DecodeColors(data, format)
+		if err != nil || len(got) != len(cols) {
+			t.Fatalf("DecodeColors(%v) => (%v, %v)", format, got, err)
+		}
+		for i := range cols {
+			if !got[i].AlmostEqualRgb(cols[i]) {
+				t.Errorf("DecodeColors(%v)[%v] => %v, want %v", format, i, got[i], cols[i])
+			}
+		}
+
+		if _, err := DecodeColors(data[1:], format); err == nil {
+			t.Errorf("DecodeColors(%v) of truncated data should have failed", format)
+		}
+	}
+
+	if _, err := DecodeColors(nil, 5); err == nil {
+		t.Errorf("DecodeColors with invalid format should have failed")
+	}
+}
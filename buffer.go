@@ -0,0 +1,121 @@
+// Conversions of whole buffers of interleaved pixels, as they come from
+// cameras and image decoders, without a Color per pixel. The sRGB transfer
+// function uses the lookup tables of lut.go.
+
+package colorful
+
+// A Sample is the type of the channel values of an sRGB pixel buffer, whose
+// full range maps to [0..1].
+type Sample interface {
+	~uint8 | ~uint16
+}
+
+func sampleMax[S Sample]() float32 {
+	return float32(^S(0))
+}
+
+// linearizeSample returns the linear value of the sRGB sample using the
+// lookup tables.
+func linearizeSample[S Sample](v S) float32 {
+	if sampleMax[S]() == 255 {
+		return float32(LinearizeUint8(uint8(v)))
+	}
+	return float32(LinearizeUint16(uint16(v)))
+}
+
+func delinearizeSample[S Sample](v float32) S {
+	if sampleMax[S]() == 255 {
+		return S(DelinearizeUint8(float64(v)))
+	}
+	return S(DelinearizeUint16(float64(v)))
+}
+
+// convertFromSamples converts the sRGB pixels of src into dst, which holds the
+// same number of channels per pixel as float32, by converting their linear
+// values with conv. Alpha is scaled to [0..1].
+func convertFromSamples[S Sample](dst []float32, src []S, channels int, conv func(vec3[float32]) vec3[float32]) {
+	checkBuffers(len(dst), len(src), channels)
+	max := sampleMax[S]()
+	for i := 0; i+channels <= len(src); i += channels {
+		s, d := src[i:i+channels], dst[i:i+channels]
+		v := conv(vec3[float32]{linearizeSample(s[0]), linearizeSample(s[1]), linearizeSample(s[2])})
+		d[0], d[1], d[2] = v[0], v[1], v[2]
+		if channels == 4 {
+			d[3] = float32(s[3]) / max
+		}
+	}
+}
+
+// convertToSamples is the inverse of convertFromSamples, conv converts to
+// linear values. The colors are clamped.
+func convertToSamples[S Sample](dst []S, src []float32, channels int, conv func(vec3[float32]) vec3[float32]) {
+	checkBuffers(len(dst), len(src), channels)
+	max := sampleMax[S]()
+	for i := 0; i+channels <= len(src); i += channels {
+		s, d := src[i:i+channels], dst[i:i+channels]
+		v := conv(vec3[float32]{s[0], s[1], s[2]})
+		d[0], d[1], d[2] = delinearizeSample[S](v[0]), delinearizeSample[S](v[1]), delinearizeSample[S](v[2])
+		if channels == 4 {
+			d[3] = S(clamp01f(s[3])*max + 0.5)
+		}
+	}
+}
+
+func identity32(v vec3[float32]) vec3[float32] {
+	return v
+}
+
+func linearRgbToLab32(v vec3[float32]) vec3[float32] {
+	return xyzToLab(mulVec(&linearRgbToXyzMat, v), D65)
+}
+
+func labToLinearRgb32(v vec3[float32]) vec3[float32] {
+	return mulVec(&xyzToLinearRgbMat, labToXyz(v, D65))
+}
+
+func checkBuffers(ndst, nsrc, channels int) {
+	if channels != 3 && channels != 4 {
+		panic("colorful: buffers need 3 or 4 channels per pixel")
+	}
+	if nsrc%channels != 0 || ndst < nsrc {
+		panic("colorful: buffer sizes don't match")
+	}
+}
+
+// ConvertBufferSRGBToLinear converts a buffer of interleaved sRGB pixels with
+// 3 (RGB) or 4 (RGBA) channels into the same pixels in linear RGB. The alpha
+// of RGBA pixels is kept. The destination needs to be at least as long as
+// the source, the function panics otherwise.
+func ConvertBufferSRGBToLinear[S Sample](dst []float32, src []S, channels int) {
+	convertFromSamples(dst, src, channels, identity32)
+}
+
+// ConvertBufferLinearToSRGB is the inverse of ConvertBufferSRGBToLinear.
+// Colors outside of the gamut are clamped.
+func ConvertBufferLinearToSRGB[S Sample](dst []S, src []float32, channels int) {
+	convertToSamples(dst, src, channels, identity32)
+}
+
+// ConvertBufferSRGBToLab converts a buffer of interleaved sRGB pixels into
+// CIE L*a*b* using D65 as reference white, see ConvertBufferSRGBToLinear.
+func ConvertBufferSRGBToLab[S Sample](dst []float32, src []S, channels int) {
+	convertFromSamples(dst, src, channels, linearRgbToLab32)
+}
+
+// ConvertBufferLabToSRGB is the inverse of ConvertBufferSRGBToLab.
+// Colors outside of the gamut are clamped.
+func ConvertBufferLabToSRGB[S Sample](dst []S, src []float32, channels int) {
+	convertToSamples(dst, src, channels, labToLinearRgb32)
+}
+
+// ConvertBufferSRGBToOkLab converts a buffer of interleaved sRGB pixels into
+// OkLab, see ConvertBufferSRGBToLinear.
+func ConvertBufferSRGBToOkLab[S Sample](dst []float32, src []S, channels int) {
+	convertFromSamples(dst, src, channels, linearRgbToOkLab[float32])
+}
+
+// ConvertBufferOkLabToSRGB is the inverse of ConvertBufferSRGBToOkLab.
+// Colors outside of the gamut are clamped.
+func ConvertBufferOkLabToSRGB[S Sample](dst []S, src []float32, channels int) {
+	convertToSamples(dst, src, channels, okLabToLinearRgb[float32])
+}
@@ -0,0 +1,88 @@
+package colorful
+
+import (
+	"testing"
+)
+
+func TestConvertBuffer8(t *testing.T) {
+	src := []uint8{255, 0, 0, 255, 0, 128, 255, 64, 12, 200, 100, 0}
+	lab := make([]float32, len(src))
+	ConvertBufferSRGBToLab(lab, src, 4)
+
+	for i := 0; i < len(src); i += 4 {
+		c := Color{float64(src[i]) / 255, float64(src[i+1]) / 255, float64(src[i+2]) / 255}
+		l, a, b := c.Lab()
+		if !almosteq32(lab[i], l) || !almosteq32(lab[i+1], a) || !almosteq32(lab[i+2], b) || !almosteq32(lab[i+3], float64(src[i+3])/255) {
+			t.Errorf("%v. ConvertBufferSRGBToLab => %v, want (%v, %v, %v)", i/4, lab[i:i+4], l, a, b)
+		}
+	}
+
+	back := make([]uint8, len(src))
+	ConvertBufferLabToSRGB(back, lab, 4)
+	for i := range src {
+		if back[i] != src[i] {
+			t.Errorf("ConvertBufferLabToSRGB(ConvertBufferSRGBToLab(%v)) => %v", src, back)
+			break
+		}
+	}
+}
+
+func TestConvertBuffer16(t *testing.T) {
+	src := []uint16{65535, 0, 0, 0, 32768, 65535, 1000, 20000, 40000}
+	for _, conv := range []struct {
+		name string
+		to   func([]float32, []uint16, int)
+		from func([]uint16, []float32, int)
+	}{
+		{"Linear", ConvertBufferSRGBToLinear[uint16], ConvertBufferLinearToSRGB[uint16]},
+		{"Lab", ConvertBufferSRGBToLab[uint16], ConvertBufferLabToSRGB[uint16]},
+		{"OkLab", ConvertBufferSRGBToOkLab[uint16], ConvertBufferOkLabToSRGB[uint16]},
+	} {
+		f := make([]float32, len(src))
+		conv.to(f, src, 3)
+		back := make([]uint16, len(src))
+		conv.from(back, f, 3)
+		for i := range src {
+			if d := int(back[i]) - int(src[i]); d < -2 || d > 2 {
+				t.Errorf("%v round-trip of %v => %v", conv.name, src, back)
+				break
+			}
+		}
+	}
+
+	f := make([]float32, 3)
+	ConvertBufferSRGBToOkLab(f, []uint16{0, 32768, 65535}, 3)
+	l, a, b := Color{0, 32768.0 / 65535, 1}.OkLab()
+	if !almosteq32(f[0], l) || !almosteq32(f[1], a) || !almosteq32(f[2], b) {
+		t.Errorf("ConvertBufferSRGBToOkLab => %v, want (%v, %v, %v)", f, l, a, b)
+	}
+}
+
+func TestConvertBufferPanics(t *testing.T) {
+	for i, fn := range []func(){
+		func() { ConvertBufferSRGBToLab(make([]float32, 6), make([]uint8, 6), 2) },
+		func() { ConvertBufferSRGBToLab(make([]float32, 6), make([]uint8, 7), 3) },
+		func() { ConvertBufferSRGBToLab(make([]float32, 3), make([]uint8, 6), 3) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v. didn't panic", i)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func BenchmarkConvertBufferSRGBToLab(bench *testing.B) {
+	src := make([]uint8, 4*1024)
+	for i := range src {
+		src[i] = uint8(i * 7)
+	}
+	dst := make([]float32, len(src))
+	bench.SetBytes(int64(len(src)))
+	for n := 0; n < bench.N; n++ {
+		ConvertBufferSRGBToLab(dst, src, 4)
+	}
+}
@@ -0,0 +1,77 @@
+// A color which remembers its representations in other spaces.
+
+package colorful
+
+import "sync"
+
+// A CachedColor is a Color which computes its XYZ, Lab, Luv and HCL
+// representations (D65) only once, on first use, which pays off when the
+// same colors are compared many times, such as in palette searches. It is
+// safe for concurrent use, but must not be copied after first use.
+//
+// It embeds the Color, so all other methods are available as well.
+type CachedColor struct {
+	Color
+
+	xyzOnce, labOnce, luvOnce sync.Once
+	xyz, lab, luv             [3]float64
+	hcl                       [2]float64
+}
+
+// NewCachedColor wraps the color for caching its representations.
+func NewCachedColor(col Color) *CachedColor {
+	return &CachedColor{Color: col}
+}
+
+// Xyz returns the color in CIE XYZ space, computing it only once.
+func (c *CachedColor) Xyz() (x, y, z float64) {
+	c.xyzOnce.Do(func() {
+		c.xyz[0], c.xyz[1], c.xyz[2] = c.Color.Xyz()
+	})
+	return c.xyz[0], c.xyz[1], c.xyz[2]
+}
+
+// Lab returns the color in CIE L*a*b* space, computing it only once.
+func (c *CachedColor) Lab() (l, a, b float64) {
+	c.labOnce.Do(func() {
+		c.lab[0], c.lab[1], c.lab[2] = XyzToLab(c.Xyz())
+		c.hcl[0], c.hcl[1], _ = LabToHcl(c.lab[0], c.lab[1], c.lab[2])
+	})
+	return c.lab[0], c.lab[1], c.lab[2]
+}
+
+// Luv returns the color in CIE L*u*v* space, computing it only once.
+func (c *CachedColor) Luv() (l, u, v float64) {
+	c.luvOnce.Do(func() {
+		c.luv[0], c.luv[1], c.luv[2] = XyzToLuv(c.Xyz())
+	})
+	return c.luv[0], c.luv[1], c.luv[2]
+}
+
+// Hcl returns the color in HCL space, computing it only once.
+func (c *CachedColor) Hcl() (h, cc, l float64) {
+	l, _, _ = c.Lab()
+	return c.hcl[0], c.hcl[1], l
+}
+
+// DistanceLab is like Color.DistanceLab, using the cached representations.
+func (c1 *CachedColor) DistanceLab(c2 *CachedColor) float64 {
+	l1, a1, b1 := c1.Lab()
+	l2, a2, b2 := c2.Lab()
+	return vec3[float64]{l1, a1, b1}.dist(vec3[float64]{l2, a2, b2})
+}
+
+// DistanceLuv is like Color.DistanceLuv, using the cached representations.
+func (c1 *CachedColor) DistanceLuv(c2 *CachedColor) float64 {
+	l1, u1, v1 := c1.Luv()
+	l2, u2, v2 := c2.Luv()
+	return vec3[float64]{l1, u1, v1}.dist(vec3[float64]{l2, u2, v2})
+}
+
+// DistanceCIEDE2000 is like Color.DistanceCIEDE2000, using the cached
+// representations.
+func (c1 *CachedColor) DistanceCIEDE2000(c2 *CachedColor) float64 {
+	l1, a1, b1 := c1.Lab()
+	l2, a2, b2 := c2.Lab()
+	return labDistanceCIEDE2000klch(l1, a1, b1, l2, a2, b2, 1.0, 1.0, 1.0)
+}
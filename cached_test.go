@@ -0,0 +1,53 @@
+package colorful
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCachedColor(t *testing.T) {
+	for i, tt := range vals {
+		c := NewCachedColor(tt.c)
+		for j := 0; j < 2; j++ {
+			if l, a, b := c.Lab(); !almosteq(l, tt.lab[0]) || !almosteq(a, tt.lab[1]) || !almosteq(b, tt.lab[2]) {
+				t.Errorf("%v. CachedColor.Lab() => (%v, %v, %v), want %v", i, l, a, b, tt.lab)
+			}
+			if l, u, v := c.Luv(); !almosteq(l, tt.luv[0]) || !almosteq(u, tt.luv[1]) || !almosteq(v, tt.luv[2]) {
+				t.Errorf("%v. CachedColor.Luv() => (%v, %v, %v), want %v", i, l, u, v, tt.luv)
+			}
+			if h, cc, l := c.Hcl(); !almosteq(h, tt.hcl[0]) || !almosteq(cc, tt.hcl[1]) || !almosteq(l, tt.hcl[2]) {
+				t.Errorf("%v. CachedColor.Hcl() => (%v, %v, %v), want %v", i, h, cc, l, tt.hcl)
+			}
+			if x, y, z := c.Xyz(); !almosteq(x, tt.xyz[0]) || !almosteq(y, tt.xyz[1]) || !almosteq(z, tt.xyz[2]) {
+				t.Errorf("%v. CachedColor.Xyz() => (%v, %v, %v), want %v", i, x, y, z, tt.xyz)
+			}
+		}
+		if h := c.Hex(); h != tt.hex {
+			t.Errorf("%v. CachedColor.Hex() => %v, want %v", i, h, tt.hex)
+		}
+	}
+
+	c1, c2 := NewCachedColor(Color{0.2, 0.5, 0.9}), NewCachedColor(Color{0.9, 0.4, 0.1})
+	if d1, d2 := c1.DistanceLab(c2), c1.Color.DistanceLab(c2.Color); d1 != d2 {
+		t.Errorf("CachedColor.DistanceLab => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.DistanceLuv(c2), c1.Color.DistanceLuv(c2.Color); d1 != d2 {
+		t.Errorf("CachedColor.DistanceLuv => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.DistanceCIEDE2000(c2), c1.Color.DistanceCIEDE2000(c2.Color); d1 != d2 {
+		t.Errorf("CachedColor.DistanceCIEDE2000 => %v, want %v", d1, d2)
+	}
+
+	// Concurrent first uses, for the race detector.
+	c := NewCachedColor(Color{0.3, 0.6, 0.1})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Hcl()
+			c.Luv()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,201 @@
+// The CAM16 color appearance model, predicting how colors look under given
+// viewing conditions, and the appearance attributes derived from it.
+// https://doi.org/10.1002/col.22131
+
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Surround is the luminance of the field around the viewed colors, relative
+// to their white.
+type Surround int
+
+const (
+	// SurroundAverage is a surface color lit like its surroundings, such as
+	// a print in an office.
+	SurroundAverage Surround = iota
+	// SurroundDim is a display in a dim room, such as a television.
+	SurroundDim
+	// SurroundDark is a projection in a dark room.
+	SurroundDark
+)
+
+// The factors F, c and Nc of each surround.
+var surroundFactors = [...][3]float64{
+	{1.0, 0.69, 1.0},
+	{0.9, 0.59, 0.9},
+	{0.8, 0.525, 0.8},
+}
+
+// ViewingConditions describe under which circumstances colors are seen, which
+// the appearance of CAM16 depends on. The zero value means a color of sRGB
+// seen in a typically lit room.
+type ViewingConditions struct {
+	// WhitePoint is the white the viewer is adapted to. The zero value means
+	// D65.
+	WhitePoint [3]float64
+	// AdaptingLuminance is the luminance of the adapting field in cd/m²,
+	// usually a fifth of that of white. Zero means 64, that of a room lit
+	// with 1000 lux.
+	AdaptingLuminance float64
+	// Background is the relative luminance of the background in percent of
+	// that of white. Zero means 20.
+	Background float64
+	// Surround is the luminance around the viewed colors, the zero value
+	// means SurroundAverage.
+	Surround Surround
+	// DiscountIlluminant assumes the viewer is fully adapted to the white,
+	// as for surface colors, rather than estimating the degree of adaptation
+	// from the luminance.
+	DiscountIlluminant bool
+}
+
+// The matrix from XYZ to the cone responses of CAM16.
+var m16 = mat3{
+	{0.401288, 0.650173, -0.051461},
+	{-0.250268, 1.204414, 0.045854},
+	{-0.002079, 0.048952, 0.953127},
+}
+
+// cam16Env holds the values derived from the viewing conditions which every
+// conversion needs.
+type cam16Env struct {
+	dRgb           [3]float64
+	fl, n, z, nbb  float64
+	c, nc, aw, fl4 float64
+}
+
+// adaptedResponse applies the post-adaptation compression of the cone
+// responses.
+func (e *cam16Env) adaptedResponse(v float64) float64 {
+	p := math.Pow(e.fl*math.Abs(v)/100.0, 0.42)
+	return math.Copysign(400.0*p/(p+27.13), v) + 0.1
+}
+
+func (vc ViewingConditions) env() *cam16Env {
+	wp := vc.WhitePoint
+	if wp == ([3]float64{}) {
+		wp = D65
+	}
+	la := vc.AdaptingLuminance
+	if la <= 0 {
+		la = 64
+	}
+	yb := vc.Background
+	if yb <= 0 {
+		yb = 20
+	}
+	if vc.Surround < SurroundAverage || vc.Surround > SurroundDark {
+		panic(fmt.Sprintf("colorful: unknown surround %v", int(vc.Surround)))
+	}
+	f := surroundFactors[vc.Surround]
+
+	e := &cam16Env{c: f[1], nc: f[2]}
+	d := 1.0
+	if !vc.DiscountIlluminant {
+		d = clamp01(f[0] * (1 - math.Exp((-la-42)/92)/3.6))
+	}
+	k := 1 / (5*la + 1)
+	k4 := k * k * k * k
+	e.fl = 0.2*k4*5*la + 0.1*(1-k4)*(1-k4)*math.Cbrt(5*la)
+	e.fl4 = math.Pow(e.fl, 0.25)
+	e.n = yb / (100 * wp[1])
+	e.z = 1.48 + math.Sqrt(e.n)
+	e.nbb = 0.725 * math.Pow(e.n, -0.2)
+
+	var rgbw [3]float64
+	rgbw[0], rgbw[1], rgbw[2] = m16.mul(100*wp[0], 100*wp[1], 100*wp[2])
+	var aw [3]float64
+	for i := range rgbw {
+		e.dRgb[i] = d*100*wp[1]/rgbw[i] + 1 - d
+		aw[i] = e.adaptedResponse(e.dRgb[i] * rgbw[i])
+	}
+	e.aw = (2*aw[0] + aw[1] + 0.05*aw[2] - 0.305) * e.nbb
+	return e
+}
+
+// CAM16 holds the appearance correlates of a color under some viewing
+// conditions.
+type CAM16 struct {
+	// J is the lightness, from 0 for black to 100 for white.
+	J float64
+	// Q is the brightness, which unlike lightness grows with the luminance
+	// of the viewing conditions.
+	Q float64
+	// C is the chroma, relative to the brightness of white.
+	C float64
+	// M is the colorfulness, which unlike chroma grows with the luminance of
+	// the viewing conditions.
+	M float64
+	// S is the saturation, the colorfulness relative to the brightness.
+	S float64
+	// Hue is the hue angle in degrees.
+	Hue float64
+}
+
+// CAM16 returns the appearance of the color under the viewing conditions.
+func (col Color) CAM16(vc ViewingConditions) CAM16 {
+	e := vc.env()
+
+	x, y, z := col.Xyz()
+	r, g, b := m16.mul(100*x, 100*y, 100*z)
+	ra := e.adaptedResponse(e.dRgb[0] * r)
+	ga := e.adaptedResponse(e.dRgb[1] * g)
+	ba := e.adaptedResponse(e.dRgb[2] * b)
+
+	a := ra - 12*ga/11 + ba/11
+	bb := (ra + ga - 2*ba) / 9
+	h := math.Mod(math.Atan2(bb, a)*180/math.Pi+360, 360)
+
+	var cam CAM16
+	cam.Hue = h
+	achromatic := (2*ra + ga + 0.05*ba - 0.305) * e.nbb
+	cam.J = 100 * math.Pow(math.Max(achromatic, 0)/e.aw, e.c*e.z)
+	cam.Q = 4 / e.c * math.Sqrt(cam.J/100) * (e.aw + 4) * e.fl4
+
+	et := (math.Cos(h*math.Pi/180+2) + 3.8) / 4
+	t := 50000.0 / 13.0 * e.nc * e.nbb * et * math.Hypot(a, bb) / (ra + ga + 21*ba/20)
+	cam.C = math.Pow(t, 0.9) * math.Sqrt(cam.J/100) * math.Pow(1.64-math.Pow(0.29, e.n), 0.73)
+	cam.M = cam.C * e.fl4
+	if cam.Q > 0 {
+		cam.S = 100 * math.Sqrt(cam.M/cam.Q)
+	}
+	return cam
+}
+
+/// Composite attributes ///
+////////////////////////////
+
+// The following follow the definitions of Berns for CIELAB, in terms of
+// CAM16 lightness and colorfulness instead.
+// https://doi.org/10.1002/col.21872
+
+// ab returns the Cartesian colorfulness coordinates of the color.
+func (cam CAM16) ab() (a, b float64) {
+	sin, cos := math.Sincos(cam.Hue * math.Pi / 180)
+	return cam.M * cos, cam.M * sin
+}
+
+// Vividness grows with both lightness and colorfulness, as the distance from
+// black. It ranks colors like "vivid" does in everyday language, such as
+// when picking the most striking of several product images.
+func (cam CAM16) Vividness() float64 {
+	return math.Hypot(cam.J, cam.M)
+}
+
+// Depth grows with colorfulness and darkness, as the distance from white,
+// such that deep colors are dark and colorful.
+func (cam CAM16) Depth() float64 {
+	return math.Hypot(100-cam.J, cam.M)
+}
+
+// Clarity is how much the color stands out from the background bg, as the
+// distance between the two, both in lightness and colorfulness.
+func (cam CAM16) Clarity(bg CAM16) float64 {
+	a1, b1 := cam.ab()
+	a2, b2 := bg.ab()
+	return math.Sqrt(sq(cam.J-bg.J) + sq(a1-a2) + sq(b1-b2))
+}
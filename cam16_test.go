@@ -0,0 +1,68 @@
+package colorful
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCAM16(t *testing.T) {
+	// The example of the colour-science library.
+	vc := ViewingConditions{
+		WhitePoint:        [3]float64{0.9505, 1, 1.0888},
+		AdaptingLuminance: 318.31,
+		Background:        20,
+	}
+	got := Xyz(0.1901, 0.2, 0.2178).CAM16(vc)
+	want := CAM16{J: 41.731207905126638, Q: 195.37170899281900, C: 0.10335573870899, M: 0.10743677233582, S: 2.34501507297868, Hue: 217.06795976739301}
+	if !almosteq_eps(got.J, want.J, 1e-9) || !almosteq_eps(got.Q, want.Q, 1e-9) || !almosteq_eps(got.C, want.C, 1e-6) ||
+		!almosteq_eps(got.M, want.M, 1e-6) || !almosteq_eps(got.S, want.S, 1e-6) || !almosteq_eps(got.Hue, want.Hue, 1e-6) {
+		t.Errorf("CAM16(%+v) => %+v, want %+v", vc, got, want)
+	}
+
+	// White is as light as it gets under any conditions. Only with complete
+	// adaptation is it colorless too.
+	for _, vc := range []ViewingConditions{{}, {Surround: SurroundDark, AdaptingLuminance: 10}, {DiscountIlluminant: true}} {
+		white := Color{1, 1, 1}.CAM16(vc)
+		if math.Abs(white.J-100) > 1e-3 || vc.DiscountIlluminant && white.C > 0.1 {
+			t.Errorf("white.CAM16(%+v) => %+v, want J 100", vc, white)
+		}
+	}
+
+	// Brightness and colorfulness grow with luminance, lightness and chroma
+	// barely do.
+	red := Color{1, 0, 0}
+	dim, bright := red.CAM16(ViewingConditions{AdaptingLuminance: 10}), red.CAM16(ViewingConditions{AdaptingLuminance: 1000})
+	if bright.Q <= dim.Q || bright.M <= dim.M {
+		t.Errorf("red.CAM16 => Q %v and M %v at 1000 cd/m², want more than %v and %v at 10", bright.Q, bright.M, dim.Q, dim.M)
+	}
+}
+
+func TestCAM16Composites(t *testing.T) {
+	vc := ViewingConditions{}
+	red, pink, maroon := MustHex("#e02020").CAM16(vc), MustHex("#f0a0a0").CAM16(vc), MustHex("#601010").CAM16(vc)
+	gray := MustHex("#808080").CAM16(vc)
+
+	if !(red.Vividness() > maroon.Vividness() && red.Vividness() > gray.Vividness()) {
+		t.Errorf("Vividness => red %v, maroon %v, gray %v, want red the most vivid", red.Vividness(), maroon.Vividness(), gray.Vividness())
+	}
+	if !(maroon.Depth() > pink.Depth() && red.Depth() > pink.Depth()) {
+		t.Errorf("Depth => maroon %v, red %v, pink %v, want pink the least deep", maroon.Depth(), red.Depth(), pink.Depth())
+	}
+	if got := gray.Clarity(gray); got != 0 {
+		t.Errorf("Clarity of a color on itself => %v, want 0", got)
+	}
+	if !(red.Clarity(gray) > pink.Clarity(gray)) {
+		t.Errorf("Clarity on gray => red %v, pink %v, want red to stand out more", red.Clarity(gray), pink.Clarity(gray))
+	}
+}
+
+func TestCAM16UnknownSurround(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.HasPrefix(fmt.Sprint(r), "colorful: ") {
+			t.Errorf("unknown surround => panic %v, want a colorful: panic", r)
+		}
+	}()
+	Color{0.5, 0.5, 0.5}.CAM16(ViewingConditions{Surround: 3})
+}
@@ -0,0 +1,70 @@
+// Constructors which report colors outside of the RGB gamut instead of
+// silently producing invalid RGB values.
+
+package colorful
+
+// checkedTolerance is how far out of range the values of a checked color may
+// be, which is half of an 8 bit step and allows for rounded constants.
+const checkedTolerance = 0.5 / 255.0
+
+func checked(col Color) (Color, error) {
+	inRange := func(v float64) bool {
+		return -checkedTolerance <= v && v <= 1.0+checkedTolerance
+	}
+	if inRange(col.R) && inRange(col.G) && inRange(col.B) {
+		return col.Clamped(), nil
+	}
+	return col, col.Validate()
+}
+
+// LabChecked is like Lab, but returns an ErrInvalidColor if the color lies
+// outside of the RGB gamut. The unclamped color is returned along with the
+// error, such that the caller can choose how to bring it into the gamut, for
+// example using Clamped or ClampedHcl. Colors which are out of range by less
+// than half of an 8 bit step, as rounded constants often are, are clamped
+// without an error.
+func LabChecked(l, a, b float64) (Color, error) {
+	return checked(Lab(l, a, b))
+}
+
+// MustLab is like LabChecked, but panics if the color lies outside of the
+// RGB gamut. It is intended for defining constant colors.
+func MustLab(l, a, b float64) Color {
+	col, err := LabChecked(l, a, b)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// LuvChecked is like Luv, but also returns an ErrInvalidColor if the color
+// lies outside of the RGB gamut, see LabChecked.
+func LuvChecked(l, u, v float64) (Color, error) {
+	return checked(Luv(l, u, v))
+}
+
+// MustLuv is like LuvChecked, but panics if the color lies outside of the
+// RGB gamut.
+func MustLuv(l, u, v float64) Color {
+	col, err := LuvChecked(l, u, v)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// HclChecked is like Hcl, but also returns an ErrInvalidColor if the color
+// lies outside of the RGB gamut, see LabChecked.
+func HclChecked(h, c, l float64) (Color, error) {
+	return checked(Hcl(h, c, l))
+}
+
+// MustHcl is like HclChecked, but panics if the color lies outside of the
+// RGB gamut.
+func MustHcl(h, c, l float64) Color {
+	col, err := HclChecked(h, c, l)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
@@ -0,0 +1,46 @@
+package colorful
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckedConstructors(t *testing.T) {
+	for i, tt := range vals {
+		if c, err := LabChecked(tt.lab[0], tt.lab[1], tt.lab[2]); err != nil || !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. LabChecked(%v) => (%v, %v), want %v", i, tt.lab, c, err, tt.c)
+		}
+		if c, err := LuvChecked(tt.luv[0], tt.luv[1], tt.luv[2]); err != nil || !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. LuvChecked(%v) => (%v, %v), want %v", i, tt.luv, c, err, tt.c)
+		}
+		if c, err := HclChecked(tt.hcl[0], tt.hcl[1], tt.hcl[2]); err != nil || !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. HclChecked(%v) => (%v, %v), want %v", i, tt.hcl, c, err, tt.c)
+		}
+	}
+
+	checked := []struct {
+		name string
+		fn   func(float64, float64, float64) (Color, error)
+		must func(float64, float64, float64) Color
+		args [3]float64
+	}{
+		{"Lab", LabChecked, MustLab, [3]float64{0.5, 1, 0}},
+		{"Luv", LuvChecked, MustLuv, [3]float64{0.5, 0, -2}},
+		{"Hcl", HclChecked, MustHcl, [3]float64{120, 1.5, 0.8}},
+	}
+	for _, tt := range checked {
+		c, err := tt.fn(tt.args[0], tt.args[1], tt.args[2])
+		if !errors.As(err, new(ErrInvalidColor)) || c.IsValid() {
+			t.Errorf("%vChecked(%v) => (%v, %v), want an ErrInvalidColor", tt.name, tt.args, c, err)
+		}
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Must%v(%v) didn't panic", tt.name, tt.args)
+				}
+			}()
+			tt.must(tt.args[0], tt.args[1], tt.args[2])
+		}()
+	}
+}
@@ -0,0 +1,125 @@
+// Three-dimensional color lookup tables, which approximate any color
+// transform by sampling it on a grid, and the HALD image format they are
+// commonly exchanged in.
+// http://www.quelsolaar.com/technology/clut.html
+
+package colorful
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// A CLUT is a color transform sampled on a cube of Size³ evenly spaced RGB
+// values from black to white. Colors in between are interpolated
+// trilinearly, so 33 or 64 samples per side are usually indistinguishable
+// from the transform itself.
+type CLUT struct {
+	Size int
+	// Table holds the transformed colors, red varying fastest, then green,
+	// then blue, as in HALD images.
+	Table []Color
+}
+
+// NewCLUT samples the transform fn on a cube of size³ colors. size needs to be
+// at least 2.
+func NewCLUT(size int, fn func(Color) Color) *CLUT {
+	if size < 2 {
+		panic("colorful: a CLUT needs at least 2 samples per side")
+	}
+	l := &CLUT{Size: size, Table: make([]Color, size*size*size)}
+	step := 1.0 / float64(size-1)
+	for b := 0; b < size; b++ {
+		for g := 0; g < size; g++ {
+			for r := 0; r < size; r++ {
+				c := Color{float64(r) * step, float64(g) * step, float64(b) * step}
+				if fn != nil {
+					c = fn(c)
+				}
+				l.Table[(b*size+g)*size+r] = c
+			}
+		}
+	}
+	return l
+}
+
+// At returns the transformed color of col, interpolating between the eight
+// surrounding samples. Colors out of the gamut are clamped first.
+func (l *CLUT) At(col Color) Color {
+	n := l.Size - 1
+	var i [3]int
+	var f [3]float64
+	for k, v := range [3]float64{col.R, col.G, col.B} {
+		v = clamp01(v) * float64(n)
+		i[k] = int(v)
+		if i[k] >= n {
+			i[k] = n - 1
+		}
+		f[k] = v - float64(i[k])
+	}
+
+	at := func(r, g, b int) Color {
+		return l.Table[((i[2]+b)*l.Size+i[1]+g)*l.Size+i[0]+r]
+	}
+	c00 := at(0, 0, 0).BlendRgb(at(1, 0, 0), f[0])
+	c10 := at(0, 1, 0).BlendRgb(at(1, 1, 0), f[0])
+	c01 := at(0, 0, 1).BlendRgb(at(1, 0, 1), f[0])
+	c11 := at(0, 1, 1).BlendRgb(at(1, 1, 1), f[0])
+	return c00.BlendRgb(c10, f[1]).BlendRgb(c01.BlendRgb(c11, f[1]), f[2])
+}
+
+// Image returns a copy of img with the CLUT applied to every pixel, keeping
+// the alpha channel.
+func (l *CLUT) Image(img image.Image) *image.NRGBA {
+	return MapImage(img, l.At)
+}
+
+/// HALD images ///
+///////////////////
+
+// ReadHald reads a CLUT from a HALD image, such as those of ImageMagick's
+// hald: and -hald-clut. A HALD image of level L is a square of L³ pixels per
+// side holding a CLUT of size L², read row by row. 16 bit images keep their
+// precision.
+func ReadHald(img image.Image) (*CLUT, error) {
+	b := img.Bounds()
+	w := b.Dx()
+	level := int(math.Round(math.Cbrt(float64(w))))
+	if w != b.Dy() || level < 2 || level*level*level != w {
+		return nil, fmt.Errorf("color: a %vx%v image is no HALD image", w, b.Dy())
+	}
+
+	size := level * level
+	l := &CLUT{Size: size, Table: make([]Color, size*size*size)}
+	for i := range l.Table {
+		c := color.NRGBA64Model.Convert(img.At(b.Min.X+i%w, b.Min.Y+i/w)).(color.NRGBA64)
+		l.Table[i] = Color{float64(c.R) / 65535.0, float64(c.G) / 65535.0, float64(c.B) / 65535.0}
+	}
+	return l, nil
+}
+
+// Hald returns the CLUT as a 16 bit HALD image, which keeps its precision
+// when saved as PNG. This needs its size to be the square of the HALD level,
+// such as 16, 36 or 64. To create a CLUT for editing elsewhere, such as in
+// a photo editor, write the one of the identity, NewCLUT(64, nil).
+func (l *CLUT) Hald() (*image.NRGBA64, error) {
+	level := int(math.Round(math.Sqrt(float64(l.Size))))
+	if level*level != l.Size {
+		return nil, fmt.Errorf("color: a CLUT of size %v has no HALD image, the size needs to be a square", l.Size)
+	}
+
+	w := level * level * level
+	img := image.NewNRGBA64(image.Rect(0, 0, w, w))
+	for i, c := range l.Table {
+		c = c.Clamped()
+		img.SetNRGBA64(i%w, i/w, color.NRGBA64{
+			uint16(c.R*65535.0 + 0.5),
+			uint16(c.G*65535.0 + 0.5),
+			uint16(c.B*65535.0 + 0.5),
+			0xffff,
+		})
+	}
+	return img, nil
+}
@@ -0,0 +1,78 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestCLUT(t *testing.T) {
+	identity := NewCLUT(5, nil)
+	for _, c := range randomColors(50) {
+		if got := identity.At(c); !got.AlmostEqualRgb(c) {
+			t.Errorf("identity.At(%v) => %v", c, got)
+		}
+	}
+
+	// A smooth transform is approximated closely.
+	warmer := func(c Color) Color { return c.AdjustTemperature(1000).Clamped() }
+	l := NewCLUT(33, warmer)
+	for _, c := range randomColors(50) {
+		if d := l.At(c).DistanceCIEDE2000(warmer(c)); d > 0.01 {
+			t.Errorf("CLUT.At(%v) => %v, want %v", c, l.At(c), warmer(c))
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{200, 100, 50, 128})
+	if got, want := l.Image(img).NRGBAAt(0, 0), l.At(Color{200 / 255.0, 100 / 255.0, 50 / 255.0}); got.A != 128 || got.R != uint8(want.R*255+0.5) {
+		t.Errorf("CLUT.Image => %v, want %v with alpha 128", got, want)
+	}
+}
+
+func TestHald(t *testing.T) {
+	l := NewCLUT(16, Color.Complement)
+	img, err := l.Hald()
+	if err != nil {
+		t.Fatalf("Hald() => %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 64 || b.Dy() != 64 {
+		t.Errorf("Hald() => %v image, want 64x64", b)
+	}
+	back, err := ReadHald(img)
+	if err != nil {
+		t.Fatalf("ReadHald() => %v", err)
+	}
+	if back.Size != l.Size {
+		t.Fatalf("ReadHald() => size %v, want %v", back.Size, l.Size)
+	}
+	for i, c := range l.Table {
+		c = c.Clamped()
+		if got := back.Table[i]; math.Abs(got.R-c.R) > 1e-5 || math.Abs(got.G-c.G) > 1e-5 || math.Abs(got.B-c.B) > 1e-5 {
+			t.Errorf("ReadHald(Hald()) => %v at %v, want %v", got, i, c)
+		}
+	}
+
+	// An 8 bit identity HALD image of level 2, as ImageMagick writes it.
+	hald := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for i := 0; i < 64; i++ {
+		hald.SetNRGBA(i%8, i/8, color.NRGBA{uint8(i % 4 * 85), uint8(i / 4 % 4 * 85), uint8(i / 16 * 85), 255})
+	}
+	identity, err := ReadHald(hald)
+	if err != nil {
+		t.Fatalf("ReadHald(identity) => %v", err)
+	}
+	for _, c := range randomColors(20) {
+		if got := identity.At(c); !got.AlmostEqualRgb(c) {
+			t.Errorf("ReadHald(identity).At(%v) => %v", c, got)
+		}
+	}
+
+	if _, err := ReadHald(image.NewNRGBA(image.Rect(0, 0, 10, 10))); err == nil {
+		t.Errorf("ReadHald(10x10) => no error")
+	}
+	if _, err := NewCLUT(17, nil).Hald(); err == nil {
+		t.Errorf("CLUT of size 17 .Hald() => no error")
+	}
+}
@@ -0,0 +1,223 @@
+// A float32 variant of Color, for per-pixel work where memory bandwidth and
+// cache size matter, such as on mobile and WASM targets.
+
+package colorful
+
+import "math"
+
+// A Color32 is a Color stored with float32 precision, which takes half the
+// memory. The conversions and blends mirror those of Color, but compute with
+// float32 wherever Go's math package allows, which still is plenty precise
+// for 8 or 16 bit per channel output.
+type Color32 struct {
+	R, G, B float32
+}
+
+// Color32 returns the color with float32 precision.
+func (col Color) Color32() Color32 {
+	return Color32{float32(col.R), float32(col.G), float32(col.B)}
+}
+
+// Color returns the color with float64 precision.
+func (c Color32) Color() Color {
+	return Color{float64(c.R), float64(c.G), float64(c.B)}
+}
+
+// RGBA implements the Go color.Color interface.
+func (c Color32) RGBA() (r, g, b, a uint32) {
+	return c.Color().RGBA()
+}
+
+// IsValid checks whether the color exists in RGB space, i.e. all values are in [0..1].
+func (c Color32) IsValid() bool {
+	return 0.0 <= c.R && c.R <= 1.0 &&
+		0.0 <= c.G && c.G <= 1.0 &&
+		0.0 <= c.B && c.B <= 1.0
+}
+
+// Clamped clamps each value to [0..1].
+func (c Color32) Clamped() Color32 {
+	return Color32{clamp01f(c.R), clamp01f(c.G), clamp01f(c.B)}
+}
+
+func clamp01f(v float32) float32 {
+	if v < 0.0 {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return v
+}
+
+// Hex returns the hex "html" representation of the color, as in #ff0080.
+func (c Color32) Hex() string {
+	return c.Color().Hex()
+}
+
+/// Linear ///
+//////////////
+
+func (c Color32) vec() vec3[float32] {
+	return vec3[float32]{c.R, c.G, c.B}
+}
+
+func color32(v vec3[float32]) Color32 {
+	return Color32{v[0], v[1], v[2]}
+}
+
+func (c Color32) linearRgb() vec3[float32] {
+	return c.vec().apply(linearizeT[float32])
+}
+
+func linearRgb32(v vec3[float32]) Color32 {
+	return color32(v.apply(delinearizeT[float32]))
+}
+
+// LinearRgb converts the color into the linear RGB space.
+func (c Color32) LinearRgb() (r, g, b float32) {
+	return c.linearRgb().split()
+}
+
+// LinearRgb32 creates an sRGB color out of the given linear RGB color.
+func LinearRgb32(r, g, b float32) Color32 {
+	return linearRgb32(vec3[float32]{r, g, b})
+}
+
+// FastLinearRgb is like Color.FastLinearRgb: much faster than LinearRgb, since
+// it doesn't need any float64 math at all, but only good for valid colors.
+func (c Color32) FastLinearRgb() (r, g, b float32) {
+	return c.vec().apply(linearizeFastT[float32]).split()
+}
+
+// BlendLinearRgb blends two colors in the linear RGB color-space.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color32) BlendLinearRgb(c2 Color32, t float32) Color32 {
+	return linearRgb32(c1.linearRgb().lerp(c2.linearRgb(), t))
+}
+
+// BlendRgb blends two colors in the sRGB color-space, see Color.BlendRgb.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color32) BlendRgb(c2 Color32, t float32) Color32 {
+	return color32(c1.vec().lerp(c2.vec(), t))
+}
+
+/// XYZ ///
+///////////
+
+func (c Color32) xyz() vec3[float32] {
+	return mulVec(&linearRgbToXyzMat, c.linearRgb())
+}
+
+func xyz32(v vec3[float32]) Color32 {
+	return linearRgb32(mulVec(&xyzToLinearRgbMat, v))
+}
+
+// Xyz converts the color to CIE XYZ space (D65).
+func (c Color32) Xyz() (x, y, z float32) {
+	return c.xyz().split()
+}
+
+// Xyz32 generates a color by using data given in CIE XYZ space (D65).
+func Xyz32(x, y, z float32) Color32 {
+	return xyz32(vec3[float32]{x, y, z})
+}
+
+/// L*a*b* ///
+//////////////
+
+func (c Color32) lab() vec3[float32] {
+	return xyzToLab(c.xyz(), D65)
+}
+
+func lab32(v vec3[float32]) Color32 {
+	return xyz32(labToXyz(v, D65))
+}
+
+// Lab converts the color to CIE L*a*b* space using D65 as reference white.
+func (c Color32) Lab() (l, a, b float32) {
+	return c.lab().split()
+}
+
+// Lab32 generates a color by using data given in CIE L*a*b* space using D65
+// as reference white.
+func Lab32(l, a, b float32) Color32 {
+	return lab32(vec3[float32]{l, a, b})
+}
+
+// DistanceLab is the Euclidean distance in Lab space, see Color.DistanceLab.
+func (c1 Color32) DistanceLab(c2 Color32) float32 {
+	return c1.lab().dist(c2.lab())
+}
+
+// BlendLab blends two colors in the L*a*b* color-space.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color32) BlendLab(c2 Color32, t float32) Color32 {
+	return lab32(c1.lab().lerp(c2.lab(), t))
+}
+
+/// HCL ///
+///////////
+
+// Hcl converts the color to HCL space using D65 as reference white.
+// H values are in [0..360], C and L values are in [0..1].
+func (c Color32) Hcl() (h, cc, l float32) {
+	l, a, b := c.Lab()
+	hh, c64, _ := LabToHcl(float64(l), float64(a), float64(b))
+	return float32(hh), float32(c64), l
+}
+
+// Hcl32 generates a color by using data given in HCL space using D65 as
+// reference white.
+func Hcl32(h, c, l float32) Color32 {
+	H := 0.01745329251994329576 * float64(h) // Deg2Rad
+	return Lab32(l, c*float32(math.Cos(H)), c*float32(math.Sin(H)))
+}
+
+// BlendHcl blends two colors in the CIE-L*C*h° color-space like Color.BlendHcl.
+// t == 0 results in c1, t == 1 results in c2
+func (col1 Color32) BlendHcl(col2 Color32, t float32) Color32 {
+	h1, c1, l1 := col1.Hcl()
+	h2, c2, l2 := col2.Hcl()
+
+	if c1 <= 0.00015 && c2 >= 0.00015 {
+		h1 = h2
+	} else if c2 <= 0.00015 && c1 >= 0.00015 {
+		h2 = h1
+	}
+
+	h := float32(interp_angle(float64(h1), float64(h2), float64(t)))
+	return Hcl32(h, c1+t*(c2-c1), l1+t*(l2-l1)).Clamped()
+}
+
+/// OkLab ///
+/////////////
+
+func (c Color32) okLab() vec3[float32] {
+	return linearRgbToOkLab(c.linearRgb())
+}
+
+func okLab32(v vec3[float32]) Color32 {
+	return linearRgb32(okLabToLinearRgb(v))
+}
+
+// OkLab converts the color to OkLab space.
+func (c Color32) OkLab() (l, a, b float32) {
+	return c.okLab().split()
+}
+
+// OkLab32 generates a color by using data given in OkLab space.
+func OkLab32(l, a, b float32) Color32 {
+	return okLab32(vec3[float32]{l, a, b})
+}
+
+// DistanceOkLab is the Euclidean distance in OkLab space, see Color.DistanceOkLab.
+func (c1 Color32) DistanceOkLab(c2 Color32) float32 {
+	return c1.okLab().dist(c2.okLab())
+}
+
+// BlendOkLab blends two colors in the OkLab color-space.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color32) BlendOkLab(c2 Color32, t float32) Color32 {
+	return okLab32(c1.okLab().lerp(c2.okLab(), t))
+}
@@ -0,0 +1,96 @@
+package colorful
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func almosteq32(v1 float32, v2 float64) bool {
+	return math.Abs(float64(v1)-v2) < 1e-5
+}
+
+func TestColor32Conversions(t *testing.T) {
+	for i, tt := range vals {
+		c := tt.c.Color32()
+
+		r, g, b := c.LinearRgb()
+		r64, g64, b64 := tt.c.LinearRgb()
+		if !almosteq32(r, r64) || !almosteq32(g, g64) || !almosteq32(b, b64) {
+			t.Errorf("%v. %v.LinearRgb() => (%v, %v, %v), want (%v, %v, %v)", i, c, r, g, b, r64, g64, b64)
+		}
+
+		l, a, bb := c.Lab()
+		if !almosteq(float64(l), tt.lab[0]) || !almosteq(float64(a), tt.lab[1]) || !almosteq(float64(bb), tt.lab[2]) {
+			t.Errorf("%v. %v.Lab() => (%v, %v, %v), want %v", i, c, l, a, bb, tt.lab)
+		}
+		if got := Lab32(l, a, bb).Color(); !got.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Lab32(%v, %v, %v) => %v, want %v", i, l, a, bb, got, tt.c)
+		}
+
+		h, cc, l := c.Hcl()
+		if got := Hcl32(h, cc, l).Color(); !got.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Hcl32(%v, %v, %v) => %v, want %v", i, h, cc, l, got, tt.c)
+		}
+
+		x, y, z := c.Xyz()
+		if got := Xyz32(x, y, z).Color(); !got.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Xyz32(%v, %v, %v) => %v, want %v", i, x, y, z, got, tt.c)
+		}
+
+		l, a, bb = c.OkLab()
+		l64, a64, b64 := tt.c.OkLab()
+		if !almosteq32(l, l64) || !almosteq32(a, a64) || !almosteq32(bb, b64) {
+			t.Errorf("%v. %v.OkLab() => (%v, %v, %v), want (%v, %v, %v)", i, c, l, a, bb, l64, a64, b64)
+		}
+		if got := OkLab32(l, a, bb).Color(); !got.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLab32(%v, %v, %v) => %v, want %v", i, l, a, bb, got, tt.c)
+		}
+	}
+}
+
+func TestColor32Blends(t *testing.T) {
+	rand.Seed(1)
+	for i := 0; i < 100; i++ {
+		c1 := Color{rand.Float64(), rand.Float64(), rand.Float64()}
+		c2 := Color{rand.Float64(), rand.Float64(), rand.Float64()}
+		f1, f2 := c1.Color32(), c2.Color32()
+		tt := rand.Float64()
+
+		blends := []struct {
+			name      string
+			got, want Color
+		}{
+			{"BlendRgb", f1.BlendRgb(f2, float32(tt)).Color(), c1.BlendRgb(c2, tt)},
+			{"BlendLinearRgb", f1.BlendLinearRgb(f2, float32(tt)).Color(), c1.BlendLinearRgb(c2, tt)},
+			{"BlendLab", f1.BlendLab(f2, float32(tt)).Color(), c1.BlendLab(c2, tt)},
+			{"BlendHcl", f1.BlendHcl(f2, float32(tt)).Color(), c1.BlendHcl(c2, tt)},
+			{"BlendOkLab", f1.BlendOkLab(f2, float32(tt)).Color(), c1.BlendOkLab(c2, tt)},
+		}
+		for _, b := range blends {
+			if !b.got.AlmostEqualRgb(b.want) {
+				t.Errorf("%v.%v(%v, %v) => %v, want %v", c1, b.name, c2, tt, b.got, b.want)
+			}
+		}
+
+		if d1, d2 := f1.DistanceLab(f2), c1.DistanceLab(c2); !almosteq32(d1, d2) && !almosteq(float64(d1), d2) {
+			t.Errorf("%v.DistanceLab(%v) => %v, want %v", c1, c2, d1, d2)
+		}
+		if d1, d2 := f1.DistanceOkLab(f2), c1.DistanceOkLab(c2); !almosteq32(d1, d2) && !almosteq(float64(d1), d2) {
+			t.Errorf("%v.DistanceOkLab(%v) => %v, want %v", c1, c2, d1, d2)
+		}
+
+		r, g, b := f1.FastLinearRgb()
+		r64, g64, b64 := c1.FastLinearRgb()
+		if !almosteq32(r, r64) || !almosteq32(g, g64) || !almosteq32(b, b64) {
+			t.Errorf("%v.FastLinearRgb() => (%v, %v, %v), want (%v, %v, %v)", c1, r, g, b, r64, g64, b64)
+		}
+	}
+}
+
+func BenchmarkColor32BlendOkLab(bench *testing.B) {
+	c1, c2 := Color32{1, 0.5, 0}, Color32{0.2, 0.4, 0.9}
+	for n := 0; n < bench.N; n++ {
+		c1.BlendOkLab(c2, 0.3)
+	}
+}
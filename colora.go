@@ -0,0 +1,124 @@
+// Colors with an alpha channel.
+
+package colorful
+
+import "image/color"
+
+// A ColorA is a color together with its opacity A, all in the range 0-1.
+// Unlike the color.Color interface, R, G and B are not premultiplied by A,
+// so the color of translucent ColorAs is exact.
+type ColorA struct {
+	R, G, B, A float64
+}
+
+// WithAlpha returns the color with the given opacity.
+func (col Color) WithAlpha(a float64) ColorA {
+	return ColorA{col.R, col.G, col.B, a}
+}
+
+// Color returns the color without its alpha.
+func (c ColorA) Color() Color {
+	return Color{c.R, c.G, c.B}
+}
+
+// RGBA implements the Go color.Color interface, premultiplying by alpha.
+func (c ColorA) RGBA() (r, g, b, a uint32) {
+	cc := c.Clamped()
+	r = uint32(cc.R*cc.A*65535.0 + 0.5)
+	g = uint32(cc.G*cc.A*65535.0 + 0.5)
+	b = uint32(cc.B*cc.A*65535.0 + 0.5)
+	a = uint32(cc.A*65535.0 + 0.5)
+	return
+}
+
+// MakeColorA constructs a ColorA from something implementing color.Color,
+// keeping its alpha. Unlike MakeColor, it doesn't fail for fully transparent
+// colors, which result in transparent black for most color.Color types.
+// color.NRGBA and color.NRGBA64 are converted exactly, even if transparent.
+func MakeColorA(col color.Color) ColorA {
+	switch c := col.(type) {
+	case ColorA:
+		return c
+	case Color:
+		return c.WithAlpha(1.0)
+	case color.NRGBA:
+		return ColorA{float64(c.R) / 255.0, float64(c.G) / 255.0, float64(c.B) / 255.0, float64(c.A) / 255.0}
+	case color.NRGBA64:
+		return ColorA{float64(c.R) / 65535.0, float64(c.G) / 65535.0, float64(c.B) / 65535.0, float64(c.A) / 65535.0}
+	}
+
+	r, g, b, a := col.RGBA()
+	if a == 0 {
+		return ColorA{}
+	}
+	fa := float64(a)
+	return ColorA{float64(r) / fa, float64(g) / fa, float64(b) / fa, fa / 65535.0}
+}
+
+// NRGBA returns the color as non-premultiplied 8 bit color.NRGBA, clamped.
+func (c ColorA) NRGBA() color.NRGBA {
+	cc := c.Clamped()
+	return color.NRGBA{
+		uint8(cc.R*255.0 + 0.5),
+		uint8(cc.G*255.0 + 0.5),
+		uint8(cc.B*255.0 + 0.5),
+		uint8(cc.A*255.0 + 0.5),
+	}
+}
+
+// Hex returns the hex "html" representation including alpha, as in #ff008080.
+func (c ColorA) Hex() string {
+	return c.Color().HexA(c.A)
+}
+
+// IsValid checks whether all values, including alpha, are in [0..1].
+func (c ColorA) IsValid() bool {
+	return c.Color().IsValid() && 0.0 <= c.A && c.A <= 1.0
+}
+
+// Clamped clamps all values, including alpha, to [0..1].
+func (c ColorA) Clamped() ColorA {
+	return c.Color().Clamped().WithAlpha(clamp01(c.A))
+}
+
+// Blend interpolates between two translucent colors, using the given blend
+// function for the colors, such as Color.BlendOkLab or Color.BlendLinearRgb.
+// As in CSS, the colors are weighted by their alpha, which is interpolated
+// linearly, so the color of a fully transparent end doesn't bleed in.
+// t == 0 results in c1, t == 1 results in c2.
+func (c1 ColorA) Blend(c2 ColorA, t float64, blend func(c1, c2 Color, t float64) Color) ColorA {
+	a := c1.A + t*(c2.A-c1.A)
+	tc := t
+	if a > 0.0 {
+		tc = t * c2.A / a
+	}
+	return blend(c1.Color(), c2.Color(), tc).WithAlpha(a)
+}
+
+// Over composites the color over the background bg, using Porter and Duff's
+// "source over" operator on the sRGB values. This is how browsers and the
+// image/draw package blend, see OverLinearRgb for a physically correct mix.
+func (c ColorA) Over(bg ColorA) ColorA {
+	return c.over(bg, func(col Color) (float64, float64, float64) {
+		return col.R, col.G, col.B
+	}, func(r, g, b float64) Color {
+		return Color{r, g, b}
+	})
+}
+
+// OverLinearRgb composites the color over the background bg like Over does,
+// but mixes linear RGB values, like light does.
+func (c ColorA) OverLinearRgb(bg ColorA) ColorA {
+	return c.over(bg, Color.LinearRgb, LinearRgb)
+}
+
+func (c ColorA) over(bg ColorA, from func(Color) (float64, float64, float64), to func(float64, float64, float64) Color) ColorA {
+	a := c.A + bg.A*(1.0-c.A)
+	if a <= 0.0 {
+		return ColorA{}
+	}
+	r1, g1, b1 := from(c.Color())
+	r2, g2, b2 := from(bg.Color())
+	wc, wbg := c.A/a, bg.A*(1.0-c.A)/a
+	return to(r1*wc+r2*wbg, g1*wc+g2*wbg, b1*wc+b2*wbg).WithAlpha(a)
+}
@@ -0,0 +1,100 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestColorAInterop(t *testing.T) {
+	c := ColorA{1, 0.5, 0, 0.5}
+
+	// The color.Color interface is premultiplied.
+	if r, g, b, a := c.RGBA(); r != 32768 || g != 16384 || b != 0 || a != 32768 {
+		t.Errorf("RGBA() => %v %v %v %v", r, g, b, a)
+	}
+	if got, want := c.NRGBA(), (color.NRGBA{255, 128, 0, 128}); got != want {
+		t.Errorf("NRGBA() => %v, want %v", got, want)
+	}
+	if hex := c.Hex(); hex != "#ff800080" {
+		t.Errorf("Hex() => %v, want #ff800080", hex)
+	}
+
+	tests := []struct {
+		in   color.Color
+		want ColorA
+	}{
+		{color.NRGBA{255, 0, 0, 0}, ColorA{1, 0, 0, 0}},
+		{color.NRGBA64{0, 65535, 0, 32768}, ColorA{0, 1, 0, 32768.0 / 65535.0}},
+		{color.RGBA{0, 0, 0, 0}, ColorA{}},
+		{color.RGBA{0, 0, 128, 128}, ColorA{0, 0, 1, 128.0 / 255.0}},
+		{Color{1, 1, 0}, ColorA{1, 1, 0, 1}},
+		{c, c},
+	}
+	for i, tt := range tests {
+		if got := MakeColorA(tt.in); !got.Color().AlmostEqualRgb(tt.want.Color()) || !almosteq(got.A, tt.want.A) {
+			t.Errorf("%v. MakeColorA(%v) => %v, want %v", i, tt.in, got, tt.want)
+		}
+	}
+
+	// A round trip through an image with straight alpha is exact.
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	draw.Draw(img, img.Bounds(), image.NewUniform(ColorA{0.2, 0.4, 0.6, 0.8}), image.Point{}, draw.Src)
+	if got := MakeColorA(img.At(0, 0)); got.Hex() != "#336699cc" {
+		t.Errorf("image round trip => %v, want #336699cc", got.Hex())
+	}
+}
+
+func TestColorABlend(t *testing.T) {
+	red := ColorA{1, 0, 0, 1}
+	clear := ColorA{0, 0, 1, 0}
+
+	// The color of a fully transparent end doesn't bleed in.
+	got := red.Blend(clear, 0.5, Color.BlendRgb)
+	if !got.Color().AlmostEqualRgb(Color{1, 0, 0}) || !almosteq(got.A, 0.5) {
+		t.Errorf("Blend(red, clear, 0.5) => %v, want red at 0.5", got)
+	}
+
+	got = red.Blend(ColorA{0, 0, 1, 1}, 0.5, Color.BlendRgb)
+	if !got.Color().AlmostEqualRgb(Color{0.5, 0, 0.5}) || !almosteq(got.A, 1) {
+		t.Errorf("Blend(red, blue, 0.5) => %v", got)
+	}
+
+	if got := red.Blend(clear, 1, Color.BlendOkLab); got.A != 0 {
+		t.Errorf("Blend(red, clear, 1) => %v, want alpha 0", got)
+	}
+}
+
+func TestColorAOver(t *testing.T) {
+	tests := []struct {
+		fg, bg ColorA
+		linear bool
+		want   ColorA
+	}{
+		{ColorA{1, 0, 0, 1}, ColorA{0, 0, 1, 1}, false, ColorA{1, 0, 0, 1}},
+		{ColorA{1, 0, 0, 0}, ColorA{0, 0, 1, 1}, false, ColorA{0, 0, 1, 1}},
+		{ColorA{1, 1, 1, 0.5}, ColorA{0, 0, 0, 1}, false, ColorA{0.5, 0.5, 0.5, 1}},
+		{ColorA{1, 1, 1, 0.5}, ColorA{0, 0, 0, 1}, true, ColorA{0.735357, 0.735357, 0.735357, 1}},
+		{ColorA{1, 0, 0, 0.5}, ColorA{0, 0, 1, 0.5}, false, ColorA{2.0 / 3.0, 0, 1.0 / 3.0, 0.75}},
+		{ColorA{}, ColorA{}, false, ColorA{}},
+	}
+	for i, tt := range tests {
+		got := tt.fg.Over(tt.bg)
+		if tt.linear {
+			got = tt.fg.OverLinearRgb(tt.bg)
+		}
+		if !got.Color().AlmostEqualRgb(tt.want.Color()) || !almosteq(got.A, tt.want.A) {
+			t.Errorf("%v. %v over %v => %v, want %v", i, tt.fg, tt.bg, got, tt.want)
+		}
+	}
+
+	// Over matches image/draw.
+	dst := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	bg, fg := ColorA{0.2, 0.6, 1, 1}, ColorA{1, 0.4, 0, 0.6}
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(fg), image.Point{}, draw.Over)
+	if got, want := MakeColorA(dst.At(0, 0)), fg.Over(bg); !got.Color().AlmostEqualRgb(want.Color()) {
+		t.Errorf("draw.Over => %v, Over => %v", got, want)
+	}
+}
@@ -3,6 +3,7 @@
 package colorful
 
 import (
+	"hash/fnv"
 	"math/rand"
 )
 
@@ -53,3 +54,28 @@ func randomPimp() Color {
 		0.5+rand.Float64()*0.3,
 		0.5+rand.Float64()*0.3)
 }
+
+// FromString deterministically maps an arbitrary string (a user name, a log
+// stream, a graph node id, ...) to a color. The same string always yields the
+// same color, and different strings are spread out evenly along the hue
+// circle. Lightness and chroma are restricted in HCL space so that the result
+// is always legible both on white and on black backgrounds.
+func FromString(s string) Color {
+	hash := fnv.New64a()
+	hash.Write([]byte(s))
+	sum := hash.Sum64()
+
+	// Use separate bits of the hash for the three components, so that
+	// strings differing in hue don't also all share the same lightness.
+	h := float64(sum&0xffff) / 65536.0 * 360.0
+	c := 0.35 + float64((sum>>16)&0xff)/255.0*0.15
+	l := 0.55 + float64((sum>>24)&0xff)/255.0*0.15
+
+	// Not every hue supports the chroma we picked, so reduce it until the
+	// color fits into RGB. Keeping hue and lightness keeps things stable.
+	col := Hcl(h, c, l)
+	for ; !col.IsValid() && c > 0.0; col = Hcl(h, c, l) {
+		c -= 0.01
+	}
+	return col.Clamped()
+}
@@ -31,3 +31,22 @@ func TestColorValidity(t *testing.T) {
 		}
 	}
 }
+
+func TestFromString(t *testing.T) {
+	for _, s := range []string{"", "alice", "bob", "carol", "ERROR", "node-42", "日本語"} {
+		col := FromString(s)
+		if !col.IsValid() {
+			t.Errorf("FromString(%q) => %v is not valid", s, col)
+		}
+		if col2 := FromString(s); col != col2 {
+			t.Errorf("FromString(%q) is not deterministic: %v != %v", s, col, col2)
+		}
+		if _, _, l := col.Hcl(); l < 0.5 || l > 0.75 {
+			t.Errorf("FromString(%q) => %v has lightness %v outside of the legible range", s, col, l)
+		}
+	}
+
+	if FromString("alice") == FromString("bob") {
+		t.Errorf("FromString gives the same color for different strings")
+	}
+}
@@ -0,0 +1,183 @@
+// Color matrix filters, as in SVG's feColorMatrix and the CSS filter
+// functions defined upon it.
+// https://www.w3.org/TR/filter-effects-1/#feColorMatrixElement
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// A ColorMatrix transforms colors by a 4x5 matrix, like the SVG
+// feColorMatrix filter: each row computes one of R, G, B and A as a weighted
+// sum of R, G, B and A plus the offset in the last column. Results are
+// clamped to [0..1], as for SVG filters.
+type ColorMatrix struct {
+	M [4][5]float64
+
+	// Linear applies the matrix to linear RGB values, as SVG filters do by
+	// default, instead of sRGB values, as CSS filter functions do.
+	Linear bool
+}
+
+// IdentityMatrix returns the matrix which doesn't change colors.
+func IdentityMatrix() ColorMatrix {
+	return ColorMatrix{M: [4][5]float64{{1, 0, 0, 0, 0}, {0, 1, 0, 0, 0}, {0, 0, 1, 0, 0}, {0, 0, 0, 1, 0}}}
+}
+
+// rgbMatrix returns the matrix applying m to R, G and B and keeping A.
+func rgbMatrix(m [3][3]float64) ColorMatrix {
+	cm := IdentityMatrix()
+	for i, row := range m {
+		copy(cm.M[i][:3], row[:])
+	}
+	return cm
+}
+
+// Then returns the matrix applying cm first and next after it, as a single
+// matrix. The result differs from applying the two one after another only
+// where cm's results are clamped. It panics if the two work on different
+// values, linear and sRGB, which no single matrix can do.
+func (cm ColorMatrix) Then(next ColorMatrix) ColorMatrix {
+	if cm.Linear != next.Linear {
+		panic("colorful: cannot compose color matrices of linear and sRGB values")
+	}
+	out := ColorMatrix{Linear: cm.Linear}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			var v float64
+			for k := 0; k < 4; k++ {
+				v += next.M[i][k] * cm.M[k][j]
+			}
+			if j == 4 {
+				v += next.M[i][4]
+			}
+			out.M[i][j] = v
+		}
+	}
+	return out
+}
+
+// ApplyA applies the matrix to the color and its alpha.
+func (cm ColorMatrix) ApplyA(c ColorA) ColorA {
+	if cm.Linear {
+		c.R, c.G, c.B = c.Color().LinearRgb()
+	}
+	var v [4]float64
+	for i, row := range cm.M {
+		v[i] = clamp01(row[0]*c.R + row[1]*c.G + row[2]*c.B + row[3]*c.A + row[4])
+	}
+	if cm.Linear {
+		col := LinearRgb(v[0], v[1], v[2])
+		v[0], v[1], v[2] = col.R, col.G, col.B
+	}
+	return ColorA{v[0], v[1], v[2], v[3]}
+}
+
+// Apply applies the matrix to the opaque color, ignoring the resulting alpha.
+func (cm ColorMatrix) Apply(col Color) Color {
+	return cm.ApplyA(col.WithAlpha(1)).Color()
+}
+
+// Image returns a copy of img with the matrix applied to every pixel,
+// including its alpha.
+func (cm ColorMatrix) Image(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(b)
+	parallelRows(b, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				ca := cm.ApplyA(ColorA{float64(c.R) / 255.0, float64(c.G) / 255.0, float64(c.B) / 255.0, float64(c.A) / 255.0})
+				p := dst.Pix[dst.PixOffset(x, y):]
+				p[0] = uint8(ca.R*255.0 + 0.5)
+				p[1] = uint8(ca.G*255.0 + 0.5)
+				p[2] = uint8(ca.B*255.0 + 0.5)
+				p[3] = uint8(ca.A*255.0 + 0.5)
+			}
+		}
+	})
+	return dst
+}
+
+/// CSS filter functions ///
+////////////////////////////
+
+// The following return the matrices of the CSS filter functions of the same
+// names, working on sRGB values. amount is as in CSS, where 1 means 100%.
+// https://www.w3.org/TR/filter-effects-1/#filter-functions
+
+// GrayscaleMatrix converts colors to gray by the given amount in [0..1].
+func GrayscaleMatrix(amount float64) ColorMatrix {
+	a := 1 - clamp01(amount)
+	return rgbMatrix([3][3]float64{
+		{0.2126 + 0.7874*a, 0.7152 - 0.7152*a, 0.0722 - 0.0722*a},
+		{0.2126 - 0.2126*a, 0.7152 + 0.2848*a, 0.0722 - 0.0722*a},
+		{0.2126 - 0.2126*a, 0.7152 - 0.7152*a, 0.0722 + 0.9278*a},
+	})
+}
+
+// SepiaMatrix converts colors to sepia by the given amount in [0..1].
+func SepiaMatrix(amount float64) ColorMatrix {
+	a := 1 - clamp01(amount)
+	return rgbMatrix([3][3]float64{
+		{0.393 + 0.607*a, 0.769 - 0.769*a, 0.189 - 0.189*a},
+		{0.349 - 0.349*a, 0.686 + 0.314*a, 0.168 - 0.168*a},
+		{0.272 - 0.272*a, 0.534 - 0.534*a, 0.131 + 0.869*a},
+	})
+}
+
+// SaturateMatrix scales the saturation by s, where 0 is gray and values
+// above 1 oversaturate.
+func SaturateMatrix(s float64) ColorMatrix {
+	return rgbMatrix([3][3]float64{
+		{0.213 + 0.787*s, 0.715 - 0.715*s, 0.072 - 0.072*s},
+		{0.213 - 0.213*s, 0.715 + 0.285*s, 0.072 - 0.072*s},
+		{0.213 - 0.213*s, 0.715 - 0.715*s, 0.072 + 0.928*s},
+	})
+}
+
+// HueRotateMatrix rotates hues by the given number of degrees. Unlike
+// Color.RotateHue, this is a crude approximation which also changes
+// lightness and saturation.
+func HueRotateMatrix(deg float64) ColorMatrix {
+	sin, cos := math.Sincos(deg * math.Pi / 180.0)
+	return rgbMatrix([3][3]float64{
+		{0.213 + cos*0.787 - sin*0.213, 0.715 - cos*0.715 - sin*0.715, 0.072 - cos*0.072 + sin*0.928},
+		{0.213 - cos*0.213 + sin*0.143, 0.715 + cos*0.285 + sin*0.140, 0.072 - cos*0.072 - sin*0.283},
+		{0.213 - cos*0.213 - sin*0.787, 0.715 - cos*0.715 + sin*0.715, 0.072 + cos*0.928 + sin*0.072},
+	})
+}
+
+// BrightnessMatrix multiplies the channels by amount.
+func BrightnessMatrix(amount float64) ColorMatrix {
+	return rgbMatrix([3][3]float64{{amount, 0, 0}, {0, amount, 0}, {0, 0, amount}})
+}
+
+// ContrastMatrix scales the channels' distances from 0.5 by amount.
+func ContrastMatrix(amount float64) ColorMatrix {
+	cm := BrightnessMatrix(amount)
+	for i := 0; i < 3; i++ {
+		cm.M[i][4] = 0.5 - 0.5*amount
+	}
+	return cm
+}
+
+// InvertMatrix inverts the channels by the given amount in [0..1].
+func InvertMatrix(amount float64) ColorMatrix {
+	a := clamp01(amount)
+	cm := BrightnessMatrix(1 - 2*a)
+	for i := 0; i < 3; i++ {
+		cm.M[i][4] = a
+	}
+	return cm
+}
+
+// OpacityMatrix multiplies alpha by the given amount in [0..1].
+func OpacityMatrix(amount float64) ColorMatrix {
+	cm := IdentityMatrix()
+	cm.M[3][3] = clamp01(amount)
+	return cm
+}
@@ -0,0 +1,79 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestColorMatrixFilters(t *testing.T) {
+	red, white := Color{1, 0, 0}, Color{1, 1, 1}
+	tests := []struct {
+		name string
+		cm   ColorMatrix
+		in   Color
+		want Color
+	}{
+		{"identity", IdentityMatrix(), Color{0.2, 0.4, 0.6}, Color{0.2, 0.4, 0.6}},
+		{"grayscale(1)", GrayscaleMatrix(1), red, Color{0.2126, 0.2126, 0.2126}},
+		{"grayscale(0)", GrayscaleMatrix(0), red, red},
+		{"sepia(1)", SepiaMatrix(1), white, Color{1, 1, 0.937}},
+		{"saturate(1)", SaturateMatrix(1), Color{0.2, 0.4, 0.6}, Color{0.2, 0.4, 0.6}},
+		{"hue-rotate(0)", HueRotateMatrix(0), Color{0.2, 0.4, 0.6}, Color{0.2, 0.4, 0.6}},
+		{"hue-rotate(360)", HueRotateMatrix(360), Color{0.2, 0.4, 0.6}, Color{0.2, 0.4, 0.6}},
+		{"brightness(0.5)", BrightnessMatrix(0.5), white, Color{0.5, 0.5, 0.5}},
+		{"brightness(2)", BrightnessMatrix(2), Color{0.2, 0.4, 0.6}, Color{0.4, 0.8, 1}},
+		{"contrast(0.5)", ContrastMatrix(0.5), Color{0, 0.5, 1}, Color{0.25, 0.5, 0.75}},
+		{"invert(1)", InvertMatrix(1), red, Color{0, 1, 1}},
+		{"invert(0.5)", InvertMatrix(0.5), red, Color{0.5, 0.5, 0.5}},
+	}
+	for i, tt := range tests {
+		if got := tt.cm.Apply(tt.in); !got.AlmostEqualRgb(tt.want) {
+			t.Errorf("%v. %v.Apply(%v) => %v, want %v", i, tt.name, tt.in, got, tt.want)
+		}
+	}
+
+	if got := OpacityMatrix(0.5).ApplyA(red.WithAlpha(0.8)); !almosteq(got.A, 0.4) || got.Color() != red {
+		t.Errorf("opacity(0.5).ApplyA(red, 0.8) => %v, want alpha 0.4", got)
+	}
+
+	// Brightness in linear RGB is exposure, unlike in sRGB.
+	lin := BrightnessMatrix(0.5)
+	lin.Linear = true
+	if got, want := lin.Apply(white), LinearRgb(0.5, 0.5, 0.5); !got.AlmostEqualRgb(want) {
+		t.Errorf("linear brightness(0.5).Apply(white) => %v, want %v", got, want)
+	}
+}
+
+func TestColorMatrixThen(t *testing.T) {
+	a, b := SepiaMatrix(0.6), ContrastMatrix(0.8).Then(HueRotateMatrix(40))
+	fused := a.Then(b)
+	for _, c := range randomColors(20) {
+		c = c.BlendRgb(Color{0.5, 0.5, 0.5}, 0.5) // Avoid clamping in between.
+		if got, want := fused.Apply(c), b.Apply(a.Apply(c)); !got.AlmostEqualRgb(want) {
+			t.Errorf("fused.Apply(%v) => %v, want %v", c, got, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Then of linear and sRGB matrices didn't panic")
+		}
+	}()
+	lin := IdentityMatrix()
+	lin.Linear = true
+	IdentityMatrix().Then(lin)
+}
+
+func TestColorMatrixImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{255, 0, 0, 255})
+	img.SetNRGBA(1, 0, color.NRGBA{0, 0, 255, 128})
+	out := InvertMatrix(1).Then(OpacityMatrix(0.5)).Image(img)
+	if got, want := out.NRGBAAt(0, 0), (color.NRGBA{0, 255, 255, 128}); got != want {
+		t.Errorf("Image => %v at (0, 0), want %v", got, want)
+	}
+	if got, want := out.NRGBAAt(1, 0), (color.NRGBA{255, 255, 0, 64}); got != want {
+		t.Errorf("Image => %v at (1, 0), want %v", got, want)
+	}
+}
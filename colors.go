@@ -2,7 +2,6 @@
 package colorful
 
 import (
-	"fmt"
 	"image/color"
 	"math"
 )
@@ -22,7 +21,32 @@ func (col Color) RGBA() (r, g, b, a uint32) {
 }
 
 // Constructs a colorful.Color from something implementing color.Color
+// The common types of the image/color package are converted without going
+// through their RGBA method, and color.NRGBA and color.NRGBA64 exactly.
 func MakeColor(col color.Color) (Color, bool) {
+	switch c := col.(type) {
+	case color.NRGBA:
+		return MakeColorNRGBA(c), c.A != 0
+	case color.NRGBA64:
+		return MakeColorNRGBA64(c), c.A != 0
+	case color.RGBA:
+		if c.A == 0 {
+			return Color{0, 0, 0}, false
+		}
+		a := uint32(c.A)
+		return Color{
+			float64(uint32(c.R)*0xffff/a) / 65535.0,
+			float64(uint32(c.G)*0xffff/a) / 65535.0,
+			float64(uint32(c.B)*0xffff/a) / 65535.0,
+		}, true
+	case color.Gray:
+		y := float64(c.Y) / 255.0
+		return Color{y, y, y}, true
+	case color.Gray16:
+		y := float64(c.Y) / 65535.0
+		return Color{y, y, y}, true
+	}
+
 	r, g, b, a := col.RGBA()
 	if a == 0 {
 		return Color{0, 0, 0}, false
@@ -40,6 +64,18 @@ func MakeColor(col color.Color) (Color, bool) {
 	return Color{float64(r) / 65535.0, float64(g) / 65535.0, float64(b) / 65535.0}, true
 }
 
+// MakeColorNRGBA constructs a Color from the non-premultiplied 8 bit color,
+// ignoring its alpha.
+func MakeColorNRGBA(c color.NRGBA) Color {
+	return Color{float64(c.R) / 255.0, float64(c.G) / 255.0, float64(c.B) / 255.0}
+}
+
+// MakeColorNRGBA64 constructs a Color from the non-premultiplied 16 bit
+// color, ignoring its alpha.
+func MakeColorNRGBA64(c color.NRGBA64) Color {
+	return Color{float64(c.R) / 65535.0, float64(c.G) / 65535.0, float64(c.B) / 65535.0}
+}
+
 // Might come in handy sometimes to reduce boilerplate code.
 func (col Color) RGB255() (r, g, b uint8) {
 	r = uint8(col.R*255.0 + 0.5)
@@ -80,6 +116,27 @@ func (c Color) Clamped() Color {
 	return Color{clamp01(c.R), clamp01(c.G), clamp01(c.B)}
 }
 
+// Validate is like IsValid, but returns an ErrInvalidColor reporting which
+// channels are out of [0..1] and by how much, or nil if the color is valid.
+func (c Color) Validate() error {
+	var chans []ErrOutOfRange
+	for i, v := range [3]float64{c.R, c.G, c.B} {
+		if !(0.0 <= v && v <= 1.0) {
+			chans = append(chans, ErrOutOfRange{"RGB"[i : i+1], v, 0.0, 1.0})
+		}
+	}
+	if chans == nil {
+		return nil
+	}
+	return ErrInvalidColor{c, chans}
+}
+
+// DeltaFromGamut returns how far the color is out of the RGB gamut, as the
+// distance in RGB space to its Clamped version, which is 0 for valid colors.
+func (c Color) DeltaFromGamut() float64 {
+	return c.DistanceRgb(c.Clamped())
+}
+
 func sq(v float64) float64 {
 	return v * v
 }
@@ -134,6 +191,18 @@ func (c1 Color) AlmostEqualRgb(c2 Color) bool {
 		math.Abs(c1.B-c2.B) < 3.0*Delta
 }
 
+// AlmostEqual checks for equality between colors within the tolerance
+// epsilon of the given distance, which is any of the Distance methods such as
+// Color.DistanceOkLab or Color.DistanceCIEDE2000. A nil distance means
+// Color.DistanceLab. Suitable tolerances depend on the space, a just
+// noticeable difference is around 0.02 in both Lab and OkLab.
+func (c1 Color) AlmostEqual(c2 Color, epsilon float64, distance func(c1, c2 Color) float64) bool {
+	if distance == nil {
+		distance = Color.DistanceLab
+	}
+	return distance(c1, c2) < epsilon
+}
+
 // You don't really want to use this, do you? Go for BlendLab, BlendLuv or BlendHcl.
 func (c1 Color) BlendRgb(c2 Color, t float64) Color {
 	return Color{c1.R + t*(c2.R-c1.R),
@@ -353,31 +422,192 @@ func Hsl(h, s, l float64) Color {
 /// Hex ///
 ///////////
 
+const hexDigits = "0123456789abcdef"
+
+func appendHexByte(dst []byte, v uint8) []byte {
+	return append(dst, hexDigits[v>>4], hexDigits[v&0xf])
+}
+
 // Hex returns the hex "html" representation of the color, as in #ff0080.
 func (col Color) Hex() string {
+	var buf [7]byte
+	return string(col.AppendHex(buf[:0]))
+}
+
+// AppendHex appends the hex "html" representation of the color, as in
+// #ff0080, to dst and returns the extended buffer. Unlike Hex, it doesn't
+// allocate if dst has enough capacity.
+func (col Color) AppendHex(dst []byte) []byte {
 	// Add 0.5 for rounding
-	return fmt.Sprintf("#%02x%02x%02x", uint8(col.R*255.0+0.5), uint8(col.G*255.0+0.5), uint8(col.B*255.0+0.5))
+	dst = append(dst, '#')
+	dst = appendHexByte(dst, uint8(col.R*255.0+0.5))
+	dst = appendHexByte(dst, uint8(col.G*255.0+0.5))
+	return appendHexByte(dst, uint8(col.B*255.0+0.5))
 }
 
-// Hex parses a "html" hex color-string, either in the 3 "#f0c" or 6 "#ff1034" digits form.
-func Hex(scol string) (Color, error) {
-	format := "#%02x%02x%02x"
-	factor := 1.0 / 255.0
-	if len(scol) == 4 {
-		format = "#%1x%1x%1x"
-		factor = 1.0 / 15.0
+// HexA returns the hex "html" representation of the color including the
+// given alpha in [0..1], as in #ff008080.
+func (col Color) HexA(alpha float64) string {
+	var buf [9]byte
+	return string(col.AppendHexA(buf[:0], alpha))
+}
+
+// AppendHexA is like AppendHex, but includes the given alpha in [0..1], as
+// in #ff008080.
+func (col Color) AppendHexA(dst []byte, alpha float64) []byte {
+	return appendHexByte(col.AppendHex(dst), uint8(clamp01(alpha)*255.0+0.5))
+}
+
+// Hex16 returns the 16 bit per channel hex representation of the color, as in
+// #ffff00008080. This is supported by X11 (XParseColor) and some imaging tools.
+func (col Color) Hex16() string {
+	var buf [13]byte
+	dst := append(buf[:0], '#')
+	for _, v := range [3]float64{col.R, col.G, col.B} {
+		v16 := uint16(clamp01(v)*65535.0 + 0.5)
+		dst = appendHexByte(appendHexByte(dst, uint8(v16>>8)), uint8(v16))
+	}
+	return string(dst)
+}
+
+// HexOptions control the hex representation written by FormatHex.
+type HexOptions struct {
+	// Uppercase selects the digits A-F instead of a-f.
+	Uppercase bool
+
+	// Short selects the 3 or 4 digits forms, as in #f08, whenever they
+	// represent the color exactly.
+	Short bool
+
+	// Alpha appends the alpha, but only if it isn't fully opaque.
+	Alpha bool
+}
+
+// FormatHex returns the hex "html" representation of the color like Hex and
+// HexA do, but formatted according to opts. The color and alpha are clamped.
+// The alpha is ignored unless opts.Alpha is set.
+func (col Color) FormatHex(alpha float64, opts HexOptions) string {
+	digits := "0123456789abcdef"
+	if opts.Uppercase {
+		digits = "0123456789ABCDEF"
 	}
 
-	var r, g, b uint8
-	n, err := fmt.Sscanf(scol, format, &r, &g, &b)
+	r, g, b := col.Clamped().RGB255()
+	values := []uint8{r, g, b}
+	if a := uint8(clamp01(alpha)*255.0 + 0.5); opts.Alpha && a < 255 {
+		values = append(values, a)
+	}
+
+	short := opts.Short
+	for _, v := range values {
+		short = short && v>>4 == v&0xf
+	}
+
+	buf := make([]byte, 1, 9)
+	buf[0] = '#'
+	for _, v := range values {
+		if !short {
+			buf = append(buf, digits[v>>4])
+		}
+		buf = append(buf, digits[v&0xf])
+	}
+	return string(buf)
+}
+
+// Hex parses a "html" hex color-string, either in the 3 "#f0c" or 6 "#ff1034"
+// digits form, or in the 4 "#f0c8" or 8 "#ff103480" digits form with alpha,
+// in which case the alpha is dropped. Use HexA to get it.
+// The high precision 9 "#fff000ccc" and 12 "#ffff10103434" digits forms used
+// by X11 are supported, too.
+// The leading '#' may also be "0x" or missing, digits may be upper case and
+// surrounding whitespace is ignored. Anything else is rejected with an
+// ErrInvalidHex.
+func Hex(scol string) (Color, error) {
+	col, _, err := HexA(scol)
+	return col, err
+}
+
+// MustHex is like Hex, but panics if scol is malformed. It is meant for
+// initializing package-level variables from constants.
+func MustHex(scol string) Color {
+	col, err := Hex(scol)
 	if err != nil {
-		return Color{}, err
+		panic(err)
+	}
+	return col
+}
+
+// HexA parses a "html" hex color-string like Hex does, but also returns its
+// alpha in [0..1], which is 1 for the forms without alpha.
+func HexA(scol string) (Color, float64, error) {
+	start, end := 0, len(scol)
+	for start < end && isSpace(scol[start]) {
+		start++
+	}
+	for end > start && isSpace(scol[end-1]) {
+		end--
+	}
+	if start < end && scol[start] == '#' {
+		start++
+	} else if end-start >= 2 && scol[start] == '0' && (scol[start+1] == 'x' || scol[start+1] == 'X') {
+		start += 2
+	}
+
+	// The number of digits per channel.
+	var ndigits int
+	switch end - start {
+	case 3, 4:
+		ndigits = 1
+	case 6, 8:
+		ndigits = 2
+	case 9:
+		ndigits = 3
+	case 12:
+		ndigits = 4
+	default:
+		// Report invalid characters in preference to the length.
+		for i := start; i < end; i++ {
+			if hexDigit(scol[i]) < 0 {
+				return Color{}, 0, ErrInvalidHex{scol, i}
+			}
+		}
+		return Color{}, 0, ErrInvalidHex{scol, -1}
 	}
-	if n != 3 {
-		return Color{}, fmt.Errorf("color: %v is not a hex-color", scol)
+
+	// Forms without alpha are opaque.
+	full := 1<<(4*uint(ndigits)) - 1
+	rgba := [4]int{0, 0, 0, full}
+	if (end-start)/ndigits == 4 {
+		rgba[3] = 0
 	}
+	for i := start; i < end; i++ {
+		d := hexDigit(scol[i])
+		if d < 0 {
+			return Color{}, 0, ErrInvalidHex{scol, i}
+		}
+		c := (i - start) / ndigits
+		rgba[c] = rgba[c]<<4 | d
+	}
+
+	factor := 1.0 / float64(full)
+	return Color{float64(rgba[0]) * factor, float64(rgba[1]) * factor, float64(rgba[2]) * factor}, float64(rgba[3]) * factor, nil
+}
 
-	return Color{float64(r) * factor, float64(g) * factor, float64(b) * factor}, nil
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// hexDigit returns the value of the hex digit c, or -1 if it isn't one.
+func hexDigit(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c - 'a' + 10)
+	case 'A' <= c && c <= 'F':
+		return int(c - 'A' + 10)
+	}
+	return -1
 }
 
 /// Linear ///
@@ -465,6 +695,19 @@ func FastLinearRgb(r, g, b float64) Color {
 	return Color{delinearize_fast(r), delinearize_fast(g), delinearize_fast(b)}
 }
 
+var (
+	linearRgbToXyzMat = mat3{
+		{0.41239079926595948, 0.35758433938387796, 0.18048078840183429},
+		{0.21263900587151036, 0.71516867876775593, 0.072192315360733715},
+		{0.019330818715591851, 0.11919477979462599, 0.95053215224966058},
+	}
+	xyzToLinearRgbMat = mat3{
+		{3.2409699419045214, -1.5373831775700935, -0.49861076029300328},
+		{-0.96924363628087983, 1.8759675015077207, 0.041555057407175613},
+		{0.055630079696993609, -0.20397695888897657, 1.0569715142428786},
+	}
+)
+
 // XyzToLinearRgb converts from CIE XYZ-space to Linear RGB space.
 func XyzToLinearRgb(x, y, z float64) (r, g, b float64) {
 	r = 3.2409699419045214*x - 1.5373831775700935*y - 0.49861076029300328*z
@@ -701,7 +944,10 @@ func (cl Color) DistanceCIEDE2000(cr Color) float64 {
 func (cl Color) DistanceCIEDE2000klch(cr Color, kl, kc, kh float64) float64 {
 	l1, a1, b1 := cl.Lab()
 	l2, a2, b2 := cr.Lab()
+	return labDistanceCIEDE2000klch(l1, a1, b1, l2, a2, b2, kl, kc, kh)
+}
 
+func labDistanceCIEDE2000klch(l1, a1, b1, l2, a2, b2, kl, kc, kh float64) float64 {
 	// As with CIE94, we scale up the ranges of L,a,b beforehand and scale
 	// them down again afterwards.
 	l1, a1, b1 = l1*100.0, a1*100.0, b1*100.0
@@ -953,6 +1199,11 @@ func HclWhiteRef(h, c, l float64, wref [3]float64) Color {
 // BlendHcl blends two colors in the CIE-L*C*h° color-space, which should result in a smoother blend.
 // t == 0 results in c1, t == 1 results in c2
 func (col1 Color) BlendHcl(col2 Color, t float64) Color {
+	return blendHclUnclamped(col1, col2, t).Clamped()
+}
+
+// blendHclUnclamped is BlendHcl without clamping the result to the RGB gamut.
+func blendHclUnclamped(col1, col2 Color, t float64) Color {
 	h1, c1, l1 := col1.Hcl()
 	h2, c2, l2 := col2.Hcl()
 	
@@ -964,7 +1215,7 @@ func (col1 Color) BlendHcl(col2 Color, t float64) Color {
 	}
 
 	// We know that h are both in [0..360]
-	return Hcl(interp_angle(h1, h2, t), c1+t*(c2-c1), l1+t*(l2-l1)).Clamped()
+	return Hcl(interp_angle(h1, h2, t), c1+t*(c2-c1), l1+t*(l2-l1))
 }
 
 // LuvLch
@@ -1027,3 +1278,149 @@ func (col1 Color) BlendLuvLCh(col2 Color, t float64) Color {
 	// We know that h are both in [0..360]
 	return LuvLCh(l1+t*(l2-l1), c1+t*(c2-c1), interp_angle(h1, h2, t))
 }
+
+/// OkLab ///
+/////////////
+// https://bottosson.github.io/posts/oklab/
+// OkLab is a perceptual color space like L*a*b*, but with better hue linearity
+// and without the need for choosing a reference white (it's always D65).
+
+// The matrices of OkLab, from linear sRGB to cone responses (LMS) and from
+// the non-linear cone responses to Lab, and their inverses.
+var (
+	okLabLmsMat = mat3{
+		{0.4122214708, 0.5363325363, 0.0514459929},
+		{0.2119034982, 0.6806995451, 0.1073969566},
+		{0.0883024619, 0.2817188376, 0.6299787005},
+	}
+	okLabLabMat = mat3{
+		{0.2104542553, 0.7936177850, -0.0040720468},
+		{1.9779984951, -2.4285922050, 0.4505937099},
+		{0.0259040371, 0.7827717662, -0.8086757660},
+	}
+	okLabLmsInvMat = mat3{
+		{4.0767416621, -3.3077115913, 0.2309699292},
+		{-1.2684380046, 2.6097574011, -0.3413193965},
+		{-0.0041960863, -0.7034186147, 1.7076147010},
+	}
+	okLabLabInvMat = mat3{
+		{1.0, 0.3963377774, 0.2158037573},
+		{1.0, -0.1055613458, -0.0638541728},
+		{1.0, -0.0894841775, -1.2914855480},
+	}
+)
+
+// LinearRgbToOkLab converts from linear sRGB to OkLab.
+func LinearRgbToOkLab(r, g, b float64) (l, a, bb float64) {
+	l_ := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m_ := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s_ := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	l = 0.2104542553*l_ + 0.7936177850*m_ - 0.0040720468*s_
+	a = 1.9779984951*l_ - 2.4285922050*m_ + 0.4505937099*s_
+	bb = 0.0259040371*l_ + 0.7827717662*m_ - 0.8086757660*s_
+	return
+}
+
+// OkLabToLinearRgb converts from OkLab to linear sRGB.
+func OkLabToLinearRgb(l, a, b float64) (r, g, bb float64) {
+	l_ := cub(l + 0.3963377774*a + 0.2158037573*b)
+	m_ := cub(l - 0.1055613458*a - 0.0638541728*b)
+	s_ := cub(l - 0.0894841775*a - 1.2914855480*b)
+
+	r = 4.0767416621*l_ - 3.3077115913*m_ + 0.2309699292*s_
+	g = -1.2684380046*l_ + 2.6097574011*m_ - 0.3413193965*s_
+	bb = -0.0041960863*l_ - 0.7034186147*m_ + 1.7076147010*s_
+	return
+}
+
+func XyzToOkLab(x, y, z float64) (l, a, b float64) {
+	return LinearRgbToOkLab(XyzToLinearRgb(x, y, z))
+}
+
+func OkLabToXyz(l, a, b float64) (x, y, z float64) {
+	return LinearRgbToXyz(OkLabToLinearRgb(l, a, b))
+}
+
+// OkLab converts the given color to OkLab space.
+// L is in [0..1], a and b are in about [-0.4..0.4].
+func (col Color) OkLab() (l, a, b float64) {
+	return LinearRgbToOkLab(col.LinearRgb())
+}
+
+// OkLab generates a color by using data given in OkLab space.
+// WARNING: many combinations of `l`, `a`, and `b` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func OkLab(l, a, b float64) Color {
+	return LinearRgb(OkLabToLinearRgb(l, a, b))
+}
+
+// DistanceOkLab is the euclidean distance in OkLab space, which is a good
+// measure of visual similarity that is cheaper to compute than CIEDE2000.
+func (c1 Color) DistanceOkLab(c2 Color) float64 {
+	l1, a1, b1 := c1.OkLab()
+	l2, a2, b2 := c2.OkLab()
+	return math.Sqrt(sq(l1-l2) + sq(a1-a2) + sq(b1-b2))
+}
+
+// BlendOkLab blends two colors in the OkLab color-space.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color) BlendOkLab(c2 Color, t float64) Color {
+	l1, a1, b1 := c1.OkLab()
+	l2, a2, b2 := c2.OkLab()
+	return OkLab(l1+t*(l2-l1),
+		a1+t*(a2-a1),
+		b1+t*(b2-b1))
+}
+
+// OkLch
+
+// OkLabToOkLch converts OkLab to its cylindrical representation.
+func OkLabToOkLch(L, a, b float64) (l, c, h float64) {
+	l = L
+	c = math.Sqrt(sq(a) + sq(b))
+	// The hue of (almost) achromatic colors is just floating point noise.
+	if c > 1e-6 {
+		h = math.Mod(57.29577951308232087721*math.Atan2(b, a)+360.0, 360.0) // Rad2Deg
+	}
+	return
+}
+
+// OkLchToOkLab converts the cylindrical OkLch back to OkLab.
+func OkLchToOkLab(l, c, h float64) (L, a, b float64) {
+	H := 0.01745329251994329576 * h // Deg2Rad
+	a = c * math.Cos(H)
+	b = c * math.Sin(H)
+	L = l
+	return
+}
+
+// OkLch converts the given color to OkLch, the cylindrical form of OkLab.
+// L is in [0..1], C is in about [0..0.4] and h is in [0..360].
+func (col Color) OkLch() (l, c, h float64) {
+	return OkLabToOkLch(col.OkLab())
+}
+
+// OkLch generates a color by using data given in OkLch space.
+// WARNING: many combinations of `l`, `c`, and `h` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func OkLch(l, c, h float64) Color {
+	return OkLab(OkLchToOkLab(l, c, h))
+}
+
+// BlendOkLch blends two colors in the cylindrical OkLab color space.
+// t == 0 results in c1, t == 1 results in c2
+func (col1 Color) BlendOkLch(col2 Color, t float64) Color {
+	l1, c1, h1 := col1.OkLch()
+	l2, c2, h2 := col2.OkLch()
+
+	// Achromatic colors don't have a meaningful hue, take the other one's.
+	if c1 <= 0.00015 && c2 >= 0.00015 {
+		h1 = h2
+	} else if c2 <= 0.00015 && c1 >= 0.00015 {
+		h2 = h1
+	}
+
+	// We know that h are both in [0..360]
+	return OkLch(l1+t*(l2-l1), c1+t*(c2-c1), interp_angle(h1, h2, t))
+}
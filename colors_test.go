@@ -199,6 +199,106 @@ func TestHexConversion(t *testing.T) {
 	}
 }
 
+func TestHexAlpha(t *testing.T) {
+	for i, tt := range []struct {
+		hex   string
+		c     Color
+		alpha float64
+	}{
+		{"#ff0080", Color{1.0, 0.0, 128.0 / 255.0}, 1.0},
+		{"#f08", Color{1.0, 0.0, 0.5333333}, 1.0},
+		{"#f08c", Color{1.0, 0.0, 0.5333333}, 0.8},
+		{"#ff008080", Color{1.0, 0.0, 128.0 / 255.0}, 128.0 / 255.0},
+		{"#FF008000", Color{1.0, 0.0, 128.0 / 255.0}, 0.0},
+	} {
+		c, alpha, err := HexA(tt.hex)
+		if err != nil || !c.AlmostEqualRgb(tt.c) || !almosteq(alpha, tt.alpha) {
+			t.Errorf("%v. HexA(%v) => (%v, %v, %v), want %v, %v", i, tt.hex, c, alpha, err, tt.c, tt.alpha)
+		}
+		if c2, err := Hex(tt.hex); err != nil || c2 != c {
+			t.Errorf("%v. Hex(%v) => (%v, %v), want %v", i, tt.hex, c2, err, c)
+		}
+	}
+
+	if hex := (Color{1.0, 0.0, 128.0 / 255.0}).HexA(0.5); hex != "#ff008080" {
+		t.Errorf("HexA(0.5) => %v, want #ff008080", hex)
+	}
+	if hex := (Color{0.0, 0.0, 0.0}).HexA(2.0); hex != "#000000ff" {
+		t.Errorf("HexA(2.0) => %v, want #000000ff", hex)
+	}
+}
+
+func TestHex16(t *testing.T) {
+	for i, tt := range []struct {
+		hex string
+		c   Color
+	}{
+		{"#ffff00008000", Color{1.0, 0.0, 32768.0 / 65535.0}},
+		{"#0123456789AB", Color{0x0123 / 65535.0, 0x4567 / 65535.0, 0x89ab / 65535.0}},
+		{"#fff000800", Color{1.0, 0.0, 2048.0 / 4095.0}},
+	} {
+		c, err := Hex(tt.hex)
+		if err != nil || math.Abs(c.R-tt.c.R)+math.Abs(c.G-tt.c.G)+math.Abs(c.B-tt.c.B) > 1e-9 {
+			t.Errorf("%v. Hex(%v) => (%v, %v), want %v", i, tt.hex, c, err, tt.c)
+		}
+	}
+
+	for i, tt := range vals {
+		c, err := Hex(tt.c.Hex16())
+		if err != nil || math.Abs(c.R-tt.c.R)+math.Abs(c.G-tt.c.G)+math.Abs(c.B-tt.c.B) > 3.0/65535.0 {
+			t.Errorf("%v. Hex(%v.Hex16()) => (%v, %v)", i, tt.c, c, err)
+		}
+	}
+	if hex := (Color{1.0, 0.0, 0.5}).Hex16(); hex != "#ffff00008000" {
+		t.Errorf("Hex16() => %v, want #ffff00008000", hex)
+	}
+}
+
+func TestFormatHex(t *testing.T) {
+	tests := []struct {
+		c     Color
+		alpha float64
+		opts  HexOptions
+		want  string
+	}{
+		{Color{1, 0.5, 0}, 1, HexOptions{}, "#ff8000"},
+		{Color{1, 0.5, 0}, 0.5, HexOptions{}, "#ff8000"},
+		{Color{1, 0.5, 0}, 0.5, HexOptions{Alpha: true}, "#ff800080"},
+		{Color{1, 0.5, 0}, 1, HexOptions{Alpha: true}, "#ff8000"},
+		{Color{1, 0.5, 0}, 1, HexOptions{Short: true}, "#ff8000"},
+		{Color{1, 0.5, 0}, 1, HexOptions{Uppercase: true}, "#FF8000"},
+		{Color{1, 0.6, 0}, 1, HexOptions{Short: true, Uppercase: true}, "#F90"},
+		{Color{1, 0.6, 0}, 0.2, HexOptions{Short: true, Alpha: true}, "#f903"},
+		{Color{1, 0.6, 0}, 0.5, HexOptions{Short: true, Alpha: true}, "#ff990080"},
+		{Color{1.5, -1, 0}, 2, HexOptions{Short: true, Alpha: true}, "#f00"},
+	}
+	for i, tt := range tests {
+		if got := tt.c.FormatHex(tt.alpha, tt.opts); got != tt.want {
+			t.Errorf("%v. %v.FormatHex(%v, %+v) => %v, want %v", i, tt.c, tt.alpha, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestHexTolerant(t *testing.T) {
+	for i, s := range []string{"#ff8000", "ff8000", "FF8000", "0xff8000", "0XFF8000", " #ff8000\n", "\tff8000 "} {
+		c, err := Hex(s)
+		if err != nil || c != (Color{1.0, 128.0 / 255.0, 0.0}) {
+			t.Errorf("%v. Hex(%q) => (%v, %v), want #ff8000", i, s, c, err)
+		}
+	}
+	for i, s := range []string{"", "#", "0x", "#ggg", "#ff8000x", "##ff8000", "#0xff8000", "0x#ff8000", "#ff 80 00", "ff8000;", "#ff80000"} {
+		if c, err := Hex(s); err == nil {
+			t.Errorf("%v. Hex(%q) => %v, want an error", i, s, c)
+		}
+	}
+}
+
+func BenchmarkHex(bench *testing.B) {
+	for n := 0; n < bench.N; n++ {
+		Hex("#ff8000")
+	}
+}
+
 /// Linear ///
 //////////////
 
@@ -520,6 +620,47 @@ func TestMakeColor(t *testing.T) {
 	}
 }
 
+// genericColor hides the type of a color.Color from MakeColor's fast paths.
+type genericColor struct {
+	color.Color
+}
+
+func TestMakeColorFastPaths(t *testing.T) {
+	for i, c := range []color.Color{
+		color.RGBA{200, 100, 50, 255},
+		color.RGBA{100, 50, 25, 128},
+		color.RGBA{0, 0, 0, 0},
+		color.Gray{77},
+		color.Gray16{12345},
+		color.NRGBA{200, 100, 50, 255},
+		color.NRGBA64{50000, 20000, 100, 0xffff},
+	} {
+		got, gotok := MakeColor(c)
+		want, wantok := MakeColor(genericColor{c})
+		if got != want || gotok != wantok {
+			t.Errorf("%v. MakeColor(%v) => (%v, %v), want (%v, %v)", i, c, got, gotok, want, wantok)
+		}
+	}
+
+	// Unlike the generic path, NRGBA is exact for translucent colors, too.
+	if c, ok := MakeColor(color.NRGBA{200, 100, 50, 3}); c != (Color{200.0 / 255.0, 100.0 / 255.0, 50.0 / 255.0}) || !ok {
+		t.Errorf("MakeColor(NRGBA{200, 100, 50, 3}) => (%v, %v)", c, ok)
+	}
+	if c, ok := MakeColor(color.NRGBA{200, 100, 50, 0}); ok {
+		t.Errorf("MakeColor(NRGBA{200, 100, 50, 0}) => (%v, %v), want false", c, ok)
+	}
+	if c := MakeColorNRGBA64(color.NRGBA64{0xffff, 0, 0x8000, 0}); c != (Color{1, 0, 32768.0 / 65535.0}) {
+		t.Errorf("MakeColorNRGBA64 => %v", c)
+	}
+}
+
+func BenchmarkMakeColorNRGBA(bench *testing.B) {
+	var c color.Color = color.NRGBA{200, 100, 50, 128}
+	for n := 0; n < bench.N; n++ {
+		MakeColor(c)
+	}
+}
+
 /// Issues raised on github ///
 ///////////////////////////////
 
@@ -645,3 +786,112 @@ func TestInterpolation(t *testing.T) {
 		}
 	}
 }
+
+/// OkLab ///
+/////////////
+
+// Reference values from https://bottosson.github.io/posts/oklab/ and colorjs.io
+var oklabvals = []struct {
+	c     Color
+	oklab [3]float64
+	oklch [3]float64
+}{
+	{Color{1.0, 1.0, 1.0}, [3]float64{1.000000, 0.000000, 0.000000}, [3]float64{1.000000, 0.000000, 0.0000}},
+	{Color{1.0, 0.0, 0.0}, [3]float64{0.627955, 0.224863, 0.125846}, [3]float64{0.627955, 0.257683, 29.2339}},
+	{Color{0.0, 1.0, 0.0}, [3]float64{0.866440, -0.233888, 0.179498}, [3]float64{0.866440, 0.294827, 142.4953}},
+	{Color{0.0, 0.0, 1.0}, [3]float64{0.452014, -0.032457, -0.311528}, [3]float64{0.452014, 0.313214, 264.0520}},
+	{Color{0.0, 0.0, 0.0}, [3]float64{0.000000, 0.000000, 0.000000}, [3]float64{0.000000, 0.000000, 0.0000}},
+}
+
+func TestOkLabCreation(t *testing.T) {
+	for i, tt := range oklabvals {
+		c := OkLab(tt.oklab[0], tt.oklab[1], tt.oklab[2])
+		if !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLab(%v) => (%v), want %v (delta %v)", i, tt.oklab, c, tt.c, delta)
+		}
+		c = OkLch(tt.oklch[0], tt.oklch[1], tt.oklch[2])
+		if !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLch(%v) => (%v), want %v (delta %v)", i, tt.oklch, c, tt.c, delta)
+		}
+	}
+}
+
+func TestOkLabConversion(t *testing.T) {
+	for i, tt := range oklabvals {
+		l, a, b := tt.c.OkLab()
+		if !almosteq(l, tt.oklab[0]) || !almosteq(a, tt.oklab[1]) || !almosteq(b, tt.oklab[2]) {
+			t.Errorf("%v. %v.OkLab() => (%v), want %v (delta %v)", i, tt.c, [3]float64{l, a, b}, tt.oklab, delta)
+		}
+		l, c, h := tt.c.OkLch()
+		if !almosteq(l, tt.oklch[0]) || !almosteq(c, tt.oklch[1]) || !almosteq(h, tt.oklch[2]) {
+			t.Errorf("%v. %v.OkLch() => (%v), want %v (delta %v)", i, tt.c, [3]float64{l, c, h}, tt.oklch, delta)
+		}
+	}
+}
+
+func TestOkLabBlend(t *testing.T) {
+	c1, c2 := Color{1, 0, 0}, Color{0, 0, 1}
+	for _, blend := range []func(Color, Color, float64) Color{Color.BlendOkLab, Color.BlendOkLch} {
+		if got := blend(c1, c2, 0); !got.AlmostEqualRgb(c1) {
+			t.Errorf("Blend(%v, %v, 0) => %v, want %v", c1, c2, got, c1)
+		}
+		if got := blend(c1, c2, 1); !got.AlmostEqualRgb(c2) {
+			t.Errorf("Blend(%v, %v, 1) => %v, want %v", c1, c2, got, c2)
+		}
+	}
+	if d := c1.DistanceOkLab(c1); d != 0 {
+		t.Errorf("%v.DistanceOkLab(%v) => %v, want 0", c1, c1, d)
+	}
+}
+
+func TestAlmostEqual(t *testing.T) {
+	tests := []struct {
+		c1, c2   Color
+		epsilon  float64
+		distance func(c1, c2 Color) float64
+		want     bool
+	}{
+		{Color{0.5, 0.5, 0.5}, Color{0.5, 0.5, 0.5}, 1e-9, nil, true},
+		{Color{0.5, 0.5, 0.5}, Color{0.51, 0.5, 0.5}, 0.02, nil, true},
+		{Color{0.5, 0.5, 0.5}, Color{0.6, 0.5, 0.5}, 0.02, nil, false},
+		{Color{0.5, 0.5, 0.5}, Color{0.51, 0.5, 0.5}, 0.005, Color.DistanceOkLab, true},
+		{Color{0.5, 0.5, 0.5}, Color{0.51, 0.5, 0.5}, 0.003, Color.DistanceOkLab, false},
+		{Color{1, 0, 0}, Color{0.99, 0.01, 0}, 0.02, Color.DistanceRgb, true},
+		{Color{1, 0, 0}, Color{0.99, 0.01, 0}, 0.01, Color.DistanceRgb, false},
+		{Color{0, 0, 1}, Color{0.02, 0, 1}, 0.001, Color.DistanceCIEDE2000, true},
+	}
+
+	for i, tt := range tests {
+		if got := tt.c1.AlmostEqual(tt.c2, tt.epsilon, tt.distance); got != tt.want {
+			t.Errorf("%v. %v.AlmostEqual(%v, %v) => (%v), want %v", i, tt.c1, tt.c2, tt.epsilon, got, tt.want)
+		}
+	}
+}
+
+func TestAppendHex(t *testing.T) {
+	for i, tt := range vals {
+		buf := []byte("color: ")
+		if got := string(tt.c.AppendHex(buf)); got != "color: "+tt.hex {
+			t.Errorf("%v. %v.AppendHex() => %q, want %q", i, tt.c, got, "color: "+tt.hex)
+		}
+		if got := string(tt.c.AppendHexA(nil, 0.5)); got != tt.hex+"80" {
+			t.Errorf("%v. %v.AppendHexA(0.5) => %q, want %q", i, tt.c, got, tt.hex+"80")
+		}
+	}
+	if got := (Color{1, 0, 0.5}).Hex16(); got != "#ffff00008000" {
+		t.Errorf("Hex16() => %q, want #ffff00008000", got)
+	}
+
+	buf := make([]byte, 0, 64)
+	c := Color{0.1, 0.2, 0.3}
+	if allocs := testing.AllocsPerRun(100, func() { c.AppendHexA(buf[:0], 0.5) }); allocs != 0 {
+		t.Errorf("AppendHexA allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkHexFormat(bench *testing.B) {
+	c := Color{0.1, 0.2, 0.3}
+	for n := 0; n < bench.N; n++ {
+		_ = c.Hex()
+	}
+}
@@ -0,0 +1,233 @@
+// Per-use conversion settings, for when the package-level defaults (D65 and
+// sRGB) don't fit, for example in servers rendering for many tenants.
+
+package colorful
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// An Observer is a CIE standard observer, which the white points of the
+// standard illuminants depend on.
+type Observer int
+
+const (
+	// Observer2 is the CIE 1931 2° standard observer, used throughout this
+	// package unless configured otherwise.
+	Observer2 Observer = iota
+	// Observer10 is the CIE 1964 10° supplementary standard observer.
+	Observer10
+)
+
+// The white points of the standard illuminants normalized to Y = 1, for the
+// 2° and 10° observers.
+// http://www.easyrgb.com/en/math.php
+var illuminants = map[string][2][3]float64{
+	"a":   {{1.09850, 1.00000, 0.35585}, {1.11144, 1.00000, 0.35200}},
+	"c":   {{0.98074, 1.00000, 1.18232}, {0.97285, 1.00000, 1.16145}},
+	"d50": {{0.96422, 1.00000, 0.82521}, {0.96720, 1.00000, 0.81427}},
+	"d55": {{0.95682, 1.00000, 0.92149}, {0.95799, 1.00000, 0.90926}},
+	"d65": {{0.95047, 1.00000, 1.08883}, {0.94811, 1.00000, 1.07304}},
+	"d75": {{0.94972, 1.00000, 1.22638}, {0.94416, 1.00000, 1.20641}},
+	"e":   {{1.00000, 1.00000, 1.00000}, {1.00000, 1.00000, 1.00000}},
+	"f2":  {{0.99187, 1.00000, 0.67395}, {1.03280, 1.00000, 0.69026}},
+	"f7":  {{0.95044, 1.00000, 1.08755}, {0.95792, 1.00000, 1.07687}},
+	"f11": {{1.00966, 1.00000, 0.64370}, {1.03866, 1.00000, 0.65627}},
+}
+
+// IlluminantWhitePoint returns the white point of the named standard
+// illuminant, one of A, C, D50, D55, D65, D75, E, F2, F7 and F11 in any case,
+// as seen by the given observer.
+func IlluminantWhitePoint(name string, observer Observer) ([3]float64, bool) {
+	wps, ok := illuminants[strings.ToLower(name)]
+	if !ok || observer < Observer2 || observer > Observer10 {
+		return [3]float64{}, false
+	}
+	return wps[observer], true
+}
+
+// A Config carries the reference white and the working RGB space of
+// conversions, which otherwise are the package-level D65 and sRGB. Configs
+// are plain values which the package never modifies, so they can be used
+// from many goroutines at once and different ones can be used side by side.
+//
+// The Colors passed to and returned by the methods of a Config hold values
+// in its working space, for example a Color{1, 0, 0} is the red primary of
+// Display P3 when the working space is "display-p3".
+type Config struct {
+	// WhitePoint is the reference white of the Lab, Luv and HCL
+	// conversions. The zero value means D65.
+	WhitePoint [3]float64
+
+	// Space is the working RGB space, named like in the CSS color()
+	// function: "srgb", "srgb-linear", "display-p3", "a98-rgb",
+	// "prophoto-rgb" or "rec2020". The empty string means sRGB, and so
+	// does an unknown name, which NewConfig and Validate report.
+	Space string
+}
+
+// DefaultConfig returns the configuration the package-level functions use,
+// D65 with the 2° observer and sRGB.
+func DefaultConfig() Config {
+	return Config{WhitePoint: D65, Space: "srgb"}
+}
+
+// NewConfig returns a configuration of the named standard illuminant as seen
+// by the given observer, see IlluminantWhitePoint, and the named working
+// space, see Config.
+func NewConfig(illuminant string, observer Observer, space string) (Config, error) {
+	wp, ok := IlluminantWhitePoint(illuminant, observer)
+	if !ok {
+		return Config{}, fmt.Errorf("color: unknown illuminant %q for observer %v", illuminant, observer)
+	}
+	cfg := Config{WhitePoint: wp, Space: strings.ToLower(space)}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if the working space of a Config, for example
+// one built as a struct literal, is unknown. Such Configs work like sRGB.
+func (cfg Config) Validate() error {
+	if _, ok := workingSpace(cfg.Space); !ok {
+		return fmt.Errorf("color: unknown working space %q", cfg.Space)
+	}
+	return nil
+}
+
+func (cfg Config) whitePoint() [3]float64 {
+	if cfg.WhitePoint == ([3]float64{}) {
+		return D65
+	}
+	return cfg.WhitePoint
+}
+
+func (cfg Config) space() *RGBSpace {
+	if s, ok := workingSpace(cfg.Space); ok {
+		return s
+	}
+	return srgbSpace
+}
+
+// workingSpace looks up the RGB space of the given name, see Config.Space.
+func workingSpace(name string) (*RGBSpace, bool) {
+	if name == "" {
+		return srgbSpace, true
+	}
+	s, ok := cssColorSpaces[strings.ToLower(name)]
+	return s, ok
+}
+
+type configKey struct{}
+
+// NewContext returns a copy of ctx which carries the configuration, for
+// threading per-request settings through a server.
+func NewContext(ctx context.Context, cfg Config) context.Context {
+	return context.WithValue(ctx, configKey{}, cfg)
+}
+
+// ConfigFromContext returns the configuration carried by ctx, or
+// DefaultConfig if there is none.
+func ConfigFromContext(ctx context.Context) Config {
+	if cfg, ok := ctx.Value(configKey{}).(Config); ok {
+		return cfg
+	}
+	return DefaultConfig()
+}
+
+// Xyz converts the color of the working space to CIE XYZ space, which is
+// always relative to D65 like the package-level Xyz.
+func (cfg Config) Xyz(col Color) (x, y, z float64) {
+	s := cfg.space()
+	return s.toXyz.mul(s.decode(col.R), s.decode(col.G), s.decode(col.B))
+}
+
+// FromXyz converts from CIE XYZ space to a color of the working space.
+func (cfg Config) FromXyz(x, y, z float64) Color {
+	s := cfg.space()
+	r, g, b := s.fromXyz.mul(x, y, z)
+	return Color{s.encode(r), s.encode(g), s.encode(b)}
+}
+
+// Lab converts the color to CIE L*a*b* space using the configured reference white.
+func (cfg Config) Lab(col Color) (l, a, b float64) {
+	x, y, z := cfg.Xyz(col)
+	return XyzToLabWhiteRef(x, y, z, cfg.whitePoint())
+}
+
+// FromLab generates a color by using data given in CIE L*a*b* space using
+// the configured reference white.
+func (cfg Config) FromLab(l, a, b float64) Color {
+	return cfg.FromXyz(LabToXyzWhiteRef(l, a, b, cfg.whitePoint()))
+}
+
+// Luv converts the color to CIE L*u*v* space using the configured reference white.
+func (cfg Config) Luv(col Color) (l, u, v float64) {
+	x, y, z := cfg.Xyz(col)
+	return XyzToLuvWhiteRef(x, y, z, cfg.whitePoint())
+}
+
+// FromLuv generates a color by using data given in CIE L*u*v* space using
+// the configured reference white.
+func (cfg Config) FromLuv(l, u, v float64) Color {
+	return cfg.FromXyz(LuvToXyzWhiteRef(l, u, v, cfg.whitePoint()))
+}
+
+// Hcl converts the color to HCL space using the configured reference white.
+func (cfg Config) Hcl(col Color) (h, c, l float64) {
+	return LabToHcl(cfg.Lab(col))
+}
+
+// FromHcl generates a color by using data given in HCL space using the
+// configured reference white.
+func (cfg Config) FromHcl(h, c, l float64) Color {
+	return cfg.FromLab(HclToLab(h, c, l))
+}
+
+// DistanceLab is like Color.DistanceLab, using the configuration.
+func (cfg Config) DistanceLab(c1, c2 Color) float64 {
+	l1, a1, b1 := cfg.Lab(c1)
+	l2, a2, b2 := cfg.Lab(c2)
+	return vec3[float64]{l1, a1, b1}.dist(vec3[float64]{l2, a2, b2})
+}
+
+// DistanceLuv is like Color.DistanceLuv, using the configuration.
+func (cfg Config) DistanceLuv(c1, c2 Color) float64 {
+	l1, u1, v1 := cfg.Luv(c1)
+	l2, u2, v2 := cfg.Luv(c2)
+	return vec3[float64]{l1, u1, v1}.dist(vec3[float64]{l2, u2, v2})
+}
+
+// BlendLab is like Color.BlendLab, using the configuration.
+// t == 0 results in c1, t == 1 results in c2
+func (cfg Config) BlendLab(c1, c2 Color, t float64) Color {
+	l1, a1, b1 := cfg.Lab(c1)
+	l2, a2, b2 := cfg.Lab(c2)
+	return cfg.FromLab(l1+t*(l2-l1), a1+t*(a2-a1), b1+t*(b2-b1))
+}
+
+// BlendLuv is like Color.BlendLuv, using the configuration.
+// t == 0 results in c1, t == 1 results in c2
+func (cfg Config) BlendLuv(c1, c2 Color, t float64) Color {
+	l1, u1, v1 := cfg.Luv(c1)
+	l2, u2, v2 := cfg.Luv(c2)
+	return cfg.FromLuv(l1+t*(l2-l1), u1+t*(u2-u1), v1+t*(v2-v1))
+}
+
+// BlendHcl is like Color.BlendHcl, using the configuration.
+// t == 0 results in c1, t == 1 results in c2
+func (cfg Config) BlendHcl(col1, col2 Color, t float64) Color {
+	h1, c1, l1 := cfg.Hcl(col1)
+	h2, c2, l2 := cfg.Hcl(col2)
+
+	if c1 <= 0.00015 && c2 >= 0.00015 {
+		h1 = h2
+	} else if c2 <= 0.00015 && c1 >= 0.00015 {
+		h2 = h1
+	}
+
+	return cfg.FromHcl(interp_angle(h1, h2, t), c1+t*(c2-c1), l1+t*(l2-l1)).Clamped()
+}
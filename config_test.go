@@ -0,0 +1,101 @@
+package colorful
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	for i, tt := range vals {
+		if l, a, b := cfg.Lab(tt.c); !almosteq(l, tt.lab[0]) || !almosteq(a, tt.lab[1]) || !almosteq(b, tt.lab[2]) {
+			t.Errorf("%v. Config.Lab(%v) => (%v, %v, %v), want %v", i, tt.c, l, a, b, tt.lab)
+		}
+		if l, u, v := cfg.Luv(tt.c); !almosteq(l, tt.luv[0]) || !almosteq(u, tt.luv[1]) || !almosteq(v, tt.luv[2]) {
+			t.Errorf("%v. Config.Luv(%v) => (%v, %v, %v), want %v", i, tt.c, l, u, v, tt.luv)
+		}
+		if c := cfg.FromHcl(cfg.Hcl(tt.c)); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Config.FromHcl(Config.Hcl(%v)) => (%v), want %v", i, tt.c, c, tt.c)
+		}
+		if c := (Config{}).FromLab(tt.lab[0], tt.lab[1], tt.lab[2]); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Config{}.FromLab(%v) => (%v), want %v", i, tt.lab, c, tt.c)
+		}
+	}
+}
+
+func TestConfigWhitePoint(t *testing.T) {
+	cfg, err := NewConfig("D50", Observer2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tt := range vals {
+		if l, a, b := cfg.Lab(tt.c); !almosteq(l, tt.lab50[0]) || !almosteq(a, tt.lab50[1]) || !almosteq(b, tt.lab50[2]) {
+			t.Errorf("%v. Config.Lab(%v) => (%v, %v, %v), want %v", i, tt.c, l, a, b, tt.lab50)
+		}
+		if c := cfg.FromLuv(cfg.Luv(tt.c)); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Config.FromLuv(Config.Luv(%v)) => (%v), want %v", i, tt.c, c, tt.c)
+		}
+	}
+
+	wp, _ := IlluminantWhitePoint("d65", Observer10)
+	if wp == D65 {
+		t.Errorf("IlluminantWhitePoint(d65, Observer10) => %v, want it to differ from D65", wp)
+	}
+}
+
+func TestConfigSpace(t *testing.T) {
+	cfg := Config{Space: "display-p3"}
+	red := cfg.FromXyz(Xyz(1, 0, 0).Xyz())
+	if red.AlmostEqualRgb(Color{1, 0, 0}) {
+		t.Errorf("sRGB red in Display P3 => %v, want it to differ", red)
+	}
+
+	p3red := MustParseCSS("color(display-p3 1 0 0)")
+	l, a, b := cfg.Lab(Color{1, 0, 0})
+	if wl, wa, wb := p3red.Lab(); !almosteq(l, wl) || !almosteq(a, wa) || !almosteq(b, wb) {
+		t.Errorf("Config.Lab(P3 red) => (%v, %v, %v), want (%v, %v, %v)", l, a, b, wl, wa, wb)
+	}
+
+	for i, tt := range vals {
+		if c := cfg.FromLab(cfg.Lab(tt.c)); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Config.FromLab(Config.Lab(%v)) => (%v), want %v", i, tt.c, c, tt.c)
+		}
+	}
+}
+
+func TestNewConfigErrors(t *testing.T) {
+	if _, err := NewConfig("D93", Observer2, ""); err == nil {
+		t.Errorf("NewConfig(D93) didn't fail")
+	}
+	if _, err := NewConfig("D65", Observer(2), ""); err == nil {
+		t.Errorf("NewConfig(Observer(2)) didn't fail")
+	}
+	if _, err := NewConfig("D65", Observer2, "adobe"); err == nil {
+		t.Errorf("NewConfig(adobe) didn't fail")
+	}
+	if cfg, err := NewConfig("d65", Observer10, "Rec2020"); err != nil || cfg.Space != "rec2020" {
+		t.Errorf("NewConfig(d65, Observer10, Rec2020) => (%v, %v)", cfg, err)
+	}
+
+	// Configs built as literals with an unknown space fall back to sRGB.
+	cfg := Config{Space: "adobe"}
+	if cfg.Validate() == nil {
+		t.Errorf("Config{Space: adobe}.Validate() didn't fail")
+	}
+	if c := cfg.FromLab(Color{1, 0.5, 0}.Lab()); !c.AlmostEqualRgb(Color{1, 0.5, 0}) {
+		t.Errorf("Config{Space: adobe} => %v, want sRGB", c)
+	}
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig().Validate() => %v", err)
+	}
+}
+
+func TestConfigContext(t *testing.T) {
+	if cfg := ConfigFromContext(context.Background()); cfg != DefaultConfig() {
+		t.Errorf("ConfigFromContext(Background) => %v, want %v", cfg, DefaultConfig())
+	}
+	want := Config{WhitePoint: D50, Space: "display-p3"}
+	if cfg := ConfigFromContext(NewContext(context.Background(), want)); cfg != want {
+		t.Errorf("ConfigFromContext(NewContext(%v)) => %v", want, cfg)
+	}
+}
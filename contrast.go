@@ -0,0 +1,254 @@
+// Contrast between foreground and background colors, as used by the
+// accessibility guidelines for text.
+
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+// Luminance returns the relative luminance of the color, its CIE Y value,
+// from 0 for black to 1 for white. Unlike lightness it is linear in the
+// amount of light, so mid gray has a luminance of about 0.2.
+func (col Color) Luminance() float64 {
+	_, y, _ := col.Xyz()
+	return y
+}
+
+// The luminance at which black and white text have the same WCAG 2 contrast.
+const darkLuminance = 0.179
+
+// IsDark reports whether the color is dark, meaning that white text on it has
+// more contrast than black text, which is the case for a luminance below
+// 0.179 or an L* below about 49.4.
+func (col Color) IsDark() bool {
+	return col.Luminance() < darkLuminance
+}
+
+// IsLight is the opposite of IsDark, black text on the color has more contrast
+// than white text.
+func (col Color) IsLight() bool {
+	return !col.IsDark()
+}
+
+/// WCAG 2 ///
+//////////////
+
+// ContrastRatio returns the contrast ratio of WCAG 2 between the two colors,
+// from 1 for equal colors to 21 for black and white. It is symmetric.
+// https://www.w3.org/TR/WCAG22/#dfn-contrast-ratio
+func (col Color) ContrastRatio(col2 Color) float64 {
+	l1 := luminance(col.Clamped().LinearRgb())
+	l2 := luminance(col2.Clamped().LinearRgb())
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// A WCAGLevel is a conformance level of WCAG 2.
+type WCAGLevel int
+
+const (
+	WCAGAA WCAGLevel = iota
+	WCAGAAA
+)
+
+// MinContrast returns the contrast ratio text needs to reach the level. Large
+// text is at least 18 point, or 14 point and bold.
+func (level WCAGLevel) MinContrast(largeText bool) float64 {
+	switch {
+	case level == WCAGAA && largeText:
+		return 3
+	case level == WCAGAA, level == WCAGAAA && largeText:
+		return 4.5
+	case level == WCAGAAA:
+		return 7
+	}
+	panic("colorful: unknown WCAG level")
+}
+
+// MeetsWCAG reports whether text of the color on the background col2, or the
+// other way around, has enough contrast for the level.
+func (col Color) MeetsWCAG(col2 Color, level WCAGLevel, largeText bool) bool {
+	return col.ContrastRatio(col2) >= level.MinContrast(largeText)
+}
+
+/// APCA ///
+////////////
+
+// apcaLuminance is the screen luminance estimate of APCA, with a simple power
+// curve instead of the sRGB transfer function and a soft clamp of blacks.
+func apcaLuminance(col Color) float64 {
+	col = col.Clamped()
+	y := 0.2126729*math.Pow(col.R, 2.4) + 0.7151522*math.Pow(col.G, 2.4) + 0.0721750*math.Pow(col.B, 2.4)
+	if y < 0.022 {
+		y += math.Pow(0.022-y, 1.414)
+	}
+	return y
+}
+
+// APCAContrast returns the lightness contrast Lc of text of the color on the
+// background bg, as computed by the APCA-W3 0.0.98G-4g algorithm of the WCAG
+// 3 drafts. Unlike ContrastRatio it depends on polarity: dark text on a light
+// background is positive, up to about 106, and light text on a dark one is
+// negative, down to about -108. Body text wants an absolute Lc of 75 or more.
+// https://github.com/Myndex/apca-w3
+func (col Color) APCAContrast(bg Color) float64 {
+	ytxt, ybg := apcaLuminance(col), apcaLuminance(bg)
+	if math.Abs(ybg-ytxt) < 0.0005 {
+		return 0
+	}
+
+	var lc float64
+	if ybg > ytxt {
+		// Normal polarity, dark text on a light background.
+		if sapc := (math.Pow(ybg, 0.56) - math.Pow(ytxt, 0.57)) * 1.14; sapc >= 0.1 {
+			lc = sapc - 0.027
+		}
+	} else {
+		// Reverse polarity, light text on a dark background.
+		if sapc := (math.Pow(ybg, 0.65) - math.Pow(ytxt, 0.62)) * 1.14; sapc <= -0.1 {
+			lc = sapc + 0.027
+		}
+	}
+	return lc * 100
+}
+
+/// Accessible colors ///
+/////////////////////////
+
+// BestTextColor returns black or white, whichever has the higher WCAG 2
+// contrast ratio on the background.
+func BestTextColor(background Color) Color {
+	black, white := Color{0, 0, 0}, Color{1, 1, 1}
+	if black.ContrastRatio(background) >= white.ContrastRatio(background) {
+		return black
+	}
+	return white
+}
+
+// AccessibleOn returns a foreground color with a WCAG 2 contrast ratio of at
+// least target on the background, such as 4.5 for WCAG AA body text. It
+// returns the first of the candidates which is accessible as-is. If none is,
+// it takes the candidate with the highest contrast and changes its OkLch
+// lightness as little as needed, keeping its hue and as much chroma as fits.
+// If no lightness reaches the target, or there are no candidates, it returns
+// BestTextColor.
+func AccessibleOn(background Color, candidates []Color, target float64) Color {
+	best, bestRatio := -1, 0.0
+	for i, c := range candidates {
+		r := c.ContrastRatio(background)
+		if r >= target {
+			return c
+		}
+		if r > bestRatio {
+			best, bestRatio = i, r
+		}
+	}
+	if best < 0 {
+		return BestTextColor(background)
+	}
+
+	if c, ok := reachContrast(candidates[best], background, target); ok {
+		return c
+	}
+	return BestTextColor(background)
+}
+
+// reachContrast changes the OkLch lightness of col as little as needed for a
+// WCAG 2 contrast ratio of at least target on the background, keeping its hue
+// and as much chroma as fits. It reports false if no lightness does.
+func reachContrast(col, background Color, target float64) (Color, bool) {
+	l, c, h := col.OkLch()
+	at := func(l float64) Color {
+		return OkLch(l, c, h).ClampedOkLch()
+	}
+	meets := func(l float64) bool {
+		return at(l).ContrastRatio(background) >= target
+	}
+
+	// Search towards black and towards white, for the lightness closest to
+	// the color's which meets the target.
+	found, foundDist := false, 0.0
+	var result Color
+	for _, end := range []float64{0, 1} {
+		if !meets(end) {
+			continue
+		}
+		lo, hi := end, clamp01(l)
+		for i := 0; i < 32; i++ {
+			mid := (lo + hi) / 2
+			if meets(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		if d := math.Abs(lo - l); !found || d < foundDist {
+			found, foundDist, result = true, d, at(lo)
+		}
+	}
+	return result, found
+}
+
+// A ContrastPair requires the WCAG 2 contrast ratio between the foreground
+// and background entries of a palette, given by their indices, to be at
+// least Min.
+type ContrastPair struct {
+	Fg, Bg int
+	Min    float64
+}
+
+// AdjustForContrast returns a copy of the palette in which every pair meets
+// its contrast requirement, changing as few entries as little as possible. It
+// changes the OkLch lightness of foregrounds, keeping their hue and as much
+// chroma as fits; if a foreground can't reach its target, the background is
+// moved away from it too. Entries can be part of several pairs, which are
+// reconciled over a few rounds. It fails if the requirements contradict each
+// other, returning the best palette it found.
+func AdjustForContrast(palette []Color, pairs []ContrastPair) ([]Color, error) {
+	adjusted := append([]Color(nil), palette...)
+	for _, p := range pairs {
+		if p.Fg < 0 || p.Fg >= len(palette) || p.Bg < 0 || p.Bg >= len(palette) {
+			panic(fmt.Sprintf("colorful: contrast pair %v out of the palette of %v colors", p, len(palette)))
+		}
+	}
+
+	for round := 0; round < 16; round++ {
+		done := true
+		for _, p := range pairs {
+			fg, bg := adjusted[p.Fg], adjusted[p.Bg]
+			// A little headroom keeps rounding from undoing the change.
+			target := p.Min + 1e-6
+			if fg.ContrastRatio(bg) >= p.Min {
+				continue
+			}
+			done = false
+			if c, ok := reachContrast(fg, bg, target); ok {
+				adjusted[p.Fg] = c
+				continue
+			}
+
+			// Even black or white doesn't work, so move the background away
+			// from the better of them first.
+			if c, ok := reachContrast(bg, BestTextColor(bg), target); ok {
+				adjusted[p.Bg] = c
+				if c, ok := reachContrast(fg, c, target); ok {
+					adjusted[p.Fg] = c
+				}
+			}
+		}
+		if done {
+			return adjusted, nil
+		}
+	}
+
+	for _, p := range pairs {
+		if r := adjusted[p.Fg].ContrastRatio(adjusted[p.Bg]); r < p.Min {
+			return adjusted, fmt.Errorf("color: palette entries %v and %v only reach a contrast of %.2f, not %v", p.Fg, p.Bg, r, p.Min)
+		}
+	}
+	return adjusted, nil
+}
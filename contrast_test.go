@@ -0,0 +1,198 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		c1, c2 string
+		ratio  float64
+	}{
+		{"#000000", "#ffffff", 21},
+		{"#ffffff", "#ffffff", 1},
+		{"#777777", "#ffffff", 4.48},
+		{"#767676", "#ffffff", 4.54},
+		{"#0000ff", "#ffffff", 8.59},
+		{"#ff0000", "#00ff00", 2.91},
+	}
+	for i, tt := range tests {
+		c1, c2 := MustHex(tt.c1), MustHex(tt.c2)
+		if got := c1.ContrastRatio(c2); math.Abs(got-tt.ratio) > 0.01 {
+			t.Errorf("%v. %v.ContrastRatio(%v) => %v, want %v", i, tt.c1, tt.c2, got, tt.ratio)
+		}
+		if got := c2.ContrastRatio(c1); math.Abs(got-tt.ratio) > 0.01 {
+			t.Errorf("%v. %v.ContrastRatio(%v) => %v, want %v", i, tt.c2, tt.c1, got, tt.ratio)
+		}
+	}
+}
+
+func TestMeetsWCAG(t *testing.T) {
+	white := Color{1, 1, 1}
+	tests := []struct {
+		fg        string
+		level     WCAGLevel
+		largeText bool
+		want      bool
+	}{
+		{"#767676", WCAGAA, false, true},
+		{"#777777", WCAGAA, false, false},
+		{"#777777", WCAGAA, true, true},
+		{"#767676", WCAGAAA, false, false},
+		{"#767676", WCAGAAA, true, true},
+		{"#595959", WCAGAAA, false, true},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.fg).MeetsWCAG(white, tt.level, tt.largeText); got != tt.want {
+			t.Errorf("%v. %v.MeetsWCAG(white, %v, %v) => %v, want %v", i, tt.fg, tt.level, tt.largeText, got, tt.want)
+		}
+	}
+}
+
+// Test values of the APCA reference implementation.
+func TestAPCAContrast(t *testing.T) {
+	tests := []struct {
+		txt, bg string
+		lc      float64
+	}{
+		{"#888888", "#ffffff", 63.056469930209424},
+		{"#ffffff", "#888888", -68.54146436644962},
+		{"#000000", "#aaaaaa", 58.146262578561334},
+		{"#aaaaaa", "#000000", -56.24113336839742},
+		{"#112233", "#ddeeff", 91.66830811481631},
+		{"#ddeeff", "#112233", -93.06770049484275},
+		{"#000000", "#ffffff", 106.04067321268862},
+		{"#ffffff", "#000000", -107.88473318309848},
+		{"#777777", "#777777", 0},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.txt).APCAContrast(MustHex(tt.bg)); math.Abs(got-tt.lc) > 1e-9 {
+			t.Errorf("%v. %v.APCAContrast(%v) => %v, want %v", i, tt.txt, tt.bg, got, tt.lc)
+		}
+	}
+}
+
+func TestBestTextColor(t *testing.T) {
+	for _, tt := range []struct {
+		bg, want string
+	}{
+		{"#ffffff", "#000000"},
+		{"#000000", "#ffffff"},
+		{"#ffff00", "#000000"},
+		{"#000080", "#ffffff"},
+	} {
+		if got := BestTextColor(MustHex(tt.bg)); got.Hex() != tt.want {
+			t.Errorf("BestTextColor(%v) => %v, want %v", tt.bg, got.Hex(), tt.want)
+		}
+	}
+}
+
+func TestAccessibleOn(t *testing.T) {
+	white := Color{1, 1, 1}
+	pale, blue := MustHex("#aaccff"), MustHex("#0044cc")
+
+	// The first accessible candidate is taken as-is.
+	if got := AccessibleOn(white, []Color{pale, blue}, 4.5); got != blue {
+		t.Errorf("AccessibleOn(white, pale and blue, 4.5) => %v, want %v", got.Hex(), blue.Hex())
+	}
+
+	// Otherwise the candidate is darkened just enough.
+	got := AccessibleOn(white, []Color{pale}, 4.5)
+	if r := got.ContrastRatio(white); r < 4.5 || r > 4.6 {
+		t.Errorf("AccessibleOn(white, pale, 4.5) => %v with contrast %v, want just above 4.5", got.Hex(), r)
+	}
+	_, _, h := pale.OkLch()
+	if _, _, h2 := got.OkLch(); math.Abs(h2-h) > 1 {
+		t.Errorf("AccessibleOn(white, pale, 4.5) => %v with hue %v, want %v", got.Hex(), h2, h)
+	}
+
+	// And lightened on dark backgrounds.
+	navy := MustHex("#001133")
+	if got := AccessibleOn(navy, []Color{blue}, 7); got.ContrastRatio(navy) < 7 {
+		t.Errorf("AccessibleOn(navy, blue, 7) => %v with contrast %v", got.Hex(), got.ContrastRatio(navy))
+	}
+
+	// Impossible targets and no candidates give black or white.
+	if got := AccessibleOn(white, []Color{pale}, 25); got != (Color{0, 0, 0}) {
+		t.Errorf("AccessibleOn(white, pale, 25) => %v, want black", got.Hex())
+	}
+	if got := AccessibleOn(white, nil, 4.5); got != (Color{0, 0, 0}) {
+		t.Errorf("AccessibleOn(white, nil, 4.5) => %v, want black", got.Hex())
+	}
+}
+
+func TestLuminance(t *testing.T) {
+	tests := []struct {
+		c         Color
+		luminance float64
+		dark      bool
+	}{
+		{Color{0, 0, 0}, 0, true},
+		{Color{1, 1, 1}, 1, false},
+		{Color{0.5, 0.5, 0.5}, 0.214041, false},
+		{Color{1, 0, 0}, 0.212673, false},
+		{Color{0, 0, 1}, 0.072175, true},
+		{Color{0, 1, 0}, 0.715152, false},
+	}
+	for i, tt := range tests {
+		if got := tt.c.Luminance(); !almosteq(got, tt.luminance) {
+			t.Errorf("%v. %v.Luminance() => %v, want %v", i, tt.c, got, tt.luminance)
+		}
+		if got := tt.c.IsDark(); got != tt.dark {
+			t.Errorf("%v. %v.IsDark() => %v, want %v", i, tt.c, got, tt.dark)
+		}
+		if got := tt.c.IsLight(); got == tt.dark {
+			t.Errorf("%v. %v.IsLight() => %v, want %v", i, tt.c, got, !tt.dark)
+		}
+	}
+
+	// IsDark agrees with BestTextColor.
+	for _, c := range randomColors(100) {
+		if math.Abs(c.Luminance()-darkLuminance) < 1e-3 {
+			continue
+		}
+		if c.IsDark() != (BestTextColor(c) == Color{1, 1, 1}) {
+			t.Errorf("%v.IsDark() => %v, but BestTextColor is %v", c, c.IsDark(), BestTextColor(c))
+		}
+	}
+}
+
+func TestAdjustForContrast(t *testing.T) {
+	bg, text, accent, muted := MustHex("#ffffff"), MustHex("#888888"), MustHex("#ff8844"), MustHex("#334455")
+	palette := []Color{bg, text, accent, muted}
+	pairs := []ContrastPair{{1, 0, 4.5}, {2, 0, 3}, {3, 0, 4.5}}
+
+	got, err := AdjustForContrast(palette, pairs)
+	if err != nil {
+		t.Fatalf("AdjustForContrast => error %v", err)
+	}
+	for _, p := range pairs {
+		if r := got[p.Fg].ContrastRatio(got[p.Bg]); r < p.Min {
+			t.Errorf("AdjustForContrast => contrast %v between %v and %v, want %v", r, p.Fg, p.Bg, p.Min)
+		}
+	}
+	if got[0] != bg || got[3] != muted {
+		t.Errorf("AdjustForContrast changed entries which were fine: %v", got)
+	}
+	if palette[1] != text {
+		t.Errorf("AdjustForContrast modified its input")
+	}
+	_, _, h1 := accent.OkLch()
+	if _, _, h2 := got[2].OkLch(); math.Abs(h1-h2) > 1 {
+		t.Errorf("AdjustForContrast => accent %v of hue %v, want %v", got[2].Hex(), h2, h1)
+	}
+
+	// Unreachable by the foreground alone, so the background has to move.
+	gray, light := MustHex("#777777"), MustHex("#999999")
+	got, err = AdjustForContrast([]Color{gray, light}, []ContrastPair{{1, 0, 7}})
+	if err != nil || got[1].ContrastRatio(got[0]) < 7 {
+		t.Errorf("AdjustForContrast(gray, light) => %v, %v", got, err)
+	}
+
+	// Contradicting requirements.
+	_, err = AdjustForContrast([]Color{gray, light}, []ContrastPair{{1, 0, 7}, {0, 1, 1}, {1, 0, 30}})
+	if err == nil {
+		t.Errorf("AdjustForContrast with an impossible requirement => no error")
+	}
+}
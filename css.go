@@ -0,0 +1,534 @@
+// Parsing of colors written in CSS syntax, as specified by CSS Color Level 4.
+// https://www.w3.org/TR/css-color-4/
+
+package colorful
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseCSS parses a color given in any of the CSS Color Level 4 notations:
+// hex colors of 3, 4, 6 or 8 digits after the "#", named colors as well as the
+// rgb(), rgba(), hsl(), hsla(), hwb(), lab(), lch(), oklab(), oklch() and
+// color() functions, including percentages, angle units, the `none` keyword
+// and the legacy comma-separated syntax.
+// The color() function supports the srgb, srgb-linear, display-p3, a98-rgb,
+// prophoto-rgb, rec2020, xyz, xyz-d50 and xyz-d65 spaces.
+//
+// The alpha component is validated, but dropped. Use ParseCSSA to get it.
+// Note that colors given in wide-gamut notations may well lie outside of sRGB,
+// i.e. not be valid.
+//
+// Malformed colors yield an ErrInvalidCSS, or an ErrInvalidHex for hex
+// colors. As the CSS specification demands, values outside of the range of
+// their channel are not an error but clamped, so rgb(300 -20 0) is red. This
+// applies to the channels of rgb(), hsl() and hwb(), to alpha, and to negative
+// lightness and chroma.
+func ParseCSS(s string) (Color, error) {
+	col, _, err := parseCSS(s)
+	return col, err
+}
+
+// ParseCSSA parses a CSS color like ParseCSS does, but also returns its alpha
+// in [0..1], as given by e.g. rgba(255, 128, 0, 0.5), hsl(30 100% 50% / 50%)
+// or #ff800080. It is 1 for colors without alpha and 0 for transparent.
+func ParseCSSA(s string) (Color, float64, error) {
+	return parseCSS(s)
+}
+
+// MustParseCSS is like ParseCSS, but panics if s is not a valid CSS color.
+// It is meant for initializing package-level variables from constants.
+func MustParseCSS(s string) Color {
+	col, err := ParseCSS(s)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// Parse is the single entry point for colors coming from configuration files
+// and the like. It detects the notation and accepts everything ParseCSS does,
+// as well as the more tolerant hex forms Hex accepts, like "ff8800" or
+// "0xff8800". Named colors take precedence over hex colors without prefix.
+func Parse(s string) (Color, error) {
+	col, err := ParseCSS(s)
+	if err == nil {
+		return col, nil
+	}
+	if col, herr := Hex(s); herr == nil {
+		return col, nil
+	}
+	return Color{}, err
+}
+
+// ColorFlag is a flag.Value accepting any color ParseCSS understands, so that
+// command line tools can take colors like --color '#ff8800' or
+// --color 'oklch(70% 0.1 50)'. Its zero value is black:
+//
+//	var fg colorful.ColorFlag
+//	flag.Var(&fg, "color", "text `color` in CSS syntax")
+type ColorFlag Color
+
+// Set implements flag.Value.
+func (f *ColorFlag) Set(s string) error {
+	col, err := ParseCSS(s)
+	if err != nil {
+		return err
+	}
+	*f = ColorFlag(col)
+	return nil
+}
+
+// String implements flag.Value and returns the hex representation.
+func (f *ColorFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return Color(*f).Hex()
+}
+
+// Get implements flag.Getter and returns the Color.
+func (f *ColorFlag) Get() interface{} {
+	return Color(*f)
+}
+
+// Value returns the parsed Color.
+func (f ColorFlag) Value() Color {
+	return Color(f)
+}
+
+func parseCSS(s string) (col Color, alpha float64, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "#") {
+		return parseCSSHex(s)
+	}
+	if s == "transparent" {
+		return Color{}, 0, nil
+	}
+	if col, ok := namesToColors[s]; ok {
+		return col, 1.0, nil
+	}
+
+	open := strings.IndexByte(s, '(')
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return Color{}, 0, ErrInvalidCSS{s, ""}
+	}
+	fn := strings.TrimSpace(s[:open])
+	args, alphaArg, legacy, err := splitCSSArgs(s[open+1 : len(s)-1])
+	if err != nil {
+		return Color{}, 0, ErrInvalidCSS{s, err.Error()}
+	}
+
+	if fn == "color" {
+		if len(args) == 0 {
+			return Color{}, 0, ErrInvalidCSS{s, "missing a color space"}
+		}
+		col, alpha, err = parseCSSColorFunction(args[0], args[1:], alphaArg)
+	} else if legacy && fn != "rgb" && fn != "rgba" && fn != "hsl" && fn != "hsla" {
+		err = fmt.Errorf("only rgb() and hsl() allow commas")
+	} else {
+		col, alpha, err = parseCSSFunction(fn, args, alphaArg, legacy)
+	}
+
+	if err != nil {
+		return Color{}, 0, ErrInvalidCSS{s, err.Error()}
+	}
+	return col, alpha, nil
+}
+
+// parseCSSHex parses the hex notations of CSS, "#" followed by exactly 3, 4, 6
+// or 8 hex digits, unlike the more tolerant HexA.
+func parseCSSHex(s string) (Color, float64, error) {
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return Color{}, 0, ErrInvalidHex{s, i}
+		}
+	}
+	switch len(s) - 1 {
+	case 3, 4, 6, 8:
+		return HexA(s)
+	}
+	return Color{}, 0, ErrInvalidHex{s, -1}
+}
+
+// splitCSSArgs splits the arguments of a CSS color function. The alpha value,
+// if any, is returned separately: in the modern syntax it must follow a "/",
+// in the legacy syntax it is the fourth comma-separated value.
+func splitCSSArgs(s string) (args, alpha []string, legacy bool, err error) {
+	if strings.Contains(s, ",") {
+		args = strings.Split(s, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+			if args[i] == "" || strings.ContainsAny(args[i], " \t\n/") {
+				return nil, nil, true, fmt.Errorf("malformed legacy arguments")
+			}
+		}
+		if len(args) == 4 {
+			return args[:3], args[3:], true, nil
+		}
+		return args, nil, true, nil
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) > 2 {
+		return nil, nil, false, fmt.Errorf("more than one \"/\"")
+	}
+	args = strings.Fields(parts[0])
+	if len(parts) == 2 {
+		alpha = strings.Fields(parts[1])
+		if len(alpha) != 1 {
+			return nil, nil, false, fmt.Errorf("expected exactly one alpha value after \"/\"")
+		}
+	}
+	return args, alpha, false, nil
+}
+
+// A cssValue is a number together with its unit, which may be "", "%" or an
+// angle unit. none is true for the `none` keyword, whose value is 0.
+type cssValue struct {
+	v    float64
+	unit string
+	none bool
+}
+
+func parseCSSValue(s string) (cssValue, error) {
+	if s == "none" {
+		return cssValue{none: true}, nil
+	}
+
+	end := len(s)
+	for end > 0 && (s[end-1] == '%' || ('a' <= s[end-1] && s[end-1] <= 'z')) {
+		end--
+	}
+	// Since we strip all trailing letters, inf and nan never make it here.
+	v, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return cssValue{}, fmt.Errorf("invalid value %q", s)
+	}
+	return cssValue{v: v, unit: s[end:]}, nil
+}
+
+// number resolves a value which may be a plain number or a percentage, in
+// which case 100% corresponds to the given reference number.
+func (v cssValue) number(percentRef float64) (float64, error) {
+	switch v.unit {
+	case "":
+		return v.v, nil
+	case "%":
+		return v.v / 100.0 * percentRef, nil
+	}
+	return 0, fmt.Errorf("unexpected unit %q", v.unit)
+}
+
+// hue resolves a value which may be a plain number (degrees) or an angle,
+// and normalizes it to [0..360).
+func (v cssValue) hue() (float64, error) {
+	deg := v.v
+	switch v.unit {
+	case "", "deg":
+	case "rad":
+		deg *= 180.0 / math.Pi
+	case "grad":
+		deg *= 0.9
+	case "turn":
+		deg *= 360.0
+	default:
+		return 0, fmt.Errorf("unexpected hue unit %q", v.unit)
+	}
+	return math.Mod(math.Mod(deg, 360.0)+360.0, 360.0), nil
+}
+
+// parseCSSAlpha parses the optional alpha argument, which defaults to 1.
+func parseCSSAlpha(args []string, legacy bool) (float64, error) {
+	if len(args) == 0 {
+		return 1.0, nil
+	}
+	v, err := parseCSSValue(args[0])
+	if err != nil {
+		return 0, err
+	}
+	if v.none && legacy {
+		return 0, fmt.Errorf("none is not allowed in the legacy syntax")
+	}
+	alpha, err := v.number(1.0)
+	return clamp01(alpha), err
+}
+
+// parseCSSFunction handles all the functions with three channels, where
+// kinds describes each channel: 'h' is a hue, any other letter is a number
+// or percentage with 100% corresponding to the given reference.
+func parseCSSFunction(fn string, args, alphaArg []string, legacy bool) (col Color, alpha float64, err error) {
+	var kinds string
+	var refs [3]float64
+	switch fn {
+	case "rgb", "rgba":
+		kinds, refs = "nnn", [3]float64{255, 255, 255}
+	case "hsl", "hsla":
+		kinds, refs = "hnn", [3]float64{0, 100, 100}
+	case "hwb":
+		kinds, refs = "hnn", [3]float64{0, 100, 100}
+	case "lab":
+		kinds, refs = "nnn", [3]float64{100, 125, 125}
+	case "lch":
+		kinds, refs = "nnh", [3]float64{100, 150, 0}
+	case "oklab":
+		kinds, refs = "nnn", [3]float64{1, 0.4, 0.4}
+	case "oklch":
+		kinds, refs = "nnh", [3]float64{1, 0.4, 0}
+	default:
+		return Color{}, 0, fmt.Errorf("unknown function %q", fn)
+	}
+
+	if len(args) != 3 {
+		return Color{}, 0, fmt.Errorf("expected 3 values and an optional alpha, got %v values", len(args))
+	}
+
+	var v [3]float64
+	var units [3]string
+	for i := range v {
+		val, err := parseCSSValue(args[i])
+		if err != nil {
+			return Color{}, 0, err
+		}
+		if val.none && legacy {
+			return Color{}, 0, fmt.Errorf("none is not allowed in the legacy syntax")
+		}
+		units[i] = val.unit
+		if kinds[i] == 'h' {
+			v[i], err = val.hue()
+		} else {
+			v[i], err = val.number(refs[i])
+		}
+		if err != nil {
+			return Color{}, 0, err
+		}
+	}
+	if alpha, err = parseCSSAlpha(alphaArg, legacy); err != nil {
+		return Color{}, 0, err
+	}
+
+	switch fn {
+	case "rgb", "rgba":
+		// Only the modern syntax allows mixing numbers and percentages.
+		if legacy && ((units[0] == "%") != (units[1] == "%") || (units[1] == "%") != (units[2] == "%")) {
+			return Color{}, 0, fmt.Errorf("cannot mix numbers and percentages in the legacy syntax")
+		}
+		col = Color{clamp01(v[0] / 255.0), clamp01(v[1] / 255.0), clamp01(v[2] / 255.0)}
+	case "hsl", "hsla":
+		if legacy && (units[1] != "%" || units[2] != "%") {
+			return Color{}, 0, fmt.Errorf("saturation and lightness need to be percentages in the legacy syntax")
+		}
+		col = Hsl(v[0], clamp01(v[1]/100.0), clamp01(v[2]/100.0))
+	case "hwb":
+		col = hwb(v[0], clamp01(v[1]/100.0), clamp01(v[2]/100.0))
+	case "lab":
+		col = cssLab(math.Max(v[0], 0.0), v[1], v[2])
+	case "lch":
+		h := 0.01745329251994329576 * v[2] // Deg2Rad
+		c := math.Max(v[1], 0.0)
+		col = cssLab(math.Max(v[0], 0.0), c*math.Cos(h), c*math.Sin(h))
+	case "oklab":
+		col = OkLab(math.Max(v[0], 0.0), v[1], v[2])
+	case "oklch":
+		col = OkLch(math.Max(v[0], 0.0), math.Max(v[1], 0.0), v[2])
+	}
+	return col, alpha, nil
+}
+
+// hwb converts from HWB (hue, whiteness, blackness) as defined by CSS.
+func hwb(h, w, b float64) Color {
+	if w+b >= 1.0 {
+		gray := w / (w + b)
+		return Color{gray, gray, gray}
+	}
+	col := Hsv(h, 1.0, 1.0)
+	scale := 1.0 - w - b
+	return Color{col.R*scale + w, col.G*scale + w, col.B*scale + w}
+}
+
+// cssLab converts from CSS's CIE Lab, which uses a D50 white with Bradford
+// adaptation and L in [0..100], unlike this package's D65 Lab.
+func cssLab(l, a, b float64) Color {
+	x, y, z := LabToXyzWhiteRef(l/100.0, a/100.0, b/100.0, D50)
+	return Xyz(bradfordD50ToD65.mul(x, y, z))
+}
+
+var cssColorSpaces = map[string]*RGBSpace{
+	"srgb":         srgbSpace,
+	"srgb-linear":  srgbLinearSpace,
+	"display-p3":   displayP3Space,
+	"a98-rgb":      a98RgbSpace,
+	"prophoto-rgb": prophotoRgbSpace,
+	"rec2020":      rec2020Space,
+}
+
+// parseCSSColorFunction handles color(space c1 c2 c3 [/ alpha]).
+func parseCSSColorFunction(space string, args, alphaArg []string) (col Color, alpha float64, err error) {
+	if len(args) != 3 {
+		return Color{}, 0, fmt.Errorf("expected 3 values and an optional alpha, got %v values", len(args))
+	}
+
+	var v [3]float64
+	for i := range v {
+		val, err := parseCSSValue(args[i])
+		if err != nil {
+			return Color{}, 0, err
+		}
+		if v[i], err = val.number(1.0); err != nil {
+			return Color{}, 0, err
+		}
+	}
+	if alpha, err = parseCSSAlpha(alphaArg, false); err != nil {
+		return Color{}, 0, err
+	}
+
+	switch space {
+	case "xyz", "xyz-d65":
+		return Xyz(v[0], v[1], v[2]), alpha, nil
+	case "xyz-d50":
+		return Xyz(bradfordD50ToD65.mul(v[0], v[1], v[2])), alpha, nil
+	case "srgb":
+		// Skip the round-trip through XYZ, which adds noise.
+		return Color{v[0], v[1], v[2]}, alpha, nil
+	case "srgb-linear":
+		return LinearRgb(v[0], v[1], v[2]), alpha, nil
+	}
+	rgb, ok := cssColorSpaces[space]
+	if !ok {
+		return Color{}, 0, fmt.Errorf("unknown color space %q", space)
+	}
+	return rgb.toColor(v[0], v[1], v[2]), alpha, nil
+}
+
+/// Serialization ///
+/////////////////////
+
+// FormatCSS writes the color in the given CSS notation, which is one of rgb,
+// hsl, hwb, lab, lch, oklab and oklch or the name of a color() space like
+// display-p3. Numbers are written with up to precision digits after the
+// decimal point, a negative precision selects a sensible default for the
+// notation. Colors are clamped for the rgb, hsl and hwb notations, which can't
+// express out-of-gamut colors, but not for any of the others.
+func (col Color) FormatCSS(notation string, precision int) (string, error) {
+	defaults := map[string]int{"rgb": 0, "hsl": 1, "hwb": 1, "lab": 2, "lch": 2, "oklab": 4, "oklch": 4}
+	if p, ok := defaults[notation]; ok && precision < 0 {
+		precision = p
+	} else if precision < 0 {
+		precision = 4
+	}
+	f := func(v float64) string {
+		return formatCSSNumber(v, precision)
+	}
+
+	switch notation {
+	case "rgb":
+		c := col.Clamped()
+		return "rgb(" + f(c.R*255.0) + " " + f(c.G*255.0) + " " + f(c.B*255.0) + ")", nil
+	case "hsl":
+		h, s, l := col.Clamped().Hsl()
+		return "hsl(" + f(h) + " " + f(s*100.0) + "% " + f(l*100.0) + "%)", nil
+	case "hwb":
+		c := col.Clamped()
+		h, _, _ := c.Hsv()
+		w := math.Min(math.Min(c.R, c.G), c.B)
+		b := 1.0 - math.Max(math.Max(c.R, c.G), c.B)
+		return "hwb(" + f(h) + " " + f(w*100.0) + "% " + f(b*100.0) + "%)", nil
+	case "lab":
+		l, a, b := col.cssLab()
+		return "lab(" + f(l) + " " + f(a) + " " + f(b) + ")", nil
+	case "lch":
+		l, a, b := col.cssLab()
+		h, c, _ := LabToHcl(l, a, b)
+		return "lch(" + f(l) + " " + f(c) + " " + f(h) + ")", nil
+	case "oklab":
+		l, a, b := col.OkLab()
+		return "oklab(" + f(l) + " " + f(a) + " " + f(b) + ")", nil
+	case "oklch":
+		l, c, h := col.OkLch()
+		return "oklch(" + f(l) + " " + f(c) + " " + f(h) + ")", nil
+	case "xyz", "xyz-d65":
+		x, y, z := col.Xyz()
+		return "color(" + notation + " " + f(x) + " " + f(y) + " " + f(z) + ")", nil
+	case "xyz-d50":
+		x, y, z := bradfordD65ToD50.mul(col.Xyz())
+		return "color(xyz-d50 " + f(x) + " " + f(y) + " " + f(z) + ")", nil
+	}
+
+	if space, ok := cssColorSpaces[notation]; ok {
+		r, g, b := space.fromColor(col)
+		return "color(" + notation + " " + f(r) + " " + f(g) + " " + f(b) + ")", nil
+	}
+	return "", fmt.Errorf("color: unknown CSS notation %q", notation)
+}
+
+// formatCSSNumber writes v with at most precision decimals and no trailing zeros.
+func formatCSSNumber(v float64, precision int) string {
+	s := strconv.FormatFloat(v, 'f', precision, 64)
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+// cssLab returns CSS's CIE Lab, which uses a D50 white with Bradford
+// adaptation and L in [0..100], unlike this package's D65 Lab.
+func (col Color) cssLab() (l, a, b float64) {
+	x, y, z := bradfordD65ToD50.mul(col.Xyz())
+	l, a, b = XyzToLabWhiteRef(x, y, z, D50)
+	return l * 100.0, a * 100.0, b * 100.0
+}
+
+// mustFormatCSS is for the notations we know to be supported.
+func (col Color) mustFormatCSS(notation string) string {
+	s, err := col.FormatCSS(notation, -1)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// CSSRGB returns the color in CSS's rgb() notation, as in rgb(255 128 0).
+// Out-of-gamut colors are clamped.
+func (col Color) CSSRGB() string {
+	return col.mustFormatCSS("rgb")
+}
+
+// CSSHSL returns the color in CSS's hsl() notation, as in hsl(30 100% 50%).
+// Out-of-gamut colors are clamped.
+func (col Color) CSSHSL() string {
+	return col.mustFormatCSS("hsl")
+}
+
+// CSSHWB returns the color in CSS's hwb() notation, as in hwb(30 0% 0%).
+// Out-of-gamut colors are clamped.
+func (col Color) CSSHWB() string {
+	return col.mustFormatCSS("hwb")
+}
+
+// CSSLab returns the color in CSS's lab() notation, which uses a D50 white
+// and L in [0..100], as in lab(67.72 45.73 74.79).
+func (col Color) CSSLab() string {
+	return col.mustFormatCSS("lab")
+}
+
+// CSSLch returns the color in CSS's lch() notation, which uses a D50 white
+// and L in [0..100], as in lch(67.72 87.67 58.56).
+func (col Color) CSSLch() string {
+	return col.mustFormatCSS("lch")
+}
+
+// CSSOkLab returns the color in CSS's oklab() notation, as in oklab(0.7311 0.1126 0.1482).
+func (col Color) CSSOkLab() string {
+	return col.mustFormatCSS("oklab")
+}
+
+// CSSOkLch returns the color in CSS's oklch() notation, as in oklch(0.7311 0.1861 52.7757).
+func (col Color) CSSOkLch() string {
+	return col.mustFormatCSS("oklch")
+}
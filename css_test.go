@@ -0,0 +1,230 @@
+package colorful
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+var cssvals = []struct {
+	s     string
+	c     Color
+	alpha float64
+}{
+	{"#ff0000", Color{1, 0, 0}, 1},
+	{"  #0F0 ", Color{0, 1, 0}, 1},
+	{"#ff000080", Color{1, 0, 0}, 128.0 / 255.0},
+	{"RebeccaPurple", Color{0.4, 0.2, 0.6}, 1},
+	{"transparent", Color{0, 0, 0}, 0},
+	{"rgb(255 0 0)", Color{1, 0, 0}, 1},
+	{"rgb(255, 128, 0)", Color{1, 128.0 / 255.0, 0}, 1},
+	{"RGB(100%, 0%, 0%)", Color{1, 0, 0}, 1},
+	{"rgba(0, 0, 255, 0.5)", Color{0, 0, 1}, 0.5},
+	{"rgba(0%, 0%, 100%, 30%)", Color{0, 0, 1}, 0.3},
+	{"rgba( 0 , 0 , 255 , .75 )", Color{0, 0, 1}, 0.75},
+	{"rgba(0, 0, 255, 2)", Color{0, 0, 1}, 1},
+	{"rgb(0 0 100% / 25%)", Color{0, 0, 1}, 0.25},
+	{"rgb(0 0 255/.25)", Color{0, 0, 1}, 0.25},
+	{"rgb(none 255 0)", Color{0, 1, 0}, 1},
+	{"rgb(300 -20 0)", Color{1, 0, 0}, 1},
+	{"rgb(255 0 0 / none)", Color{1, 0, 0}, 0},
+	{"hsl(120deg 100% 50%)", Color{0, 1, 0}, 1},
+	{"hsl(120 100 50)", Color{0, 1, 0}, 1},
+	{"hsla(240, 100%, 50%, 50%)", Color{0, 0, 1}, 0.5},
+	{"hsla(120, 100%, 25%, 0)", Color{0, 0.5, 0}, 0},
+	{"hsl(240, 100%, 50%, 0.2)", Color{0, 0, 1}, 0.2},
+	{"hsl(0.5turn 100% 50%)", Color{0, 1, 1}, 1},
+	{"hsl(3.14159265rad 100% 50%)", Color{0, 1, 1}, 1},
+	{"hsl(-120 100% 50%)", Color{0, 0, 1}, 1},
+	{"hwb(0 0% 0%)", Color{1, 0, 0}, 1},
+	{"hwb(0 50% 50%)", Color{0.5, 0.5, 0.5}, 1},
+	{"hwb(90 80% 80%)", Color{0.5, 0.5, 0.5}, 1},
+	{"lab(100 0 0)", Color{1, 1, 1}, 1},
+	{"lab(0% 0 0)", Color{0, 0, 0}, 1},
+	{"lab(54.29 80.82 69.89)", Color{1, 0, 0}, 1},
+	{"lch(54.29% 106.84 40.85)", Color{1, 0, 0}, 1},
+	{"lch(100 0 none)", Color{1, 1, 1}, 1},
+	{"oklab(62.8% 0.2249 0.1258)", Color{1, 0, 0}, 1},
+	{"oklab(0.628 56.2% 31.45%)", Color{1, 0, 0}, 1},
+	{"oklch(0.628 0.2577 29.23)", Color{1, 0, 0}, 1},
+	{"oklch(45.2% 0.3132 264.05 / 0.1)", Color{0, 0, 1}, 0.1},
+	{"color(srgb 1 0.5 0)", Color{1, 0.5, 0}, 1},
+	{"color(srgb-linear 1 0.2140 0)", Color{1, 0.5, 0}, 1},
+	{"color(display-p3 1 0 0)", LinearRgb(1.2249, -0.0421, -0.0196), 1},
+	{"color(display-p3 100% 100% 100% / 50%)", Color{1, 1, 1}, 0.5},
+	{"color(rec2020 1 1 1)", Color{1, 1, 1}, 1},
+	{"color(a98-rgb 1 1 1)", Color{1, 1, 1}, 1},
+	{"color(prophoto-rgb 1 1 1)", Color{1, 1, 1}, 1},
+	{"color(xyz-d65 0.95047 1 1.08883)", Color{1, 1, 1}, 1},
+	{"color(xyz 0.95047 1 1.08883)", Color{1, 1, 1}, 1},
+	{"color(xyz-d50 0.96422 1 0.82521)", Color{1, 1, 1}, 1},
+}
+
+func TestParseCSS(t *testing.T) {
+	for i, tt := range cssvals {
+		c, alpha, err := ParseCSSA(tt.s)
+		if err != nil {
+			t.Errorf("%v. ParseCSSA(%q) returned error %v", i, tt.s, err)
+			continue
+		}
+		if !c.AlmostEqualRgb(tt.c) || !almosteq(alpha, tt.alpha) {
+			t.Errorf("%v. ParseCSSA(%q) => %v, %v, want %v, %v", i, tt.s, c, alpha, tt.c, tt.alpha)
+		}
+		if c2, err := ParseCSS(tt.s); err != nil || c2 != c {
+			t.Errorf("%v. ParseCSS(%q) => %v, %v, want %v", i, tt.s, c2, err, c)
+		}
+	}
+}
+
+func TestParseCSSInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"reddish",
+		"dark red",
+		"#ggg",
+		"#ff00000",
+		"#ff0000ff0",
+		"#fff000fff000",
+		"#0xff0000",
+		"#ff 00 00",
+		"#",
+		"0xff0000",
+		"rgb(255 0 0",
+		"rgb(255, 0 0)",
+		"rgb(1 2)",
+		"rgb(1 2 3 4)",
+		"rgb(1 2 3 4 5)",
+		"rgb(1, 2, 3, 4, 5)",
+		"rgb(1 2 3 / 4 / 5)",
+		"rgb(1 2 3 /)",
+		"rgb(none, 0, 0)",
+		"rgb(100%, 0, 0)",
+		"rgb(1e 2 3)",
+		"rgb(nan 0 0)",
+		"rgb(inf 0 0)",
+		"rgb(1px 2 3)",
+		"hsl(120, 100, 50)",
+		"hsl(120foo 100% 50%)",
+		"lab(1, 2, 3)",
+		"foo(1 2 3)",
+		"color(foo 1 2 3)",
+		"color()",
+		"color(srgb 1 2)",
+		"color(srgb 1 0 0 0.5)",
+		"color(srgb 1 0 0 0.5 / 1)",
+		"color(srgb 1deg 2 3)",
+	} {
+		if c, err := ParseCSS(s); err == nil {
+			t.Errorf("ParseCSS(%q) => %v, want an error", s, c)
+		}
+	}
+}
+
+func TestParseCSSColorSrgbExact(t *testing.T) {
+	for _, want := range []Color{{1, 0, 0}, {0, 0, 1}, {1, 1, 1}, {0, 0.5, 1}} {
+		s, _ := want.FormatCSS("srgb", 4)
+		c, err := ParseCSS(s)
+		if err != nil || c != want || !c.IsValid() {
+			t.Errorf("ParseCSS(%q) => %v, %v, want exactly %v", s, c, err, want)
+		}
+	}
+}
+
+func TestFormatCSS(t *testing.T) {
+	c := Color{1.0, 0.5, 0.0}
+	for _, tt := range []struct {
+		got, want string
+	}{
+		{c.CSSRGB(), "rgb(255 128 0)"},
+		{c.CSSHSL(), "hsl(30 100% 50%)"},
+		{c.CSSHWB(), "hwb(30 0% 0%)"},
+		{c.CSSLab(), "lab(67.72 45.73 74.79)"},
+		{c.CSSLch(), "lch(67.72 87.67 58.56)"},
+		{c.CSSOkLab(), "oklab(0.7311 0.1126 0.1482)"},
+		{c.CSSOkLch(), "oklch(0.7311 0.1861 52.7757)"},
+		{Color{0, 0, 0}.CSSOkLab(), "oklab(0 0 0)"},
+		{Color{1.2, -0.1, 0.5}.CSSRGB(), "rgb(255 0 128)"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("got %v, want %v", tt.got, tt.want)
+		}
+	}
+
+	if s, err := c.FormatCSS("rgb", 2); err != nil || s != "rgb(255 127.5 0)" {
+		t.Errorf("FormatCSS(rgb, 2) => %v, %v", s, err)
+	}
+	if _, err := c.FormatCSS("cmyk", -1); err == nil {
+		t.Errorf("FormatCSS(cmyk) should fail")
+	}
+}
+
+func TestFormatCSSRoundtrip(t *testing.T) {
+	notations := []string{"rgb", "hsl", "hwb", "lab", "lch", "oklab", "oklch",
+		"srgb", "srgb-linear", "display-p3", "a98-rgb", "prophoto-rgb", "rec2020", "xyz", "xyz-d50", "xyz-d65"}
+	for i, tt := range vals {
+		for _, n := range notations {
+			s, err := tt.c.FormatCSS(n, -1)
+			if err != nil {
+				t.Errorf("%v. %v.FormatCSS(%v) returned error %v", i, tt.c, n, err)
+				continue
+			}
+			if c, err := ParseCSS(s); err != nil || !c.AlmostEqualRgb(tt.c) {
+				t.Errorf("%v. ParseCSS(%v.FormatCSS(%v) = %q) => %v, %v", i, tt.c, n, s, c, err)
+			}
+		}
+	}
+}
+
+func TestColorFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	var f ColorFlag
+	fs.Var(&f, "color", "color")
+
+	if err := fs.Parse([]string{"--color", "oklch(70% 0.1 50)"}); err != nil {
+		t.Fatalf("Parse => error %v", err)
+	}
+	if want := OkLch(0.7, 0.1, 50); !f.Value().AlmostEqualRgb(want) {
+		t.Errorf("--color oklch(70%% 0.1 50) => %v, want %v", f.Value(), want)
+	}
+	if err := fs.Set("color", "#ff8800"); err != nil || f.String() != "#ff8800" {
+		t.Errorf("Set(#ff8800) => %v, %v, want #ff8800", f.String(), err)
+	}
+	if got := fs.Lookup("color").Value.(flag.Getter).Get(); got != Color(f) {
+		t.Errorf("Get() => %v, want %v", got, Color(f))
+	}
+
+	if err := fs.Set("color", "nope"); err == nil {
+		t.Errorf("Set(nope) should have failed")
+	} else if _, want := ParseCSS("nope"); err.Error() != want.Error() {
+		t.Errorf("Set(nope) => error %v, want %v", err, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s string
+		c Color
+	}{
+		{"#f80", Color{1, 0.53333, 0}},
+		{"#ff880080", Color{1, 0.53333, 0}},
+		{"ff8800", Color{1, 0.53333, 0}},
+		{" FF8800 ", Color{1, 0.53333, 0}},
+		{"f80f", Color{1, 0.53333, 0}},
+		{"orange", Color{1, 0.64706, 0}},
+		{"rgb(255, 136, 0)", Color{1, 0.53333, 0}},
+		{"hsl(32deg 100% 50%)", Color{1, 0.53333, 0}},
+	}
+	for i, tt := range tests {
+		c, err := Parse(tt.s)
+		if err != nil || !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Parse(%q) => (%v, %v), want %v", i, tt.s, c, err, tt.c)
+		}
+	}
+
+	for _, s := range []string{"", "ff", "ff88000", "#ff8800x", "nope", "rgb(1 2)", "ff 88 00"} {
+		if c, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) => %v, want an error", s, c)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Per-channel transfer curves, as used for tone curves in photo editors, the
+// tone reproduction curves of ICC profiles and camera response curves.
+
+package colorful
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// The number of samples of curves built from points or functions.
+const curveSamples = 1024
+
+// A Curve maps each RGB channel through a 1D lookup table. The tables hold
+// the outputs for inputs evenly spaced over [0..1], inputs in between are
+// interpolated linearly, and inputs outside of [0..1] are clamped.
+type Curve struct {
+	R, G, B []float64
+}
+
+// A CurvePoint is an input value and the output the curve maps it to.
+type CurvePoint struct {
+	In, Out float64
+}
+
+// NewCurve returns the curve through the given points applied to all three
+// channels, see NewCurveRGB.
+func NewCurve(points ...CurvePoint) *Curve {
+	t := curveTable(points)
+	return &Curve{t, t, t}
+}
+
+// NewCurveRGB returns the curve through the given points of each channel,
+// interpolated by a monotone cubic spline, which unlike other splines doesn't
+// overshoot between the points, as the curves tools of photo editors do.
+// Outside of the first and last point, the curve is flat. Each channel needs
+// at least two points of distinct inputs, the order doesn't matter.
+func NewCurveRGB(r, g, b []CurvePoint) *Curve {
+	return &Curve{curveTable(r), curveTable(g), curveTable(b)}
+}
+
+// CurveFromFunc samples fn, such as a gamma function, at n points evenly
+// spread over [0..1] and applies it to all three channels. n needs to be at
+// least 2, zero means 1024.
+func CurveFromFunc(fn func(v float64) float64, n int) *Curve {
+	if n == 0 {
+		n = curveSamples
+	}
+	if n < 2 {
+		panic("colorful: a curve needs at least 2 samples")
+	}
+	t := make([]float64, n)
+	for i := range t {
+		t[i] = fn(float64(i) / float64(n-1))
+	}
+	return &Curve{t, t, t}
+}
+
+// curveTable samples the monotone cubic spline through the points, following
+// Fritsch and Carlson.
+// https://en.wikipedia.org/wiki/Monotone_cubic_interpolation
+func curveTable(points []CurvePoint) []float64 {
+	if len(points) < 2 {
+		panic("colorful: a curve needs at least 2 points")
+	}
+	p := append([]CurvePoint(nil), points...)
+	sort.Slice(p, func(i, j int) bool { return p[i].In < p[j].In })
+
+	n := len(p)
+	d := make([]float64, n-1)
+	for k := range d {
+		dx := p[k+1].In - p[k].In
+		if dx == 0 {
+			panic("colorful: two curve points have the same input")
+		}
+		d[k] = (p[k+1].Out - p[k].Out) / dx
+	}
+	m := make([]float64, n)
+	m[0], m[n-1] = d[0], d[n-2]
+	for k := 1; k < n-1; k++ {
+		if d[k-1]*d[k] > 0 {
+			m[k] = (d[k-1] + d[k]) / 2
+		}
+	}
+	for k, dk := range d {
+		if dk == 0 {
+			m[k], m[k+1] = 0, 0
+			continue
+		}
+		a, b := m[k]/dk, m[k+1]/dk
+		if s := a*a + b*b; s > 9 {
+			tau := 3 / math.Sqrt(s)
+			m[k], m[k+1] = tau*a*dk, tau*b*dk
+		}
+	}
+
+	t := make([]float64, curveSamples)
+	k := 0
+	for i := range t {
+		x := float64(i) / float64(curveSamples-1)
+		switch {
+		case x <= p[0].In:
+			t[i] = p[0].Out
+		case x >= p[n-1].In:
+			t[i] = p[n-1].Out
+		default:
+			for x > p[k+1].In {
+				k++
+			}
+			h := p[k+1].In - p[k].In
+			s := (x - p[k].In) / h
+			s2, s3 := s*s, s*s*s
+			t[i] = (2*s3-3*s2+1)*p[k].Out + (s3-2*s2+s)*h*m[k] + (-2*s3+3*s2)*p[k+1].Out + (s3-s2)*h*m[k+1]
+		}
+	}
+	return t
+}
+
+// lookup interpolates the table at v in [0..1].
+func lookup(t []float64, v float64) float64 {
+	f := clamp01(v) * float64(len(t)-1)
+	i := int(f)
+	if i >= len(t)-1 {
+		return t[len(t)-1]
+	}
+	return t[i] + (f-float64(i))*(t[i+1]-t[i])
+}
+
+// Apply maps each channel of the color through the curve.
+func (c *Curve) Apply(col Color) Color {
+	return Color{lookup(c.R, col.R), lookup(c.G, col.G), lookup(c.B, col.B)}
+}
+
+// Then returns the curve applying c first and next after it, as a single
+// table of the size of c's.
+func (c *Curve) Then(next *Curve) *Curve {
+	then := func(t, nt []float64) []float64 {
+		out := make([]float64, len(t))
+		for i, v := range t {
+			out[i] = lookup(nt, v)
+		}
+		return out
+	}
+	return &Curve{then(c.R, next.R), then(c.G, next.G), then(c.B, next.B)}
+}
+
+// Image returns a copy of img with the curve applied to every pixel, keeping
+// the alpha channel.
+func (c *Curve) Image(img image.Image) *image.NRGBA {
+	return MapImage(img, c.Apply)
+}
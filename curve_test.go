@@ -0,0 +1,54 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCurve(t *testing.T) {
+	identity := NewCurve(CurvePoint{0, 0}, CurvePoint{1, 1})
+	for _, c := range randomColors(20) {
+		if got := identity.Apply(c); !got.AlmostEqualRgb(c) {
+			t.Errorf("identity.Apply(%v) => %v", c, got)
+		}
+	}
+
+	// An S curve goes through its points and never overshoots them.
+	s := NewCurve(CurvePoint{1, 1}, CurvePoint{0, 0}, CurvePoint{0.25, 0.15}, CurvePoint{0.75, 0.85})
+	for _, p := range []CurvePoint{{0, 0}, {0.25, 0.15}, {0.75, 0.85}, {1, 1}} {
+		if got := lookup(s.R, p.In); math.Abs(got-p.Out) > 1e-3 {
+			t.Errorf("S curve at %v => %v, want %v", p.In, got, p.Out)
+		}
+	}
+	for i := 1; i < len(s.R); i++ {
+		if s.R[i] < s.R[i-1] {
+			t.Errorf("S curve falls at sample %v: %v after %v", i, s.R[i], s.R[i-1])
+			break
+		}
+	}
+
+	// Flat beyond the outer points.
+	flat := NewCurve(CurvePoint{0.2, 0.3}, CurvePoint{0.8, 0.6})
+	if got := flat.Apply(Color{0, 0.1, 1}); !got.AlmostEqualRgb(Color{0.3, 0.3, 0.6}) {
+		t.Errorf("flat.Apply => %v, want (0.3, 0.3, 0.6)", got)
+	}
+
+	// Per-channel curves and composition.
+	invertRed := NewCurveRGB([]CurvePoint{{0, 1}, {1, 0}}, []CurvePoint{{0, 0}, {1, 1}}, []CurvePoint{{0, 0}, {1, 1}})
+	col := Color{0.2, 0.4, 0.6}
+	if got := invertRed.Apply(col); !got.AlmostEqualRgb(Color{0.8, 0.4, 0.6}) {
+		t.Errorf("invertRed.Apply(%v) => %v", col, got)
+	}
+	if got := invertRed.Then(invertRed).Apply(col); !got.AlmostEqualRgb(col) {
+		t.Errorf("invertRed.Then(invertRed).Apply(%v) => %v", col, got)
+	}
+
+	gamma := CurveFromFunc(func(v float64) float64 { return math.Pow(v, 2.2) }, 0)
+	back := CurveFromFunc(func(v float64) float64 { return math.Pow(v, 1/2.2) }, 0)
+	if got := gamma.Apply(col); math.Abs(got.G-math.Pow(0.4, 2.2)) > 1e-4 {
+		t.Errorf("gamma.Apply(%v) => %v, want green %v", col, got, math.Pow(0.4, 2.2))
+	}
+	if got := gamma.Then(back).Apply(col); math.Abs(got.R-col.R) > 1e-3 || math.Abs(got.B-col.B) > 1e-3 {
+		t.Errorf("gamma.Then(back).Apply(%v) => %v", col, got)
+	}
+}
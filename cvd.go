@@ -0,0 +1,317 @@
+// Simulation of color vision deficiencies, which lets people with normal
+// vision check how colors look to the about 8% of men who see them
+// differently.
+
+package colorful
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+)
+
+// A CVDKind is the kind of color vision deficiency, named after the type of
+// cone which is missing or anomalous.
+type CVDKind int
+
+const (
+	// Protan deficiencies affect the long wavelength (red) cones.
+	Protan CVDKind = iota
+	// Deutan deficiencies affect the medium wavelength (green) cones, and
+	// are the most common ones.
+	Deutan
+	// Tritan deficiencies affect the short wavelength (blue) cones, and are
+	// rare.
+	Tritan
+)
+
+func (kind CVDKind) String() string {
+	switch kind {
+	case Protan:
+		return "protan"
+	case Deutan:
+		return "deutan"
+	case Tritan:
+		return "tritan"
+	}
+	return fmt.Sprintf("CVDKind(%d)", int(kind))
+}
+
+// A CVDMethod is a model of color vision deficiency.
+type CVDMethod int
+
+const (
+	// Machado is the model of Machado, Oliveira and Fernandes (2009), which
+	// also covers anomalous trichromacy. Severities between the steps of 0.1
+	// the paper gives matrices for are interpolated between the neighboring
+	// ones.
+	Machado CVDMethod = iota
+	// Brettel is the model of Brettel, Viénot and Mollon (1997), projecting
+	// onto two half-planes, which is the most accurate one for dichromats.
+	Brettel
+	// Vienot is the simplification of Brettel by Viénot, Brettel and Mollon
+	// (1999) onto a single plane. It is only defined for protan and deutan,
+	// tritan deficiencies are simulated using Brettel.
+	Vienot
+)
+
+// The matrices on linear RGB values.
+var (
+	// Machado's matrices for the severities 0, 0.1, ..., 1 as published with
+	// the paper, the last ones being dichromacy.
+	machadoMats = [...][11]mat3{
+		Protan: {
+			{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			{{0.856167, 0.182038, -0.038205}, {0.029342, 0.955115, 0.015544}, {-0.002880, -0.001563, 1.004443}},
+			{{0.734766, 0.334872, -0.069637}, {0.051840, 0.919198, 0.028963}, {-0.004928, -0.004209, 1.009137}},
+			{{0.630323, 0.465641, -0.095964}, {0.069181, 0.890046, 0.040773}, {-0.006308, -0.007724, 1.014032}},
+			{{0.539009, 0.579343, -0.118352}, {0.082546, 0.866121, 0.051332}, {-0.007136, -0.011959, 1.019095}},
+			{{0.458064, 0.679578, -0.137642}, {0.092785, 0.846313, 0.060902}, {-0.007494, -0.016807, 1.024301}},
+			{{0.385450, 0.769005, -0.154455}, {0.100526, 0.829802, 0.069673}, {-0.007442, -0.022190, 1.029632}},
+			{{0.319627, 0.849633, -0.169261}, {0.106241, 0.815969, 0.077790}, {-0.007025, -0.028051, 1.035076}},
+			{{0.259411, 0.923008, -0.182420}, {0.110296, 0.804340, 0.085364}, {-0.006276, -0.034346, 1.040622}},
+			{{0.203876, 0.990338, -0.194214}, {0.112975, 0.794542, 0.092483}, {-0.005222, -0.041043, 1.046265}},
+			{{0.152286, 1.052583, -0.204868}, {0.114503, 0.786281, 0.099216}, {-0.003882, -0.048116, 1.051998}},
+		},
+		Deutan: {
+			{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			{{0.866435, 0.177704, -0.044139}, {0.049567, 0.939063, 0.011370}, {-0.003453, 0.007233, 0.996220}},
+			{{0.760729, 0.319078, -0.079807}, {0.090568, 0.889315, 0.020117}, {-0.006027, 0.013325, 0.992702}},
+			{{0.675425, 0.433850, -0.109275}, {0.125303, 0.847755, 0.026942}, {-0.007950, 0.018572, 0.989378}},
+			{{0.605511, 0.528560, -0.134071}, {0.155318, 0.812366, 0.032316}, {-0.009376, 0.023176, 0.986200}},
+			{{0.547494, 0.607765, -0.155259}, {0.181692, 0.781742, 0.036566}, {-0.010410, 0.027275, 0.983136}},
+			{{0.498864, 0.674741, -0.173604}, {0.205199, 0.754872, 0.039929}, {-0.011131, 0.030969, 0.980162}},
+			{{0.457771, 0.731899, -0.189670}, {0.226409, 0.731012, 0.042579}, {-0.011595, 0.034333, 0.977261}},
+			{{0.422823, 0.781057, -0.203881}, {0.245752, 0.709602, 0.044646}, {-0.011843, 0.037423, 0.974421}},
+			{{0.392952, 0.823610, -0.216562}, {0.263559, 0.690210, 0.046232}, {-0.011910, 0.040281, 0.971630}},
+			{{0.367322, 0.860646, -0.227968}, {0.280085, 0.672501, 0.047413}, {-0.011820, 0.042940, 0.968881}},
+		},
+		Tritan: {
+			{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			{{0.926670, 0.092514, -0.019184}, {0.021191, 0.964503, 0.014306}, {0.008437, 0.054813, 0.936750}},
+			{{0.895720, 0.133330, -0.029050}, {0.029997, 0.945400, 0.024603}, {0.013027, 0.104707, 0.882266}},
+			{{0.905871, 0.127791, -0.033662}, {0.026856, 0.941251, 0.031893}, {0.013410, 0.148296, 0.838294}},
+			{{0.948035, 0.089490, -0.037526}, {0.014364, 0.946792, 0.038844}, {0.010853, 0.193991, 0.795156}},
+			{{1.017277, 0.027029, -0.044306}, {-0.006113, 0.958479, 0.047634}, {0.006379, 0.248708, 0.744913}},
+			{{1.104996, -0.046633, -0.058363}, {-0.032137, 0.971635, 0.060503}, {0.001336, 0.317922, 0.680742}},
+			{{1.193214, -0.109812, -0.083402}, {-0.058496, 0.979410, 0.079086}, {-0.002346, 0.403492, 0.598854}},
+			{{1.257728, -0.139648, -0.118081}, {-0.078003, 0.975409, 0.102594}, {-0.003316, 0.501214, 0.502102}},
+			{{1.278864, -0.125333, -0.153531}, {-0.084748, 0.957674, 0.127074}, {-0.000989, 0.601151, 0.399838}},
+			{{1.255528, -0.076749, -0.178779}, {-0.078411, 0.930809, 0.147602}, {0.004733, 0.691367, 0.303900}},
+		},
+	}
+
+	// The Viénot and Brettel matrices, as computed by DaltonLens.
+	// https://daltonlens.org/opensource-cvd-simulation/
+	vienotMats = [...]mat3{
+		Protan: {
+			{0.11238, 0.88762, 0.00000},
+			{0.11238, 0.88762, -0.00000},
+			{0.00401, -0.00401, 1.00000},
+		},
+		Deutan: {
+			{0.29275, 0.70725, 0.00000},
+			{0.29275, 0.70725, -0.00000},
+			{-0.02234, 0.02234, 1.00000},
+		},
+	}
+
+	// Brettel's method uses one of two matrices depending on which side of
+	// the separation plane a color lies.
+	brettelParams = [...]struct {
+		m1, m2 mat3
+		normal [3]float64
+	}{
+		Protan: {
+			mat3{{0.14510, 1.20165, -0.34675}, {0.10447, 0.85316, 0.04237}, {0.00429, -0.00603, 1.00174}},
+			mat3{{0.14115, 1.16782, -0.30897}, {0.10495, 0.85730, 0.03776}, {0.00410, -0.00628, 1.00218}},
+			[3]float64{0.00048, 0.00416, -0.00464},
+		},
+		Deutan: {
+			mat3{{0.36198, 0.86755, -0.22953}, {0.26099, 0.64512, 0.09389}, {-0.01975, 0.02686, 0.99289}},
+			mat3{{0.37009, 0.88540, -0.25549}, {0.25767, 0.63782, 0.10451}, {-0.01950, 0.02741, 0.99209}},
+			[3]float64{-0.00293, -0.00645, 0.00938},
+		},
+		Tritan: {
+			mat3{{1.01277, 0.13548, -0.14826}, {-0.01243, 0.86812, 0.14431}, {0.07589, 0.80500, 0.11911}},
+			mat3{{0.93678, 0.18979, -0.12657}, {0.06154, 0.81526, 0.12320}, {-0.37562, 1.12767, 0.24796}},
+			[3]float64{0.03901, -0.02788, -0.01113},
+		},
+	}
+)
+
+// simulateCVDLinear simulates the deficiency on linear RGB values. The
+// severity is clamped to [0..1]. Except for Machado, which has matrices for
+// anomalous trichromacy, it blends between normal vision and dichromacy.
+func simulateCVDLinear(method CVDMethod, kind CVDKind, severity float64, r, g, b float64) (float64, float64, float64) {
+	if kind < Protan || kind > Tritan {
+		panic(fmt.Sprintf("colorful: unknown CVD kind %v", kind))
+	}
+	severity = clamp01(severity)
+
+	var m *mat3
+	switch {
+	case method == Machado:
+		i := int(severity * 10)
+		if i == 10 {
+			return machadoMats[kind][10].mul(r, g, b)
+		}
+		lo, hi := &machadoMats[kind][i], &machadoMats[kind][i+1]
+		t := severity*10 - float64(i)
+		var lerped mat3
+		for row := range lerped {
+			for col := range lerped[row] {
+				lerped[row][col] = lo[row][col] + t*(hi[row][col]-lo[row][col])
+			}
+		}
+		return lerped.mul(r, g, b)
+	case method == Vienot && kind != Tritan:
+		m = &vienotMats[kind]
+	case method == Brettel, method == Vienot:
+		p := &brettelParams[kind]
+		m = &p.m1
+		if r*p.normal[0]+g*p.normal[1]+b*p.normal[2] < 0 {
+			m = &p.m2
+		}
+	default:
+		panic(fmt.Sprintf("colorful: unknown CVD method %v", method))
+	}
+
+	sr, sg, sb := m.mul(r, g, b)
+	return r + severity*(sr-r), g + severity*(sg-g), b + severity*(sb-b)
+}
+
+// SimulateCVD returns the color as seen with the given kind of color vision
+// deficiency, using the Machado model. A severity of 1 is dichromacy, the
+// complete lack of one type of cone, lower ones are anomalous trichromacy.
+func (col Color) SimulateCVD(kind CVDKind, severity float64) Color {
+	return col.SimulateCVDWith(Machado, kind, severity)
+}
+
+// SimulateCVDWith is like SimulateCVD, using the given model.
+func (col Color) SimulateCVDWith(method CVDMethod, kind CVDKind, severity float64) Color {
+	r, g, b := col.LinearRgb()
+	return LinearRgb(simulateCVDLinear(method, kind, severity, r, g, b)).Clamped()
+}
+
+// SimulateCVDImage returns a copy of img as seen with the given kind of color
+// vision deficiency, see SimulateCVDWith.
+func SimulateCVDImage(img image.Image, method CVDMethod, kind CVDKind, severity float64) *image.NRGBA {
+	return MapImage(img, func(c Color) Color {
+		return c.SimulateCVDWith(method, kind, severity)
+	})
+}
+
+/// Daltonization ///
+/////////////////////
+
+// The error redistribution of Fidaner, Lin and Ozguven, which moves the
+// information lost to a deficiency into the channels that remain visible.
+var daltonizeMats = [...]mat3{
+	Protan: {{0, 0, 0}, {0.7, 1, 0}, {0.7, 0, 1}},
+	Deutan: {{0, 0, 0}, {0.7, 1, 0}, {0.7, 0, 1}},
+	Tritan: {{1, 0, 0.7}, {0, 1, 0.7}, {0, 0, 0}},
+}
+
+// Daltonize shifts the color such that it stays distinguishable from others
+// for people with the given kind and severity of color vision deficiency.
+// The difference between the color and its simulation with the Machado model
+// is what such people can't see, which is moved into the channels they can.
+// This changes the color for people with normal vision too, most for the
+// colors the deficiency affects most, and leaves grays alone.
+func (col Color) Daltonize(kind CVDKind, severity float64) Color {
+	r, g, b := col.LinearRgb()
+	sr, sg, sb := simulateCVDLinear(Machado, kind, severity, r, g, b)
+	er, eg, eb := daltonizeMats[kind].mul(r-sr, g-sg, b-sb)
+	return LinearRgb(r+er, g+eg, b+eb).Clamped()
+}
+
+// DaltonizeImage returns a copy of img with Daltonize applied to every pixel.
+func DaltonizeImage(img image.Image, kind CVDKind, severity float64) *image.NRGBA {
+	return MapImage(img, func(c Color) Color {
+		return c.Daltonize(kind, severity)
+	})
+}
+
+/// Palette audit ///
+/////////////////////
+
+// A ColorPair identifies two colors of a palette by their indices, and how
+// far apart they are.
+type ColorPair struct {
+	I, J     int
+	Distance float64
+}
+
+// A VisionAudit describes the distinguishability of a palette's colors for
+// one kind of vision.
+type VisionAudit struct {
+	// Closest is the pair of colors which is hardest to tell apart, its
+	// distance is the minimal one of all pairs.
+	Closest ColorPair
+
+	// Problems lists all pairs closer than the threshold, closest first.
+	Problems []ColorPair
+}
+
+// A PaletteAudit reports how well the colors of a palette can be told apart
+// with normal vision and with each kind of color vision deficiency.
+type PaletteAudit struct {
+	Normal VisionAudit
+
+	// Deficient is indexed by CVDKind.
+	Deficient [3]VisionAudit
+}
+
+// Passed reports whether no pair of colors is closer than the threshold, for
+// any kind of vision.
+func (a PaletteAudit) Passed() bool {
+	if len(a.Normal.Problems) > 0 {
+		return false
+	}
+	for _, d := range a.Deficient {
+		if len(d.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func auditVision(palette []Color, threshold float64) VisionAudit {
+	var a VisionAudit
+	a.Closest.Distance = math.Inf(+1)
+	for i := range palette {
+		for j := i + 1; j < len(palette); j++ {
+			p := ColorPair{i, j, palette[i].DistanceCIEDE2000(palette[j])}
+			if p.Distance < a.Closest.Distance {
+				a.Closest = p
+			}
+			if p.Distance < threshold {
+				a.Problems = append(a.Problems, p)
+			}
+		}
+	}
+	sort.SliceStable(a.Problems, func(i, j int) bool {
+		return a.Problems[i].Distance < a.Problems[j].Distance
+	})
+	return a
+}
+
+// AuditPalette checks that every pair of colors of the palette is at least
+// threshold apart in CIEDE2000, with normal vision and with the full protan,
+// deutan and tritan deficiencies simulated using the Machado model. For
+// categorical palettes, such as the series of a chart, a threshold of 0.1 is
+// a good start. Palettes of less than two colors have an infinite minimal
+// distance and always pass.
+func AuditPalette(palette []Color, threshold float64) PaletteAudit {
+	a := PaletteAudit{Normal: auditVision(palette, threshold)}
+	simulated := make([]Color, len(palette))
+	for _, kind := range []CVDKind{Protan, Deutan, Tritan} {
+		for i, c := range palette {
+			simulated[i] = c.SimulateCVD(kind, 1)
+		}
+		a.Deficient[kind] = auditVision(simulated, threshold)
+	}
+	return a
+}
@@ -0,0 +1,146 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestSimulateCVD(t *testing.T) {
+	methods := []CVDMethod{Machado, Brettel, Vienot}
+	kinds := []CVDKind{Protan, Deutan, Tritan}
+
+	for _, m := range methods {
+		for _, k := range kinds {
+			// Grays look the same to everybody.
+			for _, v := range []float64{0, 0.3, 1} {
+				gray := Color{v, v, v}
+				if got := gray.SimulateCVDWith(m, k, 1); got.DistanceRgb(gray) > 2e-3 {
+					t.Errorf("%v %v: gray %v => %v", m, k, v, got)
+				}
+			}
+			for _, c := range randomColors(10) {
+				if got := c.SimulateCVDWith(m, k, 0); !got.AlmostEqualRgb(c) {
+					t.Errorf("%v %v: %v with severity 0 => %v, want it unchanged", m, k, c, got)
+				}
+				if got := c.SimulateCVDWith(m, k, 1); !got.IsValid() {
+					t.Errorf("%v %v: %v => %v, want a valid color", m, k, c, got)
+				}
+			}
+		}
+	}
+
+	// Red and green become hard to tell apart for protans and deutans, but
+	// not for tritans.
+	red, green := MustHex("#cc3333"), MustHex("#669933")
+	normal := red.DistanceCIEDE2000(green)
+	for _, m := range methods {
+		for _, k := range []CVDKind{Protan, Deutan} {
+			if d := red.SimulateCVDWith(m, k, 1).DistanceCIEDE2000(green.SimulateCVDWith(m, k, 1)); d > normal/2 {
+				t.Errorf("%v %v: red and green are %v apart, from %v", m, k, d, normal)
+			}
+		}
+		if d := red.SimulateCVDWith(m, Tritan, 1).DistanceCIEDE2000(green.SimulateCVDWith(m, Tritan, 1)); d < normal/2 {
+			t.Errorf("%v tritan: red and green are %v apart, from %v", m, d, normal)
+		}
+	}
+
+	// Milder severities are in between.
+	full := red.SimulateCVD(Deutan, 1).DistanceCIEDE2000(red)
+	half := red.SimulateCVD(Deutan, 0.5).DistanceCIEDE2000(red)
+	if half <= 0 || half >= full {
+		t.Errorf("SimulateCVD(Deutan, 0.5) changed red by %v, with severity 1 by %v", half, full)
+	}
+}
+
+func TestSimulateCVDMachadoSeverity(t *testing.T) {
+	// The protanomaly matrix for severity 0.5 of Machado's table, and the
+	// average of those for 0.6 and 0.7.
+	tests := []struct {
+		severity float64
+		m        mat3
+	}{
+		{0.5, mat3{{0.458064, 0.679578, -0.137642}, {0.092785, 0.846313, 0.060902}, {-0.007494, -0.016807, 1.024301}}},
+		{0.65, mat3{{0.3525385, 0.809319, -0.161858}, {0.1033835, 0.8228855, 0.0737315}, {-0.0072335, -0.0251205, 1.032354}}},
+	}
+	for _, tt := range tests {
+		for _, c := range []Color{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.2, 0.5, 0.7}} {
+			r, g, b := c.LinearRgb()
+			wr, wg, wb := tt.m.mul(r, g, b)
+			gr, gg, gb := simulateCVDLinear(Machado, Protan, tt.severity, r, g, b)
+			if !almosteq(gr, wr) || !almosteq(gg, wg) || !almosteq(gb, wb) {
+				t.Errorf("protan %v of %v => (%v, %v, %v), want (%v, %v, %v)", tt.severity, c, gr, gg, gb, wr, wg, wb)
+			}
+		}
+	}
+}
+
+func TestSimulateCVDImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.NRGBA{200, 50, 50, 77})
+	want := Color{200 / 255.0, 50 / 255.0, 50 / 255.0}.SimulateCVDWith(Brettel, Protan, 1)
+	got := SimulateCVDImage(img, Brettel, Protan, 1)
+	if c, _ := MakeColor(got.NRGBAAt(0, 0)); c.DistanceRgb(want) > 1/255.0 || got.NRGBAAt(0, 0).A != 77 {
+		t.Errorf("SimulateCVDImage => %v, want %v", got.NRGBAAt(0, 0), want)
+	}
+}
+
+func TestDaltonize(t *testing.T) {
+	for _, k := range []CVDKind{Protan, Deutan, Tritan} {
+		gray := Color{0.5, 0.5, 0.5}
+		if got := gray.Daltonize(k, 1); got.DistanceRgb(gray) > 2e-3 {
+			t.Errorf("%v: gray.Daltonize => %v, want it unchanged", k, got)
+		}
+		for _, c := range randomColors(10) {
+			if got := c.Daltonize(k, 0); !got.AlmostEqualRgb(c) {
+				t.Errorf("%v: %v.Daltonize with severity 0 => %v, want it unchanged", k, c, got)
+			}
+		}
+	}
+
+	// Daltonized red and green are further apart to deutans than the
+	// original ones.
+	red, green := MustHex("#cc3333"), MustHex("#669933")
+	before := red.SimulateCVD(Deutan, 1).DistanceCIEDE2000(green.SimulateCVD(Deutan, 1))
+	dr, dg := red.Daltonize(Deutan, 1), green.Daltonize(Deutan, 1)
+	if after := dr.SimulateCVD(Deutan, 1).DistanceCIEDE2000(dg.SimulateCVD(Deutan, 1)); after <= before {
+		t.Errorf("Daltonize(Deutan) => red and green %v apart to deutans, before %v", after, before)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, red)
+	if c, _ := MakeColor(DaltonizeImage(img, Deutan, 1).At(0, 0)); c.DistanceRgb(dr) > 1/255.0 {
+		t.Errorf("DaltonizeImage => %v, want %v", c, dr)
+	}
+}
+
+func TestAuditPalette(t *testing.T) {
+	// Red and green are fine with normal vision but not for deutans, blue
+	// and yellow are fine for everyone.
+	red, green := MustHex("#cc3333"), MustHex("#669933")
+	blue, yellow := MustHex("#3355cc"), MustHex("#eecc22")
+
+	a := AuditPalette([]Color{blue, yellow}, 0.1)
+	if !a.Passed() {
+		t.Errorf("AuditPalette(blue, yellow) => %+v, want it to pass", a)
+	}
+	if d := blue.DistanceCIEDE2000(yellow); !almosteq(a.Normal.Closest.Distance, d) || a.Normal.Closest.I != 0 || a.Normal.Closest.J != 1 {
+		t.Errorf("AuditPalette(blue, yellow) => closest %+v, want (0, 1, %v)", a.Normal.Closest, d)
+	}
+
+	a = AuditPalette([]Color{red, blue, green, yellow}, 0.1)
+	if a.Passed() {
+		t.Errorf("AuditPalette(red, blue, green, yellow) passed")
+	}
+	if len(a.Normal.Problems) != 0 {
+		t.Errorf("AuditPalette(red, blue, green, yellow) => normal problems %v", a.Normal.Problems)
+	}
+	d := a.Deficient[Deutan]
+	if len(d.Problems) == 0 || d.Problems[0].I != 0 || d.Problems[0].J != 2 || d.Closest != d.Problems[0] {
+		t.Errorf("AuditPalette(red, blue, green, yellow) => deutan problems %v, want red and green first", d.Problems)
+	}
+
+	if a := AuditPalette([]Color{red}, 0.1); !a.Passed() {
+		t.Errorf("AuditPalette of a single color => %+v, want it to pass", a)
+	}
+}
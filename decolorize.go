@@ -0,0 +1,71 @@
+// Converting images to grayscale without losing the contrast between colors
+// of the same lightness.
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Decolorize converts img to grayscale, keeping some of the contrast between
+// colors which have the same lightness, such as the red and green of a chart,
+// which a plain luma conversion maps to the same gray.
+//
+// The gray value is the OkLab lightness plus amount times the projection of
+// the color's chroma onto the principal chromatic axis of the image, which is
+// oriented to agree with the lightness such that light colors stay light. An
+// amount of 0 gives the plain lightness, 1 to 2 is a useful range. If the
+// result exceeds the range from black to white, it is rescaled to fit. Alpha
+// is ignored.
+func Decolorize(img image.Image, amount float64) *image.Gray {
+	planes := ImageToOkLab(img)
+	defer ReleasePlanes(planes)
+	L, A, B := planes[0], planes[1], planes[2]
+	n := float64(len(L))
+
+	// The principal axis of the chroma of all pixels.
+	var ma, mb, ml float64
+	for i := range L {
+		ml += float64(L[i])
+		ma += float64(A[i])
+		mb += float64(B[i])
+	}
+	ml, ma, mb = ml/n, ma/n, mb/n
+	var saa, sab, sbb float64
+	for i := range L {
+		da, db := float64(A[i])-ma, float64(B[i])-mb
+		saa += da * da
+		sab += da * db
+		sbb += db * db
+	}
+	theta := 0.5 * math.Atan2(2*sab, saa-sbb)
+	dx, dy := math.Cos(theta), math.Sin(theta)
+
+	// Orient it such that the projection correlates with lightness.
+	var corr float64
+	for i := range L {
+		corr += (float64(L[i]) - ml) * ((float64(A[i])-ma)*dx + (float64(B[i])-mb)*dy)
+	}
+	if corr < 0 || (corr == 0 && dx+dy < 0) {
+		dx, dy = -dx, -dy
+	}
+
+	lo, hi := 0.0, 1.0
+	gray := make([]float64, len(L))
+	for i := range L {
+		g := float64(L[i]) + amount*(float64(A[i])*dx+float64(B[i])*dy)
+		gray[i] = g
+		lo, hi = math.Min(lo, g), math.Max(hi, g)
+	}
+
+	b := img.Bounds()
+	dst := image.NewGray(b)
+	for i, g := range gray {
+		x, y := b.Min.X+i%b.Dx(), b.Min.Y+i/b.Dx()
+		c := OkLab((g-lo)/(hi-lo), 0, 0).Clamped()
+		dst.SetGray(x, y, color.Gray{uint8(c.R*255.0 + 0.5)})
+	}
+	return dst
+}
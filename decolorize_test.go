@@ -0,0 +1,36 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDecolorize(t *testing.T) {
+	// Red and green of (about) the same lightness, and black and white.
+	red, green := OkLch(0.6, 0.15, 30).Clamped(), OkLch(0.6, 0.15, 140).Clamped()
+	img := image.NewNRGBA(image.Rect(1, 1, 5, 2))
+	img.Set(1, 1, red)
+	img.Set(2, 1, green)
+	img.Set(3, 1, color.NRGBA{0, 0, 0, 255})
+	img.Set(4, 1, color.NRGBA{255, 255, 255, 255})
+
+	plain := Decolorize(img, 0)
+	if plain.Bounds() != img.Bounds() {
+		t.Errorf("Decolorize => bounds %v, want %v", plain.Bounds(), img.Bounds())
+	}
+	if d := int(plain.GrayAt(1, 1).Y) - int(plain.GrayAt(2, 1).Y); d < -3 || d > 3 {
+		t.Errorf("Decolorize(0) => %v and %v for red and green, want the same", plain.GrayAt(1, 1).Y, plain.GrayAt(2, 1).Y)
+	}
+	if plain.GrayAt(3, 1).Y != 0 || plain.GrayAt(4, 1).Y != 255 {
+		t.Errorf("Decolorize(0) => %v and %v for black and white", plain.GrayAt(3, 1).Y, plain.GrayAt(4, 1).Y)
+	}
+
+	g := Decolorize(img, 1.5)
+	if d := int(g.GrayAt(1, 1).Y) - int(g.GrayAt(2, 1).Y); d > -20 && d < 20 {
+		t.Errorf("Decolorize(1.5) => %v and %v for red and green, want them apart", g.GrayAt(1, 1).Y, g.GrayAt(2, 1).Y)
+	}
+	if g.GrayAt(3, 1).Y >= g.GrayAt(4, 1).Y {
+		t.Errorf("Decolorize(1.5) => %v and %v for black and white, want black darker", g.GrayAt(3, 1).Y, g.GrayAt(4, 1).Y)
+	}
+}
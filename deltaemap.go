@@ -0,0 +1,77 @@
+// Visualizing the differences between two images.
+
+package colorful
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// DeltaEMapOptions configure DeltaEMap, the zero value gives a grayscale map
+// of CIEDE2000 differences.
+type DeltaEMapOptions struct {
+	// Metric measures the difference of two pixels, nil means
+	// Color.DistanceCIEDE2000.
+	Metric func(c1, c2 Color) float64
+
+	// Scale is the difference which is mapped to white or the end of the
+	// colormap, larger ones are clipped. Zero means 0.1, which is a clearly
+	// visible difference in the CIEDE2000 values of this package.
+	Scale float64
+
+	// Colormap colors the differences scaled to [0..1] if set, for example
+	// a gradient from black over red to yellow.
+	Colormap *Gradient
+}
+
+// DeltaEMap returns an image of the per-pixel differences between a and b,
+// which must have the same size but may have different origins. The result
+// has the bounds of a, and is an *image.Gray unless a Colormap is set, in
+// which case it is an *image.NRGBA. Alpha is ignored.
+func DeltaEMap(a, b image.Image, opts DeltaEMapOptions) (image.Image, error) {
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Size() != rb.Size() {
+		return nil, fmt.Errorf("color: images differ in size: %v and %v", ra.Size(), rb.Size())
+	}
+	metric := opts.Metric
+	if metric == nil {
+		metric = Color.DistanceCIEDE2000
+	}
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 0.1
+	}
+
+	var gray *image.Gray
+	var colored *image.NRGBA
+	var lut *GradientLUT
+	if opts.Colormap != nil {
+		colored = image.NewNRGBA(ra)
+		lut = opts.Colormap.LUT(256)
+	} else {
+		gray = image.NewGray(ra)
+	}
+
+	d := rb.Min.Sub(ra.Min)
+	parallelRows(ra, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := ra.Min.X; x < ra.Max.X; x++ {
+				ca, _ := pixelAt(a, x, y)
+				cb, _ := pixelAt(b, x+d.X, y+d.Y)
+				t := clamp01(metric(ca, cb) / scale)
+				if gray != nil {
+					gray.SetGray(x, y, color.Gray{uint8(t*255.0 + 0.5)})
+				} else {
+					r, g, b := lut.At(t*(lut.Max-lut.Min) + lut.Min).Clamped().RGB255()
+					colored.SetNRGBA(x, y, color.NRGBA{r, g, b, 255})
+				}
+			}
+		}
+	})
+
+	if gray != nil {
+		return gray, nil
+	}
+	return colored, nil
+}
@@ -0,0 +1,60 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDeltaEMap(t *testing.T) {
+	a := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	b := image.NewNRGBA(image.Rect(5, 5, 8, 6))
+	for x := 0; x < 3; x++ {
+		a.Set(x, 0, color.NRGBA{100, 100, 100, 255})
+	}
+	b.Set(5, 5, color.NRGBA{100, 100, 100, 255})
+	b.Set(6, 5, color.NRGBA{103, 100, 100, 255})
+	b.Set(7, 5, color.NRGBA{255, 0, 0, 255})
+
+	m, err := DeltaEMap(a, b, DeltaEMapOptions{})
+	if err != nil {
+		t.Fatalf("DeltaEMap => error %v", err)
+	}
+	g, ok := m.(*image.Gray)
+	if !ok || g.Bounds() != a.Bounds() {
+		t.Fatalf("DeltaEMap => %T with bounds %v, want *image.Gray with %v", m, m.Bounds(), a.Bounds())
+	}
+	if v := g.GrayAt(0, 0).Y; v != 0 {
+		t.Errorf("DeltaEMap of equal pixels => %v, want 0", v)
+	}
+	if v := g.GrayAt(1, 0).Y; v == 0 || v > 64 {
+		t.Errorf("DeltaEMap of a small difference => %v, want a little above 0", v)
+	}
+	if v := g.GrayAt(2, 0).Y; v != 255 {
+		t.Errorf("DeltaEMap of a large difference => %v, want 255", v)
+	}
+
+	// Other metrics and scales.
+	m, _ = DeltaEMap(a, b, DeltaEMapOptions{Metric: Color.DistanceRgb, Scale: 10})
+	if v := m.(*image.Gray).GrayAt(2, 0).Y; v == 0 || v == 255 {
+		t.Errorf("DeltaEMap with Scale 10 => %v, want it not to saturate", v)
+	}
+
+	black, red := Color{0, 0, 0}, Color{1, 0, 0}
+	grad := NewGradient(black, red)
+	m, _ = DeltaEMap(a, b, DeltaEMapOptions{Colormap: &grad})
+	n, ok := m.(*image.NRGBA)
+	if !ok {
+		t.Fatalf("DeltaEMap with Colormap => %T, want *image.NRGBA", m)
+	}
+	if p := n.NRGBAAt(0, 0); p != (color.NRGBA{0, 0, 0, 255}) {
+		t.Errorf("DeltaEMap with Colormap of equal pixels => %v, want black", p)
+	}
+	if p := n.NRGBAAt(2, 0); p != (color.NRGBA{255, 0, 0, 255}) {
+		t.Errorf("DeltaEMap with Colormap of a large difference => %v, want red", p)
+	}
+
+	if _, err := DeltaEMap(a, image.NewNRGBA(image.Rect(0, 0, 2, 1)), DeltaEMapOptions{}); err == nil {
+		t.Errorf("DeltaEMap of differently sized images => no error")
+	}
+}
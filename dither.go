@@ -0,0 +1,352 @@
+// Ditherers for use with QuantizeImage.
+// All of them work in linear RGB, since that is the space in which light, and
+// thus the average of neighbouring pixels as seen from afar, adds up linearly.
+
+package colorful
+
+import (
+	"image"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+/// Error diffusion ///
+///////////////////////
+
+// A DiffusionWeight describes which fraction of the quantization error of a
+// pixel is pushed to the pixel at offset (DX, DY) from it.
+type DiffusionWeight struct {
+	DX, DY int
+	Weight float64
+}
+
+// ErrorDiffusion is a Ditherer which pushes the quantization error of every
+// pixel onto its not yet processed neighbours, as described by Weights.
+// The error is accumulated in linear RGB, unlike the standard library's
+// draw.FloydSteinberg which works on gamma-encoded values and thus produces
+// too bright results, especially with dark palettes.
+type ErrorDiffusion struct {
+	// All weights need DY > 0, or DY == 0 and DX > 0.
+	Weights []DiffusionWeight
+
+	// If set, every other row is processed from right to left, with the
+	// weights mirrored. This avoids some directional artifacts.
+	Serpentine bool
+}
+
+var (
+	// FloydSteinberg is the classic error diffusion by Floyd and Steinberg.
+	FloydSteinberg = ErrorDiffusion{Weights: []DiffusionWeight{
+		{1, 0, 7.0 / 16.0},
+		{-1, 1, 3.0 / 16.0}, {0, 1, 5.0 / 16.0}, {1, 1, 1.0 / 16.0},
+	}}
+
+	// Atkinson only propagates 3/4 of the error, which gives more contrast
+	// but loses detail in very dark and very light regions.
+	Atkinson = ErrorDiffusion{Weights: []DiffusionWeight{
+		{1, 0, 1.0 / 8.0}, {2, 0, 1.0 / 8.0},
+		{-1, 1, 1.0 / 8.0}, {0, 1, 1.0 / 8.0}, {1, 1, 1.0 / 8.0},
+		{0, 2, 1.0 / 8.0},
+	}}
+
+	// JarvisJudiceNinke spreads the error over a larger neighbourhood than
+	// FloydSteinberg, which results in smoother but slightly blurrier images.
+	JarvisJudiceNinke = ErrorDiffusion{Weights: []DiffusionWeight{
+		{1, 0, 7.0 / 48.0}, {2, 0, 5.0 / 48.0},
+		{-2, 1, 3.0 / 48.0}, {-1, 1, 5.0 / 48.0}, {0, 1, 7.0 / 48.0}, {1, 1, 5.0 / 48.0}, {2, 1, 3.0 / 48.0},
+		{-2, 2, 1.0 / 48.0}, {-1, 2, 3.0 / 48.0}, {0, 2, 5.0 / 48.0}, {1, 2, 3.0 / 48.0}, {2, 2, 1.0 / 48.0},
+	}}
+)
+
+func (d ErrorDiffusion) Dither(dst *image.Paletted, src image.Image, palette []Color, nearest func(Color) int) {
+	b := src.Bounds()
+	w := b.Dx()
+
+	lin := make([][3]float64, len(palette))
+	for i, c := range palette {
+		lin[i][0], lin[i][1], lin[i][2] = c.LinearRgb()
+	}
+
+	// We keep one row of errors per row reached by the kernel, padded on
+	// both sides so that we don't need to check the horizontal bounds.
+	pad, rows := 0, 1
+	for _, wt := range d.Weights {
+		if wt.DX > pad {
+			pad = wt.DX
+		} else if -wt.DX > pad {
+			pad = -wt.DX
+		}
+		if wt.DY+1 > rows {
+			rows = wt.DY + 1
+		}
+	}
+	errs := make([][][3]float64, rows)
+	for i := range errs {
+		errs[i] = make([][3]float64, w+2*pad)
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		reverse := d.Serpentine && (y-b.Min.Y)%2 == 1
+		for i := 0; i < w; i++ {
+			x, dir := i, 1
+			if reverse {
+				x, dir = w-1-i, -1
+			}
+
+			c, _ := MakeColor(src.At(b.Min.X+x, y))
+			e := &errs[0][x+pad]
+			r, g, bl := c.LinearRgb()
+			r, g, bl = clampErr(r+e[0]), clampErr(g+e[1]), clampErr(bl+e[2])
+
+			idx := nearest(LinearRgb(clamp01(r), clamp01(g), clamp01(bl)))
+			dst.SetColorIndex(b.Min.X+x, y, uint8(idx))
+
+			er, eg, eb := r-lin[idx][0], g-lin[idx][1], bl-lin[idx][2]
+			for _, wt := range d.Weights {
+				n := &errs[wt.DY][x+dir*wt.DX+pad]
+				n[0] += er * wt.Weight
+				n[1] += eg * wt.Weight
+				n[2] += eb * wt.Weight
+			}
+		}
+
+		// Move on to the next row, recycling the current one.
+		first := errs[0]
+		copy(errs, errs[1:])
+		for i := range first {
+			first[i] = [3]float64{}
+		}
+		errs[rows-1] = first
+	}
+}
+
+// The accumulated error must not be clamped to [0..1], or it would get lost
+// whenever the nearest palette entry isn't the one a linear threshold would
+// choose. But colors outside of the palette's gamut can make it grow without
+// bounds, so we still need to limit it somewhere.
+func clampErr(v float64) float64 {
+	return math.Max(-1.0, math.Min(v, 2.0))
+}
+
+/// Ordered ///
+///////////////
+
+// OrderedDither is a Ditherer which decides for every pixel between its
+// nearest palette entry and the entry on "the other side" of it, according to
+// a threshold map which is tiled over the image. Since the decision is taken
+// by where the pixel lies between the two entries in linear RGB, the result
+// has the correct brightness for any palette. Unlike error diffusion, every
+// pixel is independent of the others, so animations don't flicker.
+// Create one using Bayer or BlueNoise, the zero value doesn't dither at all
+// but maps every pixel to its nearest palette entry.
+type OrderedDither struct {
+	// The side length of the square threshold map.
+	Size int
+
+	// Size*Size thresholds in [0..1), stored row by row, which should be
+	// uniformly distributed.
+	Thresholds []float64
+}
+
+// Bayer creates an OrderedDither with a Bayer threshold map of the given
+// size, which needs to be a power of two. 4 and 8 are common choices.
+func Bayer(size int) OrderedDither {
+	if size < 1 || size&(size-1) != 0 {
+		panic("colorful: Bayer matrix size needs to be a power of two")
+	}
+
+	// Built recursively, each level tiles the previous one four times:
+	// M(2n) = [[4M, 4M+2], [4M+3, 4M+1]]
+	m := []int{0}
+	for n := 1; n < size; n *= 2 {
+		next := make([]int, 4*n*n)
+		for y := 0; y < n; y++ {
+			for x := 0; x < n; x++ {
+				v := 4 * m[y*n+x]
+				next[y*2*n+x] = v
+				next[y*2*n+x+n] = v + 2
+				next[(y+n)*2*n+x] = v + 3
+				next[(y+n)*2*n+x+n] = v + 1
+			}
+		}
+		m = next
+	}
+
+	thresholds := make([]float64, len(m))
+	for i, v := range m {
+		thresholds[i] = (float64(v) + 0.5) / float64(len(m))
+	}
+	return OrderedDither{Size: size, Thresholds: thresholds}
+}
+
+func (d OrderedDither) Dither(dst *image.Paletted, src image.Image, palette []Color, nearest func(Color) int) {
+	b := src.Bounds()
+
+	lin := make([][3]float64, len(palette))
+	for i, c := range palette {
+		lin[i][0], lin[i][1], lin[i][2] = c.LinearRgb()
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, _ := MakeColor(src.At(x, y))
+			idx := nearest(c)
+
+			// Mirroring the color at its nearest entry lands us close to
+			// the entry which lies on the other side of it.
+			r, g, bl := c.LinearRgb()
+			p := lin[idx]
+			other := nearest(LinearRgb(clamp01(2*r-p[0]), clamp01(2*g-p[1]), clamp01(2*bl-p[2])))
+
+			if other != idx && d.Size > 0 {
+				// How far along the way from the nearest to the other
+				// entry the color lies decides how often we pick the other.
+				q := lin[other]
+				dr, dg, db := q[0]-p[0], q[1]-p[1], q[2]-p[2]
+				t := ((r-p[0])*dr + (g-p[1])*dg + (bl-p[2])*db) / (dr*dr + dg*dg + db*db)
+				if d.threshold(x-b.Min.X, y-b.Min.Y) < t {
+					idx = other
+				}
+			}
+			dst.SetColorIndex(x, y, uint8(idx))
+		}
+	}
+}
+
+// threshold returns the threshold of the pixel at (x, y), which may lie
+// anywhere, also at negative coordinates.
+func (d OrderedDither) threshold(x, y int) float64 {
+	n := d.Size
+	return d.Thresholds[((y%n)+n)%n*n+((x%n)+n)%n]
+}
+
+/// Blue noise ///
+//////////////////
+
+var (
+	blueNoiseMutex sync.Mutex
+	blueNoiseCache = map[int][]float64{}
+)
+
+// BlueNoise creates an OrderedDither with a blue noise threshold map of the
+// given size, generated with Ulichney's void-and-cluster method. Blue noise
+// has no low-frequency structure, so unlike Bayer it doesn't produce visible
+// cross-hatch patterns, which makes it ideal for smooth gradients.
+// Generating the map takes time quadratic in its area (about a tenth of a
+// second for the recommended size of 64), but it is done only once per size.
+func BlueNoise(size int) OrderedDither {
+	if size < 4 {
+		panic("colorful: blue noise size needs to be at least 4")
+	}
+
+	blueNoiseMutex.Lock()
+	thresholds, ok := blueNoiseCache[size]
+	if !ok {
+		thresholds = voidAndCluster(size)
+		blueNoiseCache[size] = thresholds
+	}
+	blueNoiseMutex.Unlock()
+
+	return OrderedDither{Size: size, Thresholds: append([]float64(nil), thresholds...)}
+}
+
+// voidAndCluster ranks all pixels of a size*size torus such that the pixels of
+// any rank below a threshold are spread out as evenly as possible.
+// See Ulichney, "The void-and-cluster method for dither array generation", 1993.
+func voidAndCluster(size int) []float64 {
+	n := size * size
+
+	// Gaussian energy kernel, evaluated at every (wrapped around) offset.
+	const sigma = 1.5
+	kernel := make([]float64, n)
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			wx, wy := math.Min(float64(dx), float64(size-dx)), math.Min(float64(dy), float64(size-dy))
+			kernel[dy*size+dx] = math.Exp(-(wx*wx + wy*wy) / (2 * sigma * sigma))
+		}
+	}
+
+	pattern := make([]bool, n)
+	energy := make([]float64, n)
+	toggle := func(p int, on bool) {
+		pattern[p] = on
+		sign := 1.0
+		if !on {
+			sign = -1.0
+		}
+		px, py := p%size, p/size
+		for qy := 0; qy < size; qy++ {
+			row := ((qy - py + size) % size) * size
+			for qx := 0; qx < size; qx++ {
+				energy[qy*size+qx] += sign * kernel[row+(qx-px+size)%size]
+			}
+		}
+	}
+	// The tightest cluster is the set pixel with the highest energy,
+	// the largest void is the unset pixel with the lowest energy.
+	tightestCluster := func() (best int) {
+		best = -1
+		for p, on := range pattern {
+			if on && (best < 0 || energy[p] > energy[best]) {
+				best = p
+			}
+		}
+		return
+	}
+	largestVoid := func() (best int) {
+		best = -1
+		for p, on := range pattern {
+			if !on && (best < 0 || energy[p] < energy[best]) {
+				best = p
+			}
+		}
+		return
+	}
+
+	// Start from a random pattern with a tenth of the pixels set, and
+	// move points from clusters to voids until it's evenly distributed.
+	// The seed is fixed, so the map is always the same.
+	rng := rand.New(rand.NewSource(1))
+	ones := n / 10
+	for _, p := range rng.Perm(n)[:ones] {
+		toggle(p, true)
+	}
+	for {
+		cluster := tightestCluster()
+		toggle(cluster, false)
+		void := largestVoid()
+		toggle(void, true)
+		if void == cluster {
+			break
+		}
+	}
+	initial := append([]bool(nil), pattern...)
+	initialEnergy := append([]float64(nil), energy...)
+
+	rank := make([]int, n)
+
+	// Phase 1: rank the initial points by removing the tightest clusters.
+	for r := ones - 1; r >= 0; r-- {
+		p := tightestCluster()
+		toggle(p, false)
+		rank[p] = r
+	}
+
+	// Phase 2 and 3: rank the remaining ones by filling the largest voids.
+	// Ulichney switches to finding the tightest cluster of unset pixels half
+	// way, but since the total energy is constant, that's the same thing.
+	copy(pattern, initial)
+	copy(energy, initialEnergy)
+	for r := ones; r < n; r++ {
+		p := largestVoid()
+		toggle(p, true)
+		rank[p] = r
+	}
+
+	thresholds := make([]float64, n)
+	for p, r := range rank {
+		thresholds[p] = (float64(r) + 0.5) / float64(n)
+	}
+	return thresholds
+}
@@ -0,0 +1,144 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+var ditherers = []struct {
+	name string
+	d    Ditherer
+}{
+	{"FloydSteinberg", FloydSteinberg},
+	{"FloydSteinberg serpentine", ErrorDiffusion{FloydSteinberg.Weights, true}},
+	{"Atkinson", Atkinson},
+	{"JarvisJudiceNinke", JarvisJudiceNinke},
+	{"Bayer(4)", Bayer(4)},
+	{"Bayer(8)", Bayer(8)},
+	{"BlueNoise(32)", BlueNoise(32)},
+}
+
+// Dithering a flat mid-gray to black and white should result in a fraction of
+// white pixels equal to its linear (not its sRGB) value. Small ordered maps
+// can only approximate it to within 1/16.
+func TestDitherBrightness(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	for i := range img.Pix {
+		img.Pix[i] = 128
+	}
+	want, _, _ := Color{128.0 / 255.0, 0, 0}.LinearRgb()
+
+	for _, tt := range ditherers {
+		q := QuantizeImage(img, []Color{{0, 0, 0}, {1, 1, 1}}, tt.d)
+		white := 0
+		for _, p := range q.Pix {
+			white += int(p)
+		}
+		if got := float64(white) / float64(len(q.Pix)); math.Abs(got-want) > 0.035 {
+			t.Errorf("%v: fraction of white pixels is %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestDitherKeepsExactColors(t *testing.T) {
+	palette := []Color{{0, 0, 0}, {1, 0, 0}, {0, 0, 1}}
+	img := image.NewRGBA(image.Rect(0, 0, 9, 9))
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			img.Set(x, y, palette[(x+y)%3])
+		}
+	}
+
+	for _, tt := range ditherers {
+		q := QuantizeImage(img, palette, tt.d)
+		for y := 0; y < 9; y++ {
+			for x := 0; x < 9; x++ {
+				if got := int(q.ColorIndexAt(x, y)); got != (x+y)%3 {
+					t.Errorf("%v: pixel (%v, %v) => %v, want %v", tt.name, x, y, got, (x+y)%3)
+				}
+			}
+		}
+	}
+}
+
+func TestDitherOffsetBounds(t *testing.T) {
+	img := image.NewRGBA(image.Rect(-5, 10, 5, 20))
+	for y := 10; y < 20; y++ {
+		for x := -5; x < 5; x++ {
+			img.Set(x, y, color.RGBA{90, 90, 90, 255})
+		}
+	}
+	for _, tt := range ditherers {
+		if q := QuantizeImage(img, []Color{{0, 0, 0}, {1, 1, 1}}, tt.d); q.Bounds() != img.Bounds() {
+			t.Errorf("%v: bounds => %v, want %v", tt.name, q.Bounds(), img.Bounds())
+		}
+	}
+}
+
+func TestOrderedDitherZeroValue(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range img.Pix {
+		img.Pix[i] = 100
+	}
+	// Without a threshold map, every pixel gets the nearest entry.
+	q := QuantizeImage(img, []Color{{0, 0, 0}, {1, 1, 1}}, OrderedDither{})
+	for i, p := range q.Pix {
+		if p != 0 {
+			t.Fatalf("OrderedDither{} pixel %v => %v, want 0", i, p)
+		}
+	}
+}
+
+func TestBayer(t *testing.T) {
+	b := Bayer(4)
+	want := []float64{0, 8, 2, 10, 12, 4, 14, 6, 3, 11, 1, 9, 15, 7, 13, 5}
+	for i, v := range want {
+		if b.Thresholds[i] != (v+0.5)/16.0 {
+			t.Errorf("Bayer(4) threshold %v => %v, want %v", i, b.Thresholds[i], (v+0.5)/16.0)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Bayer(3) should panic")
+		}
+	}()
+	Bayer(3)
+}
+
+func TestBlueNoise(t *testing.T) {
+	const size = 32
+	b := BlueNoise(size)
+
+	// Every threshold needs to appear exactly once.
+	seen := make([]bool, size*size)
+	for _, v := range b.Thresholds {
+		r := int(v * size * size)
+		if seen[r] {
+			t.Fatalf("BlueNoise(%v) contains threshold %v twice", size, v)
+		}
+		seen[r] = true
+	}
+
+	// And the lowest ranks should be spread out, i.e. have no direct neighbours.
+	for p, v := range b.Thresholds {
+		if v >= 0.1 {
+			continue
+		}
+		x, y := p%size, p/size
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				q := ((y+dy+size)%size)*size + (x+dx+size)%size
+				if q != p && b.Thresholds[q] < 0.1 {
+					t.Errorf("BlueNoise(%v) has neighbouring low thresholds at %v and %v", size, p, q)
+				}
+			}
+		}
+	}
+
+	if c := BlueNoise(size); &c.Thresholds[0] == &b.Thresholds[0] {
+		t.Errorf("BlueNoise should not share its thresholds between calls")
+	}
+}
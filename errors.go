@@ -0,0 +1,94 @@
+package colorful
+
+import "fmt"
+
+// ErrInvalidHex is the error returned when parsing a malformed hex color.
+type ErrInvalidHex struct {
+	// Input is the string which failed to parse.
+	Input string
+
+	// Offset is the byte offset of the first invalid character, or -1 if
+	// the string has none but an invalid length.
+	Offset int
+}
+
+func (e ErrInvalidHex) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("color: %q is not a hex color: invalid number of digits", e.Input)
+	}
+	return fmt.Sprintf("color: %q is not a hex color: invalid digit %q at offset %v", e.Input, e.Input[e.Offset], e.Offset)
+}
+
+// ErrInvalidCSS is the error returned when parsing a malformed CSS color.
+type ErrInvalidCSS struct {
+	// Input is the string which failed to parse.
+	Input string
+
+	// Reason describes what is wrong with a CSS function, it is empty if
+	// Input doesn't look like a CSS color at all.
+	Reason string
+}
+
+func (e ErrInvalidCSS) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("color: %q is not a CSS color", e.Input)
+	}
+	return fmt.Sprintf("color: %q is not a valid CSS color: %v", e.Input, e.Reason)
+}
+
+// ErrOutOfRange is the error returned when a channel value lies outside of
+// the range its format allows.
+type ErrOutOfRange struct {
+	// Channel names the offending channel, such as "R" or "L".
+	Channel string
+
+	Value    float64
+	Min, Max float64
+}
+
+func (e ErrOutOfRange) Error() string {
+	return fmt.Sprintf("color: %v value %v is out of range [%v, %v]", e.Channel, e.Value, e.Min, e.Max)
+}
+
+// Excess returns by how much the value lies outside of the range, which is
+// negative if it is below Min.
+func (e ErrOutOfRange) Excess() float64 {
+	if e.Value < e.Min {
+		return e.Value - e.Min
+	}
+	if e.Value > e.Max {
+		return e.Value - e.Max
+	}
+	return 0
+}
+
+// ErrInvalidColor is the error returned by Color.Validate, listing each
+// channel which is out of range.
+type ErrInvalidColor struct {
+	Color    Color
+	Channels []ErrOutOfRange
+}
+
+func (e ErrInvalidColor) Error() string {
+	msg := fmt.Sprintf("color: %v is out of the RGB gamut:", e.Color)
+	for i, ch := range e.Channels {
+		if i > 0 {
+			msg += ","
+		}
+		if ch.Value < ch.Min {
+			msg += fmt.Sprintf(" %v is %v below %v", ch.Channel, -ch.Excess(), ch.Min)
+		} else {
+			msg += fmt.Sprintf(" %v is %v above %v", ch.Channel, ch.Excess(), ch.Max)
+		}
+	}
+	return msg
+}
+
+// Unwrap returns the first channel's error, such that errors.As finds an
+// ErrOutOfRange.
+func (e ErrInvalidColor) Unwrap() error {
+	if len(e.Channels) == 0 {
+		return nil
+	}
+	return e.Channels[0]
+}
@@ -0,0 +1,144 @@
+package colorful
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrInvalidHex(t *testing.T) {
+	tests := []struct {
+		hex    string
+		offset int
+	}{
+		{"gg0000", 0},
+		{"", -1},
+		{"#ff00zz", 5},
+		{"#ff0000 x", 7},
+		{"# ff0000", 1},
+		{"0x", -1},
+		{"#ff000", -1},
+		{"#", -1},
+		{"#ff00000", -1},
+	}
+	for i, tt := range tests {
+		_, err := Hex(tt.hex)
+		var herr ErrInvalidHex
+		if !errors.As(err, &herr) {
+			t.Errorf("%v. Hex(%q) => error %v, want an ErrInvalidHex", i, tt.hex, err)
+		} else if herr.Input != tt.hex || herr.Offset != tt.offset {
+			t.Errorf("%v. Hex(%q) => %#v, want offset %v", i, tt.hex, herr, tt.offset)
+		}
+	}
+
+	if _, err := ParseCSS("#12"); !errors.As(err, new(ErrInvalidHex)) {
+		t.Errorf("ParseCSS(\"#12\") => error %v, want an ErrInvalidHex", err)
+	}
+}
+
+func TestErrInvalidCSS(t *testing.T) {
+	tests := []struct {
+		s      string
+		reason bool
+	}{
+		{"nope", false},
+		{"rgb(1 2)", true},
+		{"color()", true},
+		{"foo(1 2 3)", true},
+		{"rgb(1 2 3 4)", true},
+	}
+	for i, tt := range tests {
+		_, err := ParseCSS(tt.s)
+		var cerr ErrInvalidCSS
+		if !errors.As(err, &cerr) {
+			t.Errorf("%v. ParseCSS(%q) => error %v, want an ErrInvalidCSS", i, tt.s, err)
+		} else if cerr.Input != tt.s || (cerr.Reason != "") != tt.reason {
+			t.Errorf("%v. ParseCSS(%q) => %#v", i, tt.s, cerr)
+		}
+	}
+
+	// Out of range values are clamped as in CSS.
+	if c, err := ParseCSS("rgb(300 -20 0)"); err != nil || c != (Color{1, 0, 0}) {
+		t.Errorf("ParseCSS(rgb(300 -20 0)) => %v, %v, want red", c, err)
+	}
+}
+
+func TestErrOutOfRange(t *testing.T) {
+	_, _, err := ReadGPL(strings.NewReader("GIMP Palette\n0 300 0 Too green\n"))
+	var rerr ErrOutOfRange
+	if !errors.As(err, &rerr) {
+		t.Fatalf("ReadGPL => error %v, want an ErrOutOfRange", err)
+	}
+	if want := (ErrOutOfRange{"G", 300, 0, 255}); rerr != want {
+		t.Errorf("ReadGPL => %#v, want %#v", rerr, want)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if c := MustHex("#ff8000"); c != (Color{1, 128.0 / 255.0, 0}) {
+		t.Errorf("MustHex(#ff8000) => %v", c)
+	}
+	if c := MustParseCSS("rgb(255 0 0)"); c != (Color{1, 0, 0}) {
+		t.Errorf("MustParseCSS(rgb(255 0 0)) => %v", c)
+	}
+
+	for _, f := range []func(){
+		func() { MustHex("#nope") },
+		func() { MustParseCSS("nope(1 2 3)") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Must helper didn't panic on invalid input")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		c     Color
+		chans string
+		delta float64
+	}{
+		{Color{0, 0.5, 1}, "", 0},
+		{Color{1.2, 0.5, -0.1}, "RB", 0.223606797749979},
+		{Color{0, -0.5, 0}, "G", 0.5},
+		{Color{2, 2, 2}, "RGB", 1.7320508075688772},
+	}
+	for i, tt := range tests {
+		err := tt.c.Validate()
+		if tt.chans == "" {
+			if err != nil {
+				t.Errorf("%v. %v.Validate() => %v, want nil", i, tt.c, err)
+			}
+			continue
+		}
+
+		var verr ErrInvalidColor
+		if !errors.As(err, &verr) {
+			t.Fatalf("%v. %v.Validate() => %v, want an ErrInvalidColor", i, tt.c, err)
+		}
+		chans := ""
+		for _, ch := range verr.Channels {
+			chans += ch.Channel
+			if ch.Excess() == 0 {
+				t.Errorf("%v. %v.Validate() => %v with zero excess", i, tt.c, ch)
+			}
+		}
+		if chans != tt.chans {
+			t.Errorf("%v. %v.Validate() => channels %v, want %v", i, tt.c, chans, tt.chans)
+		}
+		if !errors.As(err, new(ErrOutOfRange)) {
+			t.Errorf("%v. %v.Validate() => %v, want it to wrap an ErrOutOfRange", i, tt.c, err)
+		}
+	}
+
+	for i, tt := range tests {
+		if delta := tt.c.DeltaFromGamut(); !almosteq(delta, tt.delta) {
+			t.Errorf("%v. %v.DeltaFromGamut() => %v, want %v", i, tt.c, delta, tt.delta)
+		}
+	}
+}
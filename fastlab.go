@@ -0,0 +1,88 @@
+// Fast approximations of the Lab and OkLab conversions, for per-pixel work
+// such as computing ΔE images in real time.
+
+package colorful
+
+import "math"
+
+// linearizeTable holds linearize at 4096 equidistant steps, which linear
+// interpolation turns into an approximation with an error below 1e-7.
+var linearizeTable [4097]float64
+
+func init() {
+	for i := range linearizeTable {
+		linearizeTable[i] = linearize(float64(i) / 4096.0)
+	}
+}
+
+// linearizeLerp approximates linearize for values in [0..1].
+func linearizeLerp(v float64) float64 {
+	f := clamp01(v) * 4096.0
+	i := int(f)
+	if i >= 4096 {
+		return linearizeTable[4096]
+	}
+	return linearizeTable[i] + (f-float64(i))*(linearizeTable[i+1]-linearizeTable[i])
+}
+
+// fastCbrt approximates math.Cbrt for non-negative values, by guessing from
+// the exponent bits and two Newton steps, with a relative error below 2e-6.
+func fastCbrt(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	y := math.Float64frombits(math.Float64bits(x)/3 + 0x2a9f7893782da1ce)
+	y = (2.0*y + x/(y*y)) / 3.0
+	y = (2.0*y + x/(y*y)) / 3.0
+	return y
+}
+
+// The matrix from linear RGB to XYZ fused with the division by the D65
+// white of Lab.
+var linearRgbToXyzD65Mat = func() mat3 {
+	m := linearRgbToXyzMat
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] /= D65[i]
+		}
+	}
+	return m
+}()
+
+func fastLabF(t float64) float64 {
+	if t > 6.0/29.0*6.0/29.0*6.0/29.0 {
+		return fastCbrt(t)
+	}
+	return t/3.0*29.0/6.0*29.0/6.0 + 4.0/29.0
+}
+
+// FastLab is a faster approximation of Lab, which is off by less than 1e-5
+// in each of L*, a* and b*, a thousandth of a just noticeable difference.
+// Like FastLinearRgb, it only works for valid colors.
+func (col Color) FastLab() (l, a, b float64) {
+	x, y, z := linearRgbToXyzD65Mat.mul(linearizeLerp(col.R), linearizeLerp(col.G), linearizeLerp(col.B))
+	fx, fy, fz := fastLabF(x), fastLabF(y), fastLabF(z)
+	return 1.16*fy - 0.16, 5.0 * (fx - fy), 2.0 * (fy - fz)
+}
+
+// FastOkLab is a faster approximation of OkLab, which is off by less than
+// 1e-5 in each of L, a and b. Like FastLinearRgb, it only works for valid
+// colors.
+func (col Color) FastOkLab() (l, a, b float64) {
+	lms := mulVec(&okLabLmsMat, vec3[float64]{linearizeLerp(col.R), linearizeLerp(col.G), linearizeLerp(col.B)})
+	return mulVec(&okLabLabMat, lms.apply(fastCbrt)).split()
+}
+
+// FastDistanceLab is DistanceLab computed using FastLab.
+func (c1 Color) FastDistanceLab(c2 Color) float64 {
+	l1, a1, b1 := c1.FastLab()
+	l2, a2, b2 := c2.FastLab()
+	return vec3[float64]{l1, a1, b1}.dist(vec3[float64]{l2, a2, b2})
+}
+
+// FastDistanceOkLab is DistanceOkLab computed using FastOkLab.
+func (c1 Color) FastDistanceOkLab(c2 Color) float64 {
+	l1, a1, b1 := c1.FastOkLab()
+	l2, a2, b2 := c2.FastOkLab()
+	return vec3[float64]{l1, a1, b1}.dist(vec3[float64]{l2, a2, b2})
+}
@@ -0,0 +1,84 @@
+package colorful
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFastCbrt(t *testing.T) {
+	maxerr := 0.0
+	for x := 1e-9; x < 2; x *= 1.001 {
+		maxerr = math.Max(maxerr, math.Abs(fastCbrt(x)-math.Cbrt(x))/math.Cbrt(x))
+	}
+	if maxerr > 2e-6 {
+		t.Errorf("fastCbrt has a relative error of %v, want below 2e-6", maxerr)
+	}
+}
+
+func TestFastLab(t *testing.T) {
+	rand.Seed(7)
+	cols := append(randomColors(100000), Color{0, 0, 0}, Color{1, 1, 1}, Color{1, 0, 0}, Color{0, 0, 1}, Color{0.04045, 0.5, 0.003})
+
+	var maxlab, maxoklab float64
+	for _, c := range cols {
+		l1, a1, b1 := c.FastLab()
+		l2, a2, b2 := c.Lab()
+		maxlab = math.Max(maxlab, math.Max(math.Abs(l1-l2), math.Max(math.Abs(a1-a2), math.Abs(b1-b2))))
+
+		l1, a1, b1 = c.FastOkLab()
+		l2, a2, b2 = c.OkLab()
+		maxoklab = math.Max(maxoklab, math.Max(math.Abs(l1-l2), math.Max(math.Abs(a1-a2), math.Abs(b1-b2))))
+	}
+	if maxlab > 1e-5 {
+		t.Errorf("FastLab is off by up to %v, want below 1e-5", maxlab)
+	}
+	if maxoklab > 1e-5 {
+		t.Errorf("FastOkLab is off by up to %v, want below 1e-5", maxoklab)
+	}
+
+	c1, c2 := Color{0.2, 0.5, 0.9}, Color{0.9, 0.4, 0.1}
+	if d1, d2 := c1.FastDistanceLab(c2), c1.DistanceLab(c2); !almosteq_eps(d1, d2, 1e-6) {
+		t.Errorf("FastDistanceLab => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.FastDistanceOkLab(c2), c1.DistanceOkLab(c2); !almosteq_eps(d1, d2, 1e-6) {
+		t.Errorf("FastDistanceOkLab => %v, want %v", d1, d2)
+	}
+}
+
+func BenchmarkOkLab(bench *testing.B) {
+	c := Color{0.2, 0.5, 0.9}
+	for n := 0; n < bench.N; n++ {
+		c.OkLab()
+	}
+}
+
+func BenchmarkFastOkLab(bench *testing.B) {
+	c := Color{0.2, 0.5, 0.9}
+	for n := 0; n < bench.N; n++ {
+		c.FastOkLab()
+	}
+}
+
+func BenchmarkFromOkLab(bench *testing.B) {
+	var c Color
+	for n := 0; n < bench.N; n++ {
+		c = OkLab(0.6, 0.05, -0.1)
+	}
+	bench_result = c.R + c.G + c.B
+}
+
+func BenchmarkFromLab(bench *testing.B) {
+	var c Color
+	for n := 0; n < bench.N; n++ {
+		c = Lab(0.6, 0.2, -0.3)
+	}
+	bench_result = c.R + c.G + c.B
+}
+
+func BenchmarkLab(bench *testing.B) {
+	c := Color{0.2, 0.5, 0.9}
+	for n := 0; n < bench.N; n++ {
+		c.Lab()
+	}
+}
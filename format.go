@@ -0,0 +1,80 @@
+// Support for printing colors with the fmt package.
+
+package colorful
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the hex representation of the color, as in #ff0080.
+func (col Color) String() string {
+	return col.Hex()
+}
+
+// Format implements fmt.Formatter, such that colors print usefully in logs:
+//
+//     %s    the hex representation, as in #ff0080
+//     %x %X the hex representation without the #, in lower or upper case
+//     %q    the quoted hex representation
+//     %v    the R, G and B values, as in {1 0 0.5}
+//     %+v   the R, G and B values and the color in some other spaces
+//     %#v   Go syntax, as in colorful.Color{R:1, G:0, B:0.5}
+//
+// All other verbs, as well as width and precision, are applied to each of the
+// R, G and B values, e.g. %.2f prints {1.00 0.00 0.50}.
+func (col Color) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 's':
+		fmt.Fprintf(f, formatDirective(f, 's'), col.String())
+	case verb == 'q':
+		fmt.Fprintf(f, formatDirective(f, 'q'), col.String())
+	case verb == 'x':
+		fmt.Fprintf(f, formatDirective(f, 's'), col.Hex()[1:])
+	case verb == 'X':
+		fmt.Fprintf(f, formatDirective(f, 's'), strings.ToUpper(col.Hex()[1:]))
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "colorful.Color{R:%#v, G:%#v, B:%#v}", col.R, col.G, col.B)
+	case verb == 'v' && f.Flag('+'):
+		fv := formatDirective(f, 'v')
+		fmt.Fprintf(f, "{R:"+fv+" G:"+fv+" B:"+fv+" hex:%s", col.R, col.G, col.B, col.Hex())
+		tuple := func(a, b, c float64) {
+			fmt.Fprintf(f, "(%.4g %.4g %.4g)", a, b, c)
+		}
+		fmt.Fprint(f, " hsv:")
+		tuple(col.Hsv())
+		fmt.Fprint(f, " hsl:")
+		tuple(col.Hsl())
+		fmt.Fprint(f, " lab:")
+		tuple(col.Lab())
+		fmt.Fprint(f, " hcl:")
+		tuple(col.Hcl())
+		fmt.Fprint(f, " oklab:")
+		tuple(col.OkLab())
+		fmt.Fprint(f, " oklch:")
+		tuple(col.OkLch())
+		fmt.Fprint(f, "}")
+	default:
+		fv := formatDirective(f, verb)
+		fmt.Fprintf(f, "{"+fv+" "+fv+" "+fv+"}", col.R, col.G, col.B)
+	}
+}
+
+// formatDirective rebuilds the directive (like %-8.2f) which led to the call
+// of Format, but without the + and # flags and with the given verb.
+func formatDirective(f fmt.State, verb rune) string {
+	d := "%"
+	for _, flag := range "- 0" {
+		if f.Flag(int(flag)) {
+			d += string(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		d += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		d += "." + strconv.Itoa(p)
+	}
+	return d + string(verb)
+}
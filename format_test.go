@@ -0,0 +1,40 @@
+package colorful
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	c := Color{1.0, 0.0, 0.5}
+	for _, tt := range []struct {
+		format string
+		want   string
+	}{
+		{"%s", "#ff0080"},
+		{"%10s", "   #ff0080"},
+		{"%x", "ff0080"},
+		{"%X", "FF0080"},
+		{"%q", `"#ff0080"`},
+		{"%v", "{1 0 0.5}"},
+		{"%.2f", "{1.00 0.00 0.50}"},
+		{"%#v", "colorful.Color{R:1, G:0, B:0.5}"},
+		{"%d", "{%!d(float64=1) %!d(float64=0) %!d(float64=0.5)}"},
+	} {
+		if got := fmt.Sprintf(tt.format, c); got != tt.want {
+			t.Errorf("Sprintf(%q, %v) => %q, want %q", tt.format, c, got, tt.want)
+		}
+	}
+
+	if got := c.String(); got != "#ff0080" {
+		t.Errorf("%v.String() => %q, want #ff0080", c, got)
+	}
+
+	dump := fmt.Sprintf("%+v", c)
+	for _, part := range []string{"{R:1 G:0 B:0.5 hex:#ff0080 ", " hsv:(", " lab:(", " oklch:("} {
+		if !strings.Contains(dump, part) {
+			t.Errorf("Sprintf(%%+v) => %q, which is missing %q", dump, part)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+// Bringing out-of-gamut colors into the RGB gamut.
+
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+// reduceChroma finds the largest chroma in [0..c] at which at returns a
+// valid color, by bisection, and returns that color.
+func reduceChroma(c float64, at func(c float64) Color) Color {
+	lo, hi := 0.0, c
+	for i := 0; i < 32; i++ {
+		mid := (lo + hi) / 2.0
+		if at(mid).IsValid() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	// Takes care of the remaining rounding noise.
+	return at(lo).Clamped()
+}
+
+// ClampedHcl brings the color into the RGB gamut by reducing its chroma in
+// HCL space, keeping its hue and lightness, unlike Clamped which shifts the
+// hue of saturated colors. Lightness outside of [0..1] is clamped, and valid
+// colors are returned as-is.
+func (col Color) ClampedHcl() Color {
+	if col.IsValid() {
+		return col
+	}
+	h, c, l := col.Hcl()
+	l = clamp01(l)
+	return reduceChroma(c, func(c float64) Color {
+		return Hcl(h, c, l)
+	})
+}
+
+// ClampedOkLch is like ClampedHcl, but keeps hue and lightness in OkLch
+// space, which preserves the hue of blues better.
+func (col Color) ClampedOkLch() Color {
+	if col.IsValid() {
+		return col
+	}
+	l, c, h := col.OkLch()
+	l = clamp01(l)
+	return reduceChroma(c, func(c float64) Color {
+		return OkLch(l, c, h)
+	})
+}
+
+// ToGamut brings the color into the RGB gamut using the gamut mapping
+// algorithm of CSS Color 4: it reduces the OkLch chroma by binary search, but
+// accepts clipping the channels once the clipped color is less than a just
+// noticeable difference (0.02 in OkLab) away, which keeps more of the color's
+// saturation than ClampedOkLch. Lightness at or beyond white or black gives
+// white or black, and valid colors are returned as-is.
+// https://www.w3.org/TR/css-color-4/#gamut-mapping
+func (col Color) ToGamut() Color {
+	return col.ToGamutIn(SRGB)
+}
+
+// ToGamutIn is like ToGamut, but maps the color into the gamut of the given
+// RGB space, such as DisplayP3, which may be outside of sRGB's.
+func (col Color) ToGamutIn(s *RGBSpace) Color {
+	const jnd, epsilon = 0.02, 0.0001
+
+	if col.IsValidIn(s) {
+		return col
+	}
+	l, c, h := col.OkLch()
+	if l >= 1 {
+		return Color{1, 1, 1}
+	} else if l <= 0 {
+		return Color{0, 0, 0}
+	}
+
+	current := col
+	clipped := current.ClampedIn(s)
+	if clipped.DistanceOkLab(current) < jnd {
+		return clipped
+	}
+
+	lo, hi, loInGamut := 0.0, c, true
+	for hi-lo > epsilon {
+		chroma := (lo + hi) / 2
+		current = OkLch(l, chroma, h)
+		if loInGamut && current.IsValidIn(s) {
+			lo = chroma
+			continue
+		}
+		clipped = current.ClampedIn(s)
+		if e := clipped.DistanceOkLab(current); e < jnd {
+			if jnd-e < epsilon {
+				return clipped
+			}
+			loInGamut = false
+			lo = chroma
+		} else {
+			hi = chroma
+		}
+	}
+	return clipped
+}
+
+// A CylindricalSpace is a color space of lightness, chroma and hue.
+type CylindricalSpace int
+
+const (
+	// SpaceHcl is the HCL space of Color.Hcl, derived from CIE L*a*b*.
+	SpaceHcl CylindricalSpace = iota
+	// SpaceOkLch is the OkLch space of Color.OkLch, derived from OkLab.
+	SpaceOkLch
+)
+
+// MaxChroma returns the largest chroma at which the hue h and lightness l of
+// the space are inside the gamut of the named RGB space, as in Config.Space,
+// where "" means sRGB. It is 0 for lightness outside of (0..1). Ramps using
+// this chroma for each step are as saturated as possible without clipping.
+func MaxChroma(h, l float64, space CylindricalSpace, gamut string) float64 {
+	rgb, ok := workingSpace(gamut)
+	if !ok {
+		panic(fmt.Sprintf("colorful: unknown working space %q", gamut))
+	}
+	return maxChroma(h, l, space, rgb)
+}
+
+func maxChroma(h, l float64, space CylindricalSpace, s *RGBSpace) float64 {
+	if !(l > 0 && l < 1) {
+		return 0
+	}
+
+	var at func(c float64) Color
+	var hi float64
+	switch space {
+	case SpaceHcl:
+		at = func(c float64) Color { return Hcl(h, c, l) }
+		hi = 2
+	case SpaceOkLch:
+		at = func(c float64) Color { return OkLch(l, c, h) }
+		hi = 0.6
+	default:
+		panic("colorful: unknown cylindrical space")
+	}
+
+	lo := 0.0
+	for i := 0; i < 32; i++ {
+		mid := (lo + hi) / 2
+		if s.Contains(at(mid)) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+/// Gamut boundary ///
+//////////////////////
+
+// A GamutBoundary describes the surface of the gamut of an RGB space in OkLch,
+// precomputed on a grid of hues and lightnesses, such that querying it is
+// cheap. It is safe for concurrent use.
+type GamutBoundary struct {
+	space           *RGBSpace
+	hues, lightness int
+
+	// chroma holds the maximal chroma of each hue for lightness 0 to 1.
+	chroma [][]float64
+	cuspL  []float64
+	cuspC  []float64
+}
+
+// NewGamutBoundary computes the boundary of the gamut of the RGB space, for
+// the given number of evenly spaced hues and lightness steps. 360 hues and
+// 100 steps take about a second and are accurate to a small fraction of a
+// just noticeable difference, except close to the cusps where the boundary
+// has a kink.
+func NewGamutBoundary(s *RGBSpace, hues, lightnessSteps int) *GamutBoundary {
+	if hues < 1 || lightnessSteps < 2 {
+		panic("colorful: a gamut boundary needs at least 1 hue and 2 lightness steps")
+	}
+	g := &GamutBoundary{
+		space:     s,
+		hues:      hues,
+		lightness: lightnessSteps,
+		chroma:    make([][]float64, hues),
+		cuspL:     make([]float64, hues),
+		cuspC:     make([]float64, hues),
+	}
+	for i := range g.chroma {
+		h := 360 * float64(i) / float64(hues)
+		row := make([]float64, lightnessSteps+1)
+		best := 0
+		for j := range row {
+			row[j] = maxChroma(h, float64(j)/float64(lightnessSteps), SpaceOkLch, s)
+			if row[j] > row[best] {
+				best = j
+			}
+		}
+		g.chroma[i] = row
+
+		// The maximal chroma is unimodal in lightness, refine the cusp
+		// around the best step by golden section search.
+		lo := float64(best-1) / float64(lightnessSteps)
+		hi := float64(best+1) / float64(lightnessSteps)
+		for k := 0; k < 30; k++ {
+			m1, m2 := hi-0.618034*(hi-lo), lo+0.618034*(hi-lo)
+			if maxChroma(h, m1, SpaceOkLch, s) < maxChroma(h, m2, SpaceOkLch, s) {
+				lo = m1
+			} else {
+				hi = m2
+			}
+		}
+		g.cuspL[i] = (lo + hi) / 2
+		g.cuspC[i] = maxChroma(h, g.cuspL[i], SpaceOkLch, s)
+	}
+	return g
+}
+
+// hueIndex returns the two grid hues around h and the weight of the second.
+func (g *GamutBoundary) hueIndex(h float64) (i0, i1 int, t float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	f := h / 360 * float64(g.hues)
+	i0 = int(f) % g.hues
+	return i0, (i0 + 1) % g.hues, f - math.Floor(f)
+}
+
+// Cusp returns the OkLch lightness and chroma of the most chromatic color of
+// the gamut at hue h, interpolated between the precomputed hues.
+func (g *GamutBoundary) Cusp(h float64) (l, c float64) {
+	i0, i1, t := g.hueIndex(h)
+	return g.cuspL[i0] + t*(g.cuspL[i1]-g.cuspL[i0]), g.cuspC[i0] + t*(g.cuspC[i1]-g.cuspC[i0])
+}
+
+// MaxChroma returns the largest OkLch chroma at hue h and lightness l which
+// is inside the gamut, interpolated bilinearly on the grid like MaxChroma.
+func (g *GamutBoundary) MaxChroma(h, l float64) float64 {
+	if !(l > 0 && l < 1) {
+		return 0
+	}
+	i0, i1, t := g.hueIndex(h)
+	f := l * float64(g.lightness)
+	j := int(f)
+	if j >= g.lightness {
+		j = g.lightness - 1
+	}
+	u := f - float64(j)
+	c0 := g.chroma[i0][j] + u*(g.chroma[i0][j+1]-g.chroma[i0][j])
+	c1 := g.chroma[i1][j] + u*(g.chroma[i1][j+1]-g.chroma[i1][j])
+	return c0 + t*(c1-c0)
+}
+
+// Contains reports whether the OkLch color is inside the gamut according to
+// the boundary.
+func (g *GamutBoundary) Contains(l, c, h float64) bool {
+	return c <= g.MaxChroma(h, l)
+}
+
+/// Gamut mappers ///
+/////////////////////
+
+// A GamutMapper brings colors outside of the RGB gamut, such as those created
+// in Lab or OkLch or blended between such colors, into the gamut. There is no
+// single best way to do so: clipping the channels is fast but shifts hues,
+// reducing chroma keeps hues but loses saturation, and soft compression also
+// changes valid colors close to the edge to keep their gradations apart.
+// Mappers apply to the results of constructors and blends, which have
+// variants taking one such as HclIn and Color.BlendHclIn, and Gradient takes
+// one.
+type GamutMapper interface {
+	// MapToGamut returns a valid color for col, and valid colors unchanged
+	// unless the mapper deliberately changes them.
+	MapToGamut(col Color) Color
+}
+
+// GamutMapperFunc adapts a function, such as Color.ToGamut, to a GamutMapper.
+type GamutMapperFunc func(col Color) Color
+
+func (f GamutMapperFunc) MapToGamut(col Color) Color {
+	return f(col)
+}
+
+var (
+	// ClipGamut clips each channel into [0..1], see Color.Clamped.
+	ClipGamut GamutMapper = GamutMapperFunc(Color.Clamped)
+
+	// ChromaReductionGamut reduces chroma keeping hue and lightness in
+	// OkLch, see Color.ClampedOkLch.
+	ChromaReductionGamut GamutMapper = GamutMapperFunc(Color.ClampedOkLch)
+
+	// TowardsGrayGamut keeps the OkLch hue and moves the color straight
+	// towards the gray of lightness 0.5, changing lightness along with chroma,
+	// which keeps more saturation for very light or dark colors.
+	TowardsGrayGamut GamutMapper = GamutMapperFunc(Color.towardsMidGray)
+
+	// CSSGamut is the gamut mapping of CSS Color 4, see Color.ToGamut.
+	CSSGamut GamutMapper = GamutMapperFunc(Color.ToGamut)
+)
+
+// towardsMidGray is the mapping of TowardsGrayGamut.
+func (col Color) towardsMidGray() Color {
+	if col.IsValid() {
+		return col
+	}
+	l, c, h := col.OkLch()
+	return reduceChroma(1, func(t float64) Color {
+		return OkLch(0.5+t*(l-0.5), t*c, h)
+	})
+}
+
+// SoftCompressionGamut compresses OkLch chroma smoothly, keeping hue and
+// lightness: chroma up to Threshold times the largest one in gamut is kept,
+// larger chroma is compressed into the remaining range, such that colors
+// beyond the gamut keep differing from each other instead of piling up at its
+// edge. It changes valid colors of high chroma too.
+type SoftCompressionGamut struct {
+	// Threshold is the fraction of the gamut left untouched, in (0..1).
+	// Zero means 0.8.
+	Threshold float64
+}
+
+func (s SoftCompressionGamut) MapToGamut(col Color) Color {
+	threshold := s.Threshold
+	if threshold <= 0 || threshold >= 1 {
+		threshold = 0.8
+	}
+	l, c, h := col.OkLch()
+	if l <= 0 || l >= 1 {
+		return col.ClampedOkLch()
+	}
+
+	max := MaxChroma(h, l, SpaceOkLch, "")
+	knee := threshold * max
+	if c <= knee {
+		return col.ClampedOkLch()
+	}
+	c = knee + (max-knee)*math.Tanh((c-knee)/(max-knee))
+	return OkLch(l, c, h).ClampedOkLch()
+}
+
+/// Constructors and blends with a gamut mapper ///
+///////////////////////////////////////////////////
+
+// These are the constructors and blends of the spaces reaching beyond the RGB
+// gamut, bringing their results into it with the mapper m, where nil means
+// ClipGamut. HclIn(h, c, l, m) is m.MapToGamut(Hcl(h, c, l)).
+
+func mapToGamut(col Color, m GamutMapper) Color {
+	if m == nil {
+		m = ClipGamut
+	}
+	return m.MapToGamut(col)
+}
+
+// LabIn is Lab with the result mapped into the gamut by m.
+func LabIn(l, a, b float64, m GamutMapper) Color {
+	return mapToGamut(Lab(l, a, b), m)
+}
+
+// LuvIn is Luv with the result mapped into the gamut by m.
+func LuvIn(l, u, v float64, m GamutMapper) Color {
+	return mapToGamut(Luv(l, u, v), m)
+}
+
+// HclIn is Hcl with the result mapped into the gamut by m.
+func HclIn(h, c, l float64, m GamutMapper) Color {
+	return mapToGamut(Hcl(h, c, l), m)
+}
+
+// LuvLChIn is LuvLCh with the result mapped into the gamut by m.
+func LuvLChIn(l, c, h float64, m GamutMapper) Color {
+	return mapToGamut(LuvLCh(l, c, h), m)
+}
+
+// OkLabIn is OkLab with the result mapped into the gamut by m.
+func OkLabIn(l, a, b float64, m GamutMapper) Color {
+	return mapToGamut(OkLab(l, a, b), m)
+}
+
+// OkLchIn is OkLch with the result mapped into the gamut by m.
+func OkLchIn(l, c, h float64, m GamutMapper) Color {
+	return mapToGamut(OkLch(l, c, h), m)
+}
+
+// BlendLabIn is BlendLab with the result mapped into the gamut by m.
+func (c1 Color) BlendLabIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(c1.BlendLab(c2, t), m)
+}
+
+// BlendLuvIn is BlendLuv with the result mapped into the gamut by m.
+func (c1 Color) BlendLuvIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(c1.BlendLuv(c2, t), m)
+}
+
+// BlendHclIn is BlendHcl with the result mapped into the gamut by m instead
+// of clamped.
+func (c1 Color) BlendHclIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(blendHclUnclamped(c1, c2, t), m)
+}
+
+// BlendLuvLChIn is BlendLuvLCh with the result mapped into the gamut by m.
+func (c1 Color) BlendLuvLChIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(c1.BlendLuvLCh(c2, t), m)
+}
+
+// BlendOkLabIn is BlendOkLab with the result mapped into the gamut by m.
+func (c1 Color) BlendOkLabIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(c1.BlendOkLab(c2, t), m)
+}
+
+// BlendOkLchIn is BlendOkLch with the result mapped into the gamut by m.
+func (c1 Color) BlendOkLchIn(c2 Color, t float64, m GamutMapper) Color {
+	return mapToGamut(c1.BlendOkLch(c2, t), m)
+}
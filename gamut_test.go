@@ -0,0 +1,227 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClampedHcl(t *testing.T) {
+	for i, c := range []Color{
+		Hcl(30, 1.2, 0.5),
+		Hcl(270, 0.9, 0.3),
+		Lab(0.6, -0.9, 0.9),
+		{1.5, 0.2, -0.3},
+		{0.1, 0.2, 0.3},
+	} {
+		got := c.ClampedHcl()
+		if !got.IsValid() {
+			t.Errorf("%v. %v.ClampedHcl() => %v, which is invalid", i, c, got)
+		}
+		h1, c1, l1 := c.Hcl()
+		h2, c2, l2 := got.Hcl()
+		if c.IsValid() && got != c {
+			t.Errorf("%v. %v.ClampedHcl() => %v, want it unchanged", i, c, got)
+		}
+		if math.Abs(l1-l2) > 0.01 || c2 > 0.01 && math.Abs(h1-h2) > 1 || c2 > c1+1e-9 {
+			t.Errorf("%v. %v.ClampedHcl() => HCL (%v, %v, %v), want hue %v and lightness %v", i, c, h2, c2, l2, h1, l1)
+		}
+	}
+}
+
+func TestClampedOkLch(t *testing.T) {
+	for i, c := range []Color{
+		OkLch(0.5, 0.4, 30),
+		OkLch(0.4, 0.35, 264),
+		{1.5, 0.2, -0.3},
+		{-0.2, -0.1, 1.3},
+	} {
+		got := c.ClampedOkLch()
+		if !got.IsValid() {
+			t.Errorf("%v. %v.ClampedOkLch() => %v, which is invalid", i, c, got)
+		}
+		l1, c1, h1 := c.OkLch()
+		l2, c2, h2 := got.OkLch()
+		if math.Abs(l1-l2) > 0.01 || c2 > 0.01 && math.Abs(h1-h2) > 1 || c2 > c1+1e-9 {
+			t.Errorf("%v. %v.ClampedOkLch() => OkLch (%v, %v, %v), want lightness %v and hue %v", i, c, l2, c2, h2, l1, h1)
+		}
+	}
+}
+
+func TestToGamut(t *testing.T) {
+	if got := (Color{0.1, 0.2, 0.3}).ToGamut(); got != (Color{0.1, 0.2, 0.3}) {
+		t.Errorf("ToGamut of a valid color => %v, want it unchanged", got)
+	}
+	if got := OkLch(1.2, 0.1, 30).ToGamut(); got != (Color{1, 1, 1}) {
+		t.Errorf("ToGamut of too light a color => %v, want white", got)
+	}
+	if got := OkLch(-0.1, 0.1, 30).ToGamut(); got != (Color{0, 0, 0}) {
+		t.Errorf("ToGamut of too dark a color => %v, want black", got)
+	}
+
+	for i, c := range []Color{
+		OkLch(0.5, 0.4, 30),
+		OkLch(0.4, 0.35, 264),
+		OkLch(0.9, 0.3, 140),
+		{1.5, 0.2, -0.3},
+		{-0.2, -0.1, 1.3},
+		{1.01, 0.5, 0.5},
+	} {
+		got := c.ToGamut()
+		if !got.IsValid() {
+			t.Errorf("%v. %v.ToGamut() => %v, which is invalid", i, c, got)
+		}
+		l1, _, h1 := c.OkLch()
+		l2, c2, h2 := got.OkLch()
+		if math.Abs(l1-l2) > 0.02 || c2 > 0.01 && math.Abs(h1-h2) > 5 {
+			t.Errorf("%v. %v.ToGamut() => OkLch (%v, %v, %v), want lightness %v and hue %v", i, c, l2, c2, h2, l1, h1)
+		}
+		if _, c3, _ := c.ClampedOkLch().OkLch(); c2 < c3-1e-3 {
+			t.Errorf("%v. %v.ToGamut() => chroma %v, less than ClampedOkLch's %v", i, c, c2, c3)
+		}
+	}
+}
+
+func TestMaxChroma(t *testing.T) {
+	for _, space := range []CylindricalSpace{SpaceHcl, SpaceOkLch} {
+		at := func(h, c, l float64) Color {
+			if space == SpaceHcl {
+				return Hcl(h, c, l)
+			}
+			return OkLch(l, c, h)
+		}
+		for _, h := range []float64{0, 30, 120, 200, 264, 330} {
+			for _, l := range []float64{0.2, 0.5, 0.8} {
+				c := MaxChroma(h, l, space, "")
+				if c <= 0 || !at(h, c, l).Clamped().AlmostEqualRgb(at(h, c, l)) || at(h, c+1e-3, l).IsValid() {
+					t.Errorf("%v. MaxChroma(%v, %v) => %v, which is not the edge of sRGB", space, h, l, c)
+				}
+				if p3 := MaxChroma(h, l, space, "display-p3"); p3 < c {
+					t.Errorf("%v. MaxChroma(%v, %v, display-p3) => %v, less than sRGB's %v", space, h, l, p3, c)
+				}
+			}
+		}
+		if c := MaxChroma(30, 0, space, ""); c != 0 {
+			t.Errorf("%v. MaxChroma at black => %v, want 0", space, c)
+		}
+	}
+}
+
+func TestGamutMappers(t *testing.T) {
+	mappers := []struct {
+		name string
+		m    GamutMapper
+	}{
+		{"ClipGamut", ClipGamut},
+		{"ChromaReductionGamut", ChromaReductionGamut},
+		{"TowardsGrayGamut", TowardsGrayGamut},
+		{"CSSGamut", CSSGamut},
+		{"SoftCompressionGamut", SoftCompressionGamut{}},
+	}
+	outside := []Color{OkLch(0.5, 0.4, 30), OkLch(0.95, 0.3, 264), Hcl(140, 1.2, 0.8), {1.5, 0.2, -0.3}}
+	for _, tt := range mappers {
+		for _, c := range outside {
+			if got := tt.m.MapToGamut(c); !got.IsValid() {
+				t.Errorf("%v.MapToGamut(%v) => %v, which is invalid", tt.name, c, got)
+			}
+		}
+		if tt.name == "SoftCompressionGamut" {
+			continue
+		}
+		for _, c := range randomColors(10) {
+			if got := tt.m.MapToGamut(c); got != c {
+				t.Errorf("%v.MapToGamut(%v) => %v, want valid colors unchanged", tt.name, c, got)
+			}
+		}
+	}
+
+	// Moving towards mid gray keeps the hue, but not the lightness.
+	c := OkLch(0.95, 0.3, 264)
+	l, _, h := TowardsGrayGamut.MapToGamut(c).OkLch()
+	if math.Abs(h-264) > 1 || l >= 0.95 || l <= 0.5 {
+		t.Errorf("TowardsGrayGamut.MapToGamut(%v) => lightness %v and hue %v", c, l, h)
+	}
+
+	// Soft compression keeps low chroma and separates what clipping merges.
+	soft := SoftCompressionGamut{Threshold: 0.5}
+	gray := OkLch(0.6, 0.01, 30)
+	if got := soft.MapToGamut(gray); !got.AlmostEqualRgb(gray) {
+		t.Errorf("SoftCompressionGamut.MapToGamut(%v) => %v, want it unchanged", gray, got)
+	}
+	_, c1, _ := soft.MapToGamut(OkLch(0.6, 0.3, 30)).OkLch()
+	_, c2, _ := soft.MapToGamut(OkLch(0.6, 0.4, 30)).OkLch()
+	if !(c1 < c2) {
+		t.Errorf("SoftCompressionGamut => chroma %v and %v, want them apart", c1, c2)
+	}
+
+	// The In variants of constructors and blends map their results.
+	if got, want := OkLchIn(0.7, 0.4, 140, CSSGamut), OkLch(0.7, 0.4, 140).ToGamut(); got != want {
+		t.Errorf("OkLchIn(CSSGamut) => %v, want %v", got, want)
+	}
+	if got, want := HclIn(140, 1.2, 0.8, nil), Hcl(140, 1.2, 0.8).Clamped(); got != want {
+		t.Errorf("HclIn(nil) => %v, want %v", got, want)
+	}
+	blue, yellow := Color{0, 0, 1}, Color{1, 1, 0}
+	for _, tt := range mappers {
+		if got, want := blue.BlendHclIn(yellow, 0.5, tt.m), tt.m.MapToGamut(blendHclUnclamped(blue, yellow, 0.5)); got != want {
+			t.Errorf("BlendHclIn(%v) => %v, want %v", tt.name, got, want)
+		}
+		if got := blue.BlendOkLchIn(yellow, 0.5, tt.m); !got.IsValid() {
+			t.Errorf("BlendOkLchIn(%v) => %v, which is invalid", tt.name, got)
+		}
+	}
+	if got, want := blue.BlendHclIn(yellow, 0.5, ClipGamut), blue.BlendHcl(yellow, 0.5); got != want {
+		t.Errorf("BlendHclIn(ClipGamut) => %v, want BlendHcl's %v", got, want)
+	}
+
+	g := NewGradient(OkLch(0.5, 0.1, 30), OkLch(0.7, 0.4, 140))
+	g.Gamut = CSSGamut
+	if got, want := g.At(1), OkLch(0.7, 0.4, 140).ToGamut(); got != want {
+		t.Errorf("Gradient with CSSGamut => %v, want %v", got, want)
+	}
+}
+
+func TestGamutBoundary(t *testing.T) {
+	g := NewGamutBoundary(SRGB, 72, 50)
+
+	// The cusps at the precomputed hues are the most chromatic colors.
+	for _, h := range []float64{0, 90, 145, 265} {
+		bestL, bestC := 0.0, 0.0
+		for l := 0.0; l <= 1; l += 0.001 {
+			if c := MaxChroma(h, l, SpaceOkLch, ""); c > bestC {
+				bestL, bestC = l, c
+			}
+		}
+		if l, c := g.Cusp(h); math.Abs(l-bestL) > 0.002 || c < bestC-1e-6 || c > bestC+1e-3 {
+			t.Errorf("Cusp(%v) => (%v, %v), want (%v, %v)", h, l, c, bestL, bestC)
+		}
+	}
+
+	// Elsewhere they are interpolated, which is close to the primaries.
+	for _, c := range []Color{{1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {0, 1, 1}, {0, 0, 1}, {1, 0, 1}} {
+		l, ch, h := c.OkLch()
+		cl, cc := g.Cusp(h)
+		if math.Abs(cl-l) > 0.03 || math.Abs(cc-ch) > 0.02 {
+			t.Errorf("Cusp(%v) => (%v, %v), want (%v, %v) of %v", h, cl, cc, l, ch, c)
+		}
+	}
+
+	for _, h := range []float64{0, 33, 180, 271.5, -30, 400} {
+		for _, l := range []float64{0.1, 0.35, 0.6, 0.9} {
+			want := MaxChroma(h, l, SpaceOkLch, "")
+			if got := g.MaxChroma(h, l); math.Abs(got-want) > 0.01 {
+				t.Errorf("MaxChroma(%v, %v) => %v, want %v", h, l, got, want)
+			}
+		}
+	}
+	if c := g.MaxChroma(30, 1); c != 0 {
+		t.Errorf("MaxChroma at white => %v, want 0", c)
+	}
+	if !g.Contains(0.5, 0.05, 30) || g.Contains(0.5, 0.3, 30) {
+		t.Errorf("Contains is wrong")
+	}
+
+	p3 := NewGamutBoundary(DisplayP3, 12, 10)
+	if _, c := p3.Cusp(30); c <= 0 {
+		t.Errorf("DisplayP3 cusp chroma => %v", c)
+	}
+}
@@ -0,0 +1,187 @@
+// Gradients between several colors, and lookup tables for applying them to
+// many values quickly, such as when rendering heatmaps.
+
+package colorful
+
+import (
+	"math"
+	"sort"
+)
+
+// A GradientStop places a color at a position of a Gradient.
+type GradientStop struct {
+	Col Color
+	Pos float64
+}
+
+// A Gradient maps positions, usually in [0..1], to colors by blending
+// between the two stops around the position. Positions before the first and
+// after the last stop map to the colors of those stops.
+type Gradient struct {
+	// Stops need to be sorted by position.
+	Stops []GradientStop
+
+	// Blend is the function used to blend between stops, such as
+	// Color.BlendOkLab. nil means Color.BlendHcl.
+	Blend func(c1, c2 Color, t float64) Color
+	// Gamut brings blended colors which are out of the RGB gamut back into
+	// it. nil means ClipGamut.
+	Gamut GamutMapper
+}
+
+// NewGradient creates a gradient with the colors spread evenly over [0..1],
+// blending in HCL space.
+func NewGradient(colors ...Color) Gradient {
+	stops := make([]GradientStop, len(colors))
+	for i, c := range colors {
+		stops[i] = GradientStop{c, 0.0}
+		if len(colors) > 1 {
+			stops[i].Pos = float64(i) / float64(len(colors)-1)
+		}
+	}
+	return Gradient{Stops: stops}
+}
+
+// At returns the color at position t, brought into the RGB gamut by the
+// gradient's GamutMapper. It panics if the gradient has no stops.
+func (g Gradient) At(t float64) Color {
+	if len(g.Stops) == 0 {
+		panic("colorful: gradient has no stops")
+	}
+
+	// The index of the first stop after t.
+	i := sort.Search(len(g.Stops), func(i int) bool {
+		return g.Stops[i].Pos > t
+	})
+	gamut := g.Gamut
+	if gamut == nil {
+		gamut = ClipGamut
+	}
+	if i == 0 {
+		return gamut.MapToGamut(g.Stops[0].Col)
+	}
+	if i == len(g.Stops) {
+		return gamut.MapToGamut(g.Stops[i-1].Col)
+	}
+
+	s1, s2 := g.Stops[i-1], g.Stops[i]
+	blend := g.Blend
+	if blend == nil {
+		blend = Color.BlendHcl
+	}
+	return gamut.MapToGamut(blend(s1.Col, s2.Col, (t-s1.Pos)/(s2.Pos-s1.Pos)))
+}
+
+// A GradientLUT is a gradient sampled at evenly spaced positions, which
+// approximates it by interpolating linearly between the samples in RGB,
+// instead of converting colors to another space and back.
+type GradientLUT struct {
+	// Colors are the samples, the first at position Min and the last at Max.
+	Colors   []Color
+	Min, Max float64
+}
+
+// LUT samples the gradient at n evenly spaced positions from the first to
+// the last stop. n needs to be at least 2, a few hundred samples make the
+// difference invisible for smooth gradients.
+func (g Gradient) LUT(n int) *GradientLUT {
+	if n < 2 {
+		panic("colorful: a gradient LUT needs at least 2 samples")
+	}
+	if len(g.Stops) == 0 {
+		panic("colorful: gradient has no stops")
+	}
+
+	lut := &GradientLUT{
+		Colors: make([]Color, n),
+		Min:    g.Stops[0].Pos,
+		Max:    g.Stops[len(g.Stops)-1].Pos,
+	}
+	for i := range lut.Colors {
+		lut.Colors[i] = g.At(lut.Min + (lut.Max-lut.Min)*float64(i)/float64(n-1))
+	}
+	return lut
+}
+
+// At returns the color at position t, interpolating between the two nearest
+// samples. Positions outside of [Min..Max] map to the first or last sample.
+func (lut *GradientLUT) At(t float64) Color {
+	f := 0.0
+	if lut.Max > lut.Min {
+		f = (t - lut.Min) / (lut.Max - lut.Min) * float64(len(lut.Colors)-1)
+	}
+	if !(f > 0.0) {
+		return lut.Colors[0]
+	}
+	i := int(f)
+	if i >= len(lut.Colors)-1 {
+		return lut.Colors[len(lut.Colors)-1]
+	}
+	return lut.Colors[i].BlendRgb(lut.Colors[i+1], f-float64(i))
+}
+
+// NewDivergingGradient creates a gradient for data from min to max diverging
+// from a midpoint, such as zero, which maps to the color mid, while min maps
+// to low and max to high, blending in OkLab. Both arms keep their ends at
+// the data's range even if it is asymmetric around the midpoint, such as from
+// -2 to 10 around 0. If symmetric is set, the same distance from the midpoint
+// changes the color by the same amount on both arms, so the shorter arm
+// stops short of its end color, which keeps the colors of equally large
+// positive and negative values equally strong. It panics if the midpoint is
+// outside of [min..max].
+func NewDivergingGradient(low, mid, high Color, min, midpoint, max float64, symmetric bool) Gradient {
+	if !(min <= midpoint && midpoint <= max) {
+		panic("colorful: diverging gradient midpoint outside of its range")
+	}
+	if symmetric {
+		lo, hi := midpoint-min, max-midpoint
+		if extent := math.Max(lo, hi); extent > 0 {
+			low = mid.BlendOkLab(low, lo/extent)
+			high = mid.BlendOkLab(high, hi/extent)
+		}
+	}
+	return Gradient{
+		Stops: []GradientStop{{low, min}, {mid, midpoint}, {high, max}},
+		Blend: Color.BlendOkLab,
+	}
+}
+
+// NewRainbowGradient creates a gradient sweeping the OkLch hue from fromHue to
+// toHue, going through the hues in between without wrapping, so 260 to 30
+// goes from blue through green and yellow to red, and -100 to 30 through
+// purple. Unlike the classic rainbow and jet colormaps, whose bright yellow
+// and cyan bands suggest features which aren't in the data, the lightness
+// changes evenly from fromLightness to toLightness, or stays flat if they are
+// equal, and the chroma is the same all along, the largest which fits into
+// the sRGB gamut everywhere.
+func NewRainbowGradient(fromHue, toHue, fromLightness, toLightness float64) Gradient {
+	// A stop at least every 10°, blending between them in OkLch.
+	n := int(math.Ceil(math.Abs(toHue-fromHue)/10)) + 1
+	if n < 2 {
+		n = 2
+	}
+	hl := func(i int) (h, l float64) {
+		t := float64(i) / float64(n-1)
+		h = math.Mod(fromHue+t*(toHue-fromHue), 360)
+		if h < 0 {
+			h += 360
+		}
+		return h, fromLightness + t*(toLightness-fromLightness)
+	}
+
+	// Check the chroma between the stops too.
+	chroma := math.Inf(1)
+	for i := 0; i < 4*(n-1)+1; i++ {
+		t := float64(i) / float64(4*(n-1))
+		h := fromHue + t*(toHue-fromHue)
+		l := fromLightness + t*(toLightness-fromLightness)
+		chroma = math.Min(chroma, maxChroma(h, l, SpaceOkLch, SRGB))
+	}
+
+	stops := make([]GradientStop, n)
+	for i := range stops {
+		h, l := hl(i)
+		stops[i] = GradientStop{OkLch(l, chroma, h), float64(i) / float64(n-1)}
+	}
+	return Gradient{Stops: stops, Blend: Color.BlendOkLch, Gamut: ChromaReductionGamut}
+}
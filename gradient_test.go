@@ -0,0 +1,150 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	red, green, blue := Color{1, 0, 0}, Color{0, 1, 0}, Color{0, 0, 1}
+	g := NewGradient(red, green, blue)
+
+	tests := []struct {
+		t    float64
+		want Color
+	}{
+		{-1, red},
+		{0, red},
+		{0.25, red.BlendHcl(green, 0.5).Clamped()},
+		{0.5, green},
+		{0.75, green.BlendHcl(blue, 0.5).Clamped()},
+		{1, blue},
+		{2, blue},
+	}
+	for i, tt := range tests {
+		if got := g.At(tt.t); !got.AlmostEqualRgb(tt.want) {
+			t.Errorf("%v. Gradient.At(%v) => (%v), want %v", i, tt.t, got, tt.want)
+		}
+	}
+
+	g = Gradient{
+		Stops: []GradientStop{{red, 0.2}, {blue, 0.4}},
+		Blend: Color.BlendRgb,
+	}
+	if got, want := g.At(0.3), (Color{0.5, 0, 0.5}); !got.AlmostEqualRgb(want) {
+		t.Errorf("Gradient.At(0.3) => (%v), want %v", got, want)
+	}
+	if got := NewGradient(green).At(0.7); got != green {
+		t.Errorf("NewGradient(%v).At(0.7) => (%v), want %v", green, got, green)
+	}
+}
+
+func TestGradientLUT(t *testing.T) {
+	g := NewGradient(Color{0.1, 0.1, 0.4}, Color{0.9, 0.2, 0.1}, Color{1, 1, 0.6})
+	g.Blend = Color.BlendOkLab
+	lut := g.LUT(256)
+
+	for i := 0; i <= 1000; i++ {
+		tt := float64(i) / 1000.0
+		if got, want := lut.At(tt), g.At(tt); got.DistanceOkLab(want) > 0.005 {
+			t.Errorf("GradientLUT.At(%v) => (%v), want %v", tt, got, want)
+		}
+	}
+	if got := lut.At(-5); got != lut.Colors[0] {
+		t.Errorf("GradientLUT.At(-5) => (%v), want %v", got, lut.Colors[0])
+	}
+	if got := lut.At(5); got != lut.Colors[255] {
+		t.Errorf("GradientLUT.At(5) => (%v), want %v", got, lut.Colors[255])
+	}
+}
+
+func BenchmarkGradientAt(bench *testing.B) {
+	g := NewGradient(Color{0.1, 0.1, 0.4}, Color{0.9, 0.2, 0.1}, Color{1, 1, 0.6})
+	for n := 0; n < bench.N; n++ {
+		g.At(float64(n%1000) / 1000.0)
+	}
+}
+
+func BenchmarkGradientLUTAt(bench *testing.B) {
+	lut := NewGradient(Color{0.1, 0.1, 0.4}, Color{0.9, 0.2, 0.1}, Color{1, 1, 0.6}).LUT(256)
+	for n := 0; n < bench.N; n++ {
+		lut.At(float64(n%1000) / 1000.0)
+	}
+}
+
+func TestDivergingGradient(t *testing.T) {
+	blue, white, red := MustHex("#2166ac"), Color{1, 1, 1}, MustHex("#b2182b")
+
+	g := NewDivergingGradient(blue, white, red, -2, 0, 10, false)
+	tests := []struct {
+		v    float64
+		want Color
+	}{
+		{-2, blue},
+		{0, white},
+		{10, red},
+		{-1, blue.BlendOkLab(white, 0.5)},
+		{5, white.BlendOkLab(red, 0.5)},
+		{-5, blue},
+		{20, red},
+	}
+	for i, tt := range tests {
+		if got := g.At(tt.v); !got.AlmostEqualRgb(tt.want) {
+			t.Errorf("%v. At(%v) => %v, want %v", i, tt.v, got, tt.want)
+		}
+	}
+
+	// Symmetric: -2 is as far from white as 2.
+	s := NewDivergingGradient(blue, white, red, -2, 0, 10, true)
+	if got, want := s.At(-2), white.BlendOkLab(blue, 0.2); !got.AlmostEqualRgb(want) {
+		t.Errorf("symmetric At(-2) => %v, want %v", got, want)
+	}
+	if got := s.At(10); !got.AlmostEqualRgb(red) {
+		t.Errorf("symmetric At(10) => %v, want %v", got, red)
+	}
+	if d1, d2 := s.At(-2).DistanceOkLab(white), s.At(2).DistanceOkLab(white); d1 > d2*1.5 || d2 > d1*1.5 {
+		t.Errorf("symmetric => -2 and 2 are %v and %v from white", d1, d2)
+	}
+
+	// All data on one side of the midpoint.
+	one := NewDivergingGradient(blue, white, red, 0, 0, 4, true)
+	if got := one.At(0); !got.AlmostEqualRgb(white) {
+		t.Errorf("one-sided At(0) => %v, want white", got)
+	}
+	if got := one.At(4); !got.AlmostEqualRgb(red) {
+		t.Errorf("one-sided At(4) => %v, want %v", got, red)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewDivergingGradient with the midpoint out of range didn't panic")
+		}
+	}()
+	NewDivergingGradient(blue, white, red, 1, 0, 4, false)
+}
+
+func TestRainbowGradient(t *testing.T) {
+	flat := NewRainbowGradient(260, 30, 0.7, 0.7)
+	_, c0, _ := flat.At(0).OkLch()
+	if c0 < 0.05 {
+		t.Errorf("flat rainbow => chroma %v, want a colorful one", c0)
+	}
+	for i := 0; i <= 100; i++ {
+		col := flat.At(float64(i) / 100)
+		l, c, h := col.OkLch()
+		want := 260 - 230*float64(i)/100
+		if !col.IsValid() || math.Abs(l-0.7) > 1e-3 || math.Abs(c-c0) > 2e-3 || hueDiff(h, want) > 0.5 {
+			t.Errorf("flat rainbow At(%v) => %v, OkLch (%v, %v, %v), want (0.7, %v, %v)", float64(i)/100, col, l, c, h, c0, want)
+		}
+	}
+
+	rising := NewRainbowGradient(270, 80, 0.3, 0.9)
+	prev := 0.0
+	for i := 0; i <= 100; i++ {
+		l, _, _ := rising.At(float64(i) / 100).OkLch()
+		if l <= prev {
+			t.Errorf("rising rainbow At(%v) => lightness %v after %v", float64(i)/100, l, prev)
+		}
+		prev = l
+	}
+}
@@ -0,0 +1,109 @@
+// Analyzing the perceptual quality of gradients used as colormaps.
+
+package colorful
+
+import "math"
+
+// A GradientReport describes how well a gradient works as a colormap, as
+// sampled by AnalyzeGradient. Distances are CIEDE2000, as in
+// Color.DistanceCIEDE2000.
+type GradientReport struct {
+	// MinLightness and MaxLightness are the range of the OkLab lightness of
+	// the gradient, in [0..1].
+	MinLightness, MaxLightness float64
+
+	// LightnessMonotonic reports whether the lightness only rises or only
+	// falls from start to end, or stays flat, such that it doesn't suggest
+	// features which aren't in the data. Changes below 0.0001 between
+	// consecutive samples are ignored.
+	LightnessMonotonic bool
+
+	// MinStep, MeanStep and MaxStep are the distances between consecutive
+	// samples.
+	MinStep, MeanStep, MaxStep float64
+
+	// StepVariation is the standard deviation of the distances between
+	// consecutive samples relative to their mean, 0 for perceptually uniform
+	// gradients, on which equal data differences look equally large.
+	StepVariation float64
+
+	// Clipped is the fraction of samples which were out of the RGB gamut
+	// before the gradient's GamutMapper brought them back. For the default
+	// HCL blending this is measured before its clamping, custom Blend
+	// functions which clamp their results themselves hide it.
+	Clipped float64
+
+	// Length is the sum of the distances between consecutive samples, how
+	// many differences the gradient can show.
+	Length float64
+
+	// DeficientLength is the Length with each kind of color vision
+	// deficiency simulated, indexed by CVDKind. Much less than Length means
+	// that many values are hard to tell apart with that deficiency.
+	DeficientLength [3]float64
+}
+
+// AnalyzeGradient samples the gradient at n evenly spaced positions from its
+// first to its last stop and reports on its quality. n needs to be at least
+// 2, a few hundred samples are precise for smooth gradients.
+func AnalyzeGradient(g Gradient, n int) GradientReport {
+	if n < 2 {
+		panic("colorful: analyzing a gradient needs at least 2 samples")
+	}
+	lut := g.LUT(n)
+
+	// The same gradient without gamut mapping, to see what gets clipped.
+	raw := g
+	raw.Gamut = GamutMapperFunc(func(col Color) Color { return col })
+	if raw.Blend == nil {
+		raw.Blend = blendHclUnclamped
+	}
+	first, last := g.Stops[0].Pos, g.Stops[len(g.Stops)-1].Pos
+
+	r := GradientReport{
+		MinLightness:       math.Inf(1),
+		MaxLightness:       math.Inf(-1),
+		LightnessMonotonic: true,
+		MinStep:            math.Inf(1),
+	}
+	const eps = 1e-4
+	rising, falling := false, false
+	steps := make([]float64, 0, n-1)
+	for i, col := range lut.Colors {
+		l, _, _ := col.OkLab()
+		r.MinLightness = math.Min(r.MinLightness, l)
+		r.MaxLightness = math.Max(r.MaxLightness, l)
+		if !raw.At(first + (last-first)*float64(i)/float64(n-1)).IsValid() {
+			r.Clipped++
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := lut.Colors[i-1]
+		pl, _, _ := prev.OkLab()
+		rising = rising || l > pl+eps
+		falling = falling || l < pl-eps
+
+		d := prev.DistanceCIEDE2000(col)
+		steps = append(steps, d)
+		r.Length += d
+		r.MinStep = math.Min(r.MinStep, d)
+		r.MaxStep = math.Max(r.MaxStep, d)
+		for _, kind := range []CVDKind{Protan, Deutan, Tritan} {
+			r.DeficientLength[kind] += prev.SimulateCVD(kind, 1).DistanceCIEDE2000(col.SimulateCVD(kind, 1))
+		}
+	}
+	r.LightnessMonotonic = !(rising && falling)
+	r.Clipped /= float64(n)
+
+	r.MeanStep = r.Length / float64(len(steps))
+	if r.MeanStep > 0 {
+		var variance float64
+		for _, d := range steps {
+			variance += sq(d - r.MeanStep)
+		}
+		r.StepVariation = math.Sqrt(variance/float64(len(steps))) / r.MeanStep
+	}
+	return r
+}
@@ -0,0 +1,54 @@
+package colorful
+
+import "testing"
+
+func TestAnalyzeGradient(t *testing.T) {
+	gray := Gradient{Stops: NewGradient(Color{0, 0, 0}, Color{1, 1, 1}).Stops, Blend: Color.BlendLab}
+	r := AnalyzeGradient(gray, 256)
+	if !r.LightnessMonotonic || r.MinLightness > 1e-6 || r.MaxLightness < 1-1e-6 || r.Clipped != 0 {
+		t.Errorf("AnalyzeGradient(gray) => %+v, want monotonic from 0 to 1 without clipping", r)
+	}
+	// CIEDE2000 weighs lightness differences less away from middle gray.
+	if r.StepVariation > 0.2 {
+		t.Errorf("AnalyzeGradient(gray) => step variation %v, want a uniform gradient", r.StepVariation)
+	}
+	for kind, l := range r.DeficientLength {
+		if l < 0.95*r.Length {
+			t.Errorf("AnalyzeGradient(gray) => %v length %v, want about %v", CVDKind(kind), l, r.Length)
+		}
+	}
+
+	// A jet-like rainbow blended in RGB is neither monotonic nor uniform.
+	jet := Gradient{
+		Stops: NewGradient(Color{0, 0, 0.5}, Color{0, 0, 1}, Color{0, 1, 1}, Color{1, 1, 0}, Color{1, 0, 0}, Color{0.5, 0, 0}).Stops,
+		Blend: Color.BlendRgb,
+	}
+	rj := AnalyzeGradient(jet, 256)
+	if rj.LightnessMonotonic || rj.StepVariation < 2*r.StepVariation {
+		t.Errorf("AnalyzeGradient(jet) => %+v, want non-monotonic and non-uniform", rj)
+	}
+
+	// The rainbow of flat lightness is monotonic.
+	if rf := AnalyzeGradient(NewRainbowGradient(260, 30, 0.7, 0.7), 256); !rf.LightnessMonotonic {
+		t.Errorf("AnalyzeGradient(flat rainbow) => %+v, want monotonic", rf)
+	}
+
+	// Red to green of the same lightness is hard to see for deuteranopes.
+	rg := AnalyzeGradient(Gradient{Stops: NewGradient(Hcl(40, 0.5, 0.55), Hcl(135, 0.5, 0.55)).Stops, Blend: Color.BlendLab}, 128)
+	if rg.DeficientLength[Deutan] > 0.5*rg.Length {
+		t.Errorf("AnalyzeGradient(red-green) => deutan length %v, want much less than %v", rg.DeficientLength[Deutan], rg.Length)
+	}
+
+	// Stops out of gamut are clipped.
+	vivid := AnalyzeGradient(Gradient{Stops: NewGradient(Hcl(140, 1.2, 0.5), Hcl(300, 1.2, 0.5)).Stops, Blend: Color.BlendOkLab}, 64)
+	if vivid.Clipped < 0.5 {
+		t.Errorf("AnalyzeGradient(vivid) => clipped %v, want most samples", vivid.Clipped)
+	}
+
+	// The default HCL blending leaves the gamut between these in-gamut stops,
+	// before it clamps.
+	by := AnalyzeGradient(NewGradient(Color{0, 0, 1}, Color{1, 1, 0}), 64)
+	if by.Clipped == 0 {
+		t.Errorf("AnalyzeGradient(default blend) => clipped %v, want some samples", by.Clipped)
+	}
+}
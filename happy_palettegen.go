@@ -9,17 +9,32 @@ import (
 // If you've got time to spare, use Lab (the non-fast below).
 func FastHappyPalette(colorsCount int) (colors []Color) {
 	colors = make([]Color, colorsCount)
+	FastHappyPaletteInto(colors)
+	return
+}
 
-	for i := 0; i < colorsCount; i++ {
-		colors[i] = Hsv(float64(i)*(360.0/float64(colorsCount)), 0.8+rand.Float64()*0.2, 0.65+rand.Float64()*0.2)
+// FastHappyPaletteInto is like FastHappyPalette, but fills dst instead of
+// allocating a new palette.
+func FastHappyPaletteInto(dst []Color) {
+	for i := range dst {
+		dst[i] = Hsv(float64(i)*(360.0/float64(len(dst))), 0.8+rand.Float64()*0.2, 0.65+rand.Float64()*0.2)
 	}
-	return
 }
 
 func HappyPalette(colorsCount int) ([]Color, error) {
+	return SoftPaletteEx(colorsCount, happyPaletteSettings())
+}
+
+// NewHappyPaletteGenerator creates a generator of the palettes HappyPalette
+// returns, which doesn't allocate for every palette.
+func NewHappyPaletteGenerator() *SoftPaletteGenerator {
+	return NewSoftPaletteGenerator(happyPaletteSettings())
+}
+
+func happyPaletteSettings() SoftPaletteSettings {
 	pimpy := func(l, a, b float64) bool {
 		_, c, _ := LabToHcl(l, a, b)
 		return 0.3 <= c && 0.4 <= l && l <= 0.8
 	}
-	return SoftPaletteEx(colorsCount, SoftPaletteSettings{pimpy, 50, true})
+	return SoftPaletteSettings{pimpy, 50, true}
 }
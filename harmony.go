@@ -0,0 +1,59 @@
+// Color harmonies, the classic color wheel schemes, on the OkLch hue circle
+// rather than HSV's, such that the colors of a scheme look equally light.
+
+package colorful
+
+// harmony returns the color followed by its rotations by the given degrees.
+func (col Color) harmony(degs ...float64) []Color {
+	colors := make([]Color, 1, len(degs)+1)
+	colors[0] = col
+	for _, deg := range degs {
+		colors = append(colors, col.RotateHue(deg))
+	}
+	return colors
+}
+
+// Complementary returns the color and its complement, see Complement.
+func (col Color) Complementary() []Color {
+	return col.harmony(180)
+}
+
+// SplitComplementary returns the color and the two colors 30° to either side
+// of its complement.
+func (col Color) SplitComplementary() []Color {
+	return col.harmony(150, 210)
+}
+
+// Triadic returns the color and the two colors evenly spaced around the hue
+// circle with it.
+func (col Color) Triadic() []Color {
+	return col.harmony(120, 240)
+}
+
+// Tetradic returns the color, the color 60° further, and the complements of
+// both, which form a rectangle on the hue circle.
+func (col Color) Tetradic() []Color {
+	return col.harmony(60, 180, 240)
+}
+
+// Analogous returns n colors with hues evenly spread over spread degrees
+// centered on the hue of the color, ordered by hue. For odd n, the middle one
+// is the color itself.
+func (col Color) Analogous(n int, spread float64) []Color {
+	if n < 1 {
+		return nil
+	}
+	if n == 1 {
+		return []Color{col}
+	}
+	colors := make([]Color, n)
+	for i := range colors {
+		deg := spread * (float64(i)/float64(n-1) - 0.5)
+		if deg == 0 {
+			colors[i] = col
+		} else {
+			colors[i] = col.RotateHue(deg)
+		}
+	}
+	return colors
+}
@@ -0,0 +1,43 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHarmonies(t *testing.T) {
+	col := OkLch(0.6, 0.08, 40)
+	tests := []struct {
+		name   string
+		colors []Color
+		degs   []float64
+	}{
+		{"Complementary", col.Complementary(), []float64{0, 180}},
+		{"SplitComplementary", col.SplitComplementary(), []float64{0, 150, 210}},
+		{"Triadic", col.Triadic(), []float64{0, 120, 240}},
+		{"Tetradic", col.Tetradic(), []float64{0, 60, 180, 240}},
+		{"Analogous", col.Analogous(5, 60), []float64{-30, -15, 0, 15, 30}},
+	}
+	for _, tt := range tests {
+		if len(tt.colors) != len(tt.degs) {
+			t.Errorf("%v() => %v colors, want %v", tt.name, len(tt.colors), len(tt.degs))
+			continue
+		}
+		for i, c := range tt.colors {
+			if !c.IsValid() {
+				t.Errorf("%v()[%v] => %v, which is invalid", tt.name, i, c)
+			}
+			l, _, h := c.OkLch()
+			if hueDiff(h, 40+tt.degs[i]) > 0.01 || math.Abs(l-0.6) > 1e-6 {
+				t.Errorf("%v()[%v] => OkLch lightness %v hue %v, want 0.6 and %v", tt.name, i, l, h, 40+tt.degs[i])
+			}
+		}
+	}
+
+	if got := col.Analogous(1, 60); len(got) != 1 || got[0] != col {
+		t.Errorf("Analogous(1, 60) => %v, want [%v]", got, col)
+	}
+	if got := col.Analogous(0, 60); got != nil {
+		t.Errorf("Analogous(0, 60) => %v, want nil", got)
+	}
+}
@@ -0,0 +1,143 @@
+// Histograms of the colors of images in a perceptual space.
+
+package colorful
+
+import (
+	"image"
+	"math"
+)
+
+// The chroma range of the histogram bins, which covers all of sRGB.
+const histogramMaxChroma = 0.4
+
+// An OkLchHistogram counts colors in bins of OkLch lightness, chroma and hue,
+// such as the pixels of an image. The chroma range [0..0.4] covers all sRGB
+// colors, larger chroma counts in the last bin. Achromatic colors count
+// towards the hue bin of 0°, so it is best to ignore the hue of the lowest
+// chroma bin.
+type OkLchHistogram struct {
+	LightnessBins, ChromaBins, HueBins int
+
+	counts []float64
+	sums   []vec3[float64] // OkLab, weighted.
+}
+
+// NewOkLchHistogram returns an empty histogram with the given number of bins
+// per dimension, each of which must be at least 1.
+func NewOkLchHistogram(lightnessBins, chromaBins, hueBins int) *OkLchHistogram {
+	if lightnessBins < 1 || chromaBins < 1 || hueBins < 1 {
+		panic("colorful: an OkLchHistogram needs at least one bin per dimension")
+	}
+	n := lightnessBins * chromaBins * hueBins
+	return &OkLchHistogram{
+		LightnessBins: lightnessBins,
+		ChromaBins:    chromaBins,
+		HueBins:       hueBins,
+		counts:        make([]float64, n),
+		sums:          make([]vec3[float64], n),
+	}
+}
+
+// binIndex returns the index of the value v in [0..max] when split into n bins.
+func binIndex(v, max float64, n int) int {
+	i := int(v / max * float64(n))
+	if i < 0 {
+		return 0
+	} else if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// Len returns the number of bins.
+func (h *OkLchHistogram) Len() int {
+	return len(h.counts)
+}
+
+// Bin returns the index of the bin the color counts towards.
+func (h *OkLchHistogram) Bin(c Color) int {
+	l, ch, hue := c.OkLch()
+	return h.binOkLch(l, ch, hue)
+}
+
+func (h *OkLchHistogram) binOkLch(l, c, hue float64) int {
+	li := binIndex(l, 1, h.LightnessBins)
+	ci := binIndex(c, histogramMaxChroma, h.ChromaBins)
+	hi := binIndex(hue, 360, h.HueBins)
+	return (li*h.ChromaBins+ci)*h.HueBins + hi
+}
+
+// BinOkLch returns the ranges of OkLch lightness, chroma and hue the bin covers.
+func (h *OkLchHistogram) BinOkLch(bin int) (l0, l1, c0, c1, h0, h1 float64) {
+	hi := bin % h.HueBins
+	ci := bin / h.HueBins % h.ChromaBins
+	li := bin / h.HueBins / h.ChromaBins
+	dl, dc, dh := 1/float64(h.LightnessBins), histogramMaxChroma/float64(h.ChromaBins), 360/float64(h.HueBins)
+	return float64(li) * dl, float64(li+1) * dl, float64(ci) * dc, float64(ci+1) * dc, float64(hi) * dh, float64(hi+1) * dh
+}
+
+// Add counts the color with the given weight.
+func (h *OkLchHistogram) Add(c Color, weight float64) {
+	L, a, b := c.OkLab()
+	l, ch, hue := OkLabToOkLch(L, a, b)
+	bin := h.binOkLch(l, ch, hue)
+	h.counts[bin] += weight
+	h.sums[bin][0] += weight * L
+	h.sums[bin][1] += weight * a
+	h.sums[bin][2] += weight * b
+}
+
+// AddImage counts every pixel of img, weighted by its alpha.
+func (h *OkLchHistogram) AddImage(img image.Image) {
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c, a := pixelAt(img, x, y); a > 0 {
+				h.Add(c, a)
+			}
+		}
+	}
+}
+
+// Count returns the total weight of the colors in the bin.
+func (h *OkLchHistogram) Count(bin int) float64 {
+	return h.counts[bin]
+}
+
+// Total returns the total weight of all colors.
+func (h *OkLchHistogram) Total() (total float64) {
+	for _, c := range h.counts {
+		total += c
+	}
+	return
+}
+
+// Color returns the representative color of the bin, which is the average
+// of the colors counted in it, or the center of the bin if it is empty. The
+// centers of some bins are outside of the RGB gamut.
+func (h *OkLchHistogram) Color(bin int) Color {
+	if n := h.counts[bin]; n > 0 {
+		s := h.sums[bin]
+		return OkLab(s[0]/n, s[1]/n, s[2]/n)
+	}
+	l0, l1, c0, c1, h0, h1 := h.BinOkLch(bin)
+	return OkLch((l0+l1)/2, (c0+c1)/2, (h0+h1)/2)
+}
+
+// Intersection returns the overlap of the normalized histograms h and other,
+// which must have the same bins, from 0 for no colors in common to 1 for the
+// same distribution. Near-duplicate images have an intersection close to 1.
+func (h *OkLchHistogram) Intersection(other *OkLchHistogram) float64 {
+	if len(h.counts) != len(other.counts) || h.HueBins != other.HueBins || h.ChromaBins != other.ChromaBins {
+		panic("colorful: intersecting OkLchHistograms of different bins")
+	}
+	t1, t2 := h.Total(), other.Total()
+	if t1 == 0 || t2 == 0 {
+		return 0
+	}
+	var sum float64
+	for i := range h.counts {
+		sum += math.Min(h.counts[i]/t1, other.counts[i]/t2)
+	}
+	return sum
+}
@@ -0,0 +1,54 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestOkLchHistogram(t *testing.T) {
+	h := NewOkLchHistogram(4, 4, 12)
+	if h.Len() != 4*4*12 {
+		t.Errorf("Len() => %v, want %v", h.Len(), 4*4*12)
+	}
+
+	for bin := 0; bin < h.Len(); bin++ {
+		if got := h.Bin(h.Color(bin)); got != bin && h.Color(bin).IsValid() {
+			t.Errorf("Bin(Color(%v)) => %v", bin, got)
+		}
+	}
+
+	red, blue := Color{0.9, 0.1, 0.1}, Color{0.1, 0.2, 0.8}
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, red)
+	img.Set(2, 0, blue)
+	img.Set(3, 0, color.NRGBA{0, 255, 0, 0})
+	h.AddImage(img)
+
+	if got := h.Total(); !almosteq(got, 3) {
+		t.Errorf("Total() => %v, want 3", got)
+	}
+	r, _ := MakeColor(img.At(0, 0))
+	if got := h.Count(h.Bin(r)); !almosteq(got, 2) {
+		t.Errorf("Count of red's bin => %v, want 2", got)
+	}
+	if got := h.Color(h.Bin(r)); !got.AlmostEqualRgb(r) {
+		t.Errorf("Color of red's bin => %v, want %v", got, r)
+	}
+	l0, l1, c0, c1, h0, h1 := h.BinOkLch(h.Bin(r))
+	if l, c, hue := r.OkLch(); l < l0 || l >= l1 || c < c0 || c >= c1 || hue < h0 || hue >= h1 {
+		t.Errorf("BinOkLch of red's bin => (%v..%v, %v..%v, %v..%v), which doesn't contain (%v, %v, %v)", l0, l1, c0, c1, h0, h1, l, c, hue)
+	}
+
+	other := NewOkLchHistogram(4, 4, 12)
+	other.AddImage(img)
+	if got := h.Intersection(other); !almosteq(got, 1) {
+		t.Errorf("Intersection with itself => %v, want 1", got)
+	}
+	other = NewOkLchHistogram(4, 4, 12)
+	other.Add(Color{1, 1, 0}, 1)
+	if got := h.Intersection(other); !almosteq(got, 0) {
+		t.Errorf("Intersection with other colors => %v, want 0", got)
+	}
+}
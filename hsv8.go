@@ -0,0 +1,116 @@
+// Integer HSV and HSL conversions of 8 bit colors, for hot loops in which
+// both input and output are 8 bit anyway. Hues are in whole degrees.
+
+package colorful
+
+// divRound divides n by the positive d, rounding to nearest.
+func divRound(n, d int) int {
+	if n < 0 {
+		return -((-n + d/2) / d)
+	}
+	return (n + d/2) / d
+}
+
+// hue255 returns the hue in degrees [0..359] of the 8 bit color.
+func hue255(r, g, b, max, delta int) int {
+	if delta == 0 {
+		return 0
+	}
+	var h int
+	switch max {
+	case r:
+		h = divRound(60*(g-b), delta)
+	case g:
+		h = 120 + divRound(60*(b-r), delta)
+	default:
+		h = 240 + divRound(60*(r-g), delta)
+	}
+	return (h + 360) % 360
+}
+
+func minMax255(r, g, b uint8) (min, max int) {
+	min, max = int(r), int(r)
+	for _, v := range [2]int{int(g), int(b)} {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+// fromHueChroma255 returns the 8 bit color of hue h in degrees, chroma c and
+// offset m, both in units of 1/(255*60).
+func fromHueChroma255(h, c, m int) (r, g, b uint8) {
+	h %= 360
+	if h < 0 {
+		h += 360
+	}
+	x := c / 60 * (60 - abs(h%120-60))
+
+	var rr, gg, bb int
+	switch h / 60 {
+	case 0:
+		rr, gg = c, x
+	case 1:
+		rr, gg = x, c
+	case 2:
+		gg, bb = c, x
+	case 3:
+		gg, bb = x, c
+	case 4:
+		rr, bb = x, c
+	default:
+		rr, bb = c, x
+	}
+	const d = 255 * 60
+	return uint8((rr + m + d/2) / d), uint8((gg + m + d/2) / d), uint8((bb + m + d/2) / d)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// RGB255ToHsv converts an 8 bit color to HSV, with the hue in whole degrees
+// [0..359] and saturation and value in [0..255], using integers only.
+func RGB255ToHsv(r, g, b uint8) (h uint16, s, v uint8) {
+	min, max := minMax255(r, g, b)
+	if max > 0 {
+		s = uint8(divRound(255*(max-min), max))
+	}
+	return uint16(hue255(int(r), int(g), int(b), max, max-min)), s, uint8(max)
+}
+
+// HsvToRGB255 is the inverse of RGB255ToHsv. Hues outside of [0..359] wrap
+// around.
+func HsvToRGB255(h uint16, s, v uint8) (r, g, b uint8) {
+	c := int(v) * int(s) * 60
+	return fromHueChroma255(int(h), c, int(v)*255*60-c)
+}
+
+// RGB255ToHsl converts an 8 bit color to HSL, with the hue in whole degrees
+// [0..359] and saturation and luminance in [0..255], using integers only.
+func RGB255ToHsl(r, g, b uint8) (h uint16, s, l uint8) {
+	min, max := minMax255(r, g, b)
+	delta := max - min
+	if delta > 0 {
+		if max+min <= 255 {
+			s = uint8(divRound(255*delta, max+min))
+		} else {
+			s = uint8(divRound(255*delta, 510-max-min))
+		}
+	}
+	return uint16(hue255(int(r), int(g), int(b), max, delta)), s, uint8((max + min + 1) / 2)
+}
+
+// HslToRGB255 is the inverse of RGB255ToHsl. Hues outside of [0..359] wrap
+// around.
+func HslToRGB255(h uint16, s, l uint8) (r, g, b uint8) {
+	c := (255 - abs(2*int(l)-255)) * int(s) * 60
+	return fromHueChroma255(int(h), c, int(l)*255*60-c/2)
+}
@@ -0,0 +1,70 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func hueDiff(h1, h2 float64) float64 {
+	d := math.Abs(h1 - h2)
+	return math.Min(d, 360-d)
+}
+
+func TestRGB255ToHsvHsl(t *testing.T) {
+	for r := 0; r < 256; r += 3 {
+		for g := 0; g < 256; g += 5 {
+			for b := 0; b < 256; b += 7 {
+				c := Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+
+				h, s, v := RGB255ToHsv(uint8(r), uint8(g), uint8(b))
+				fh, fs, fv := c.Hsv()
+				if hueDiff(float64(h), fh) > 0.5+1e-9 && fs > 0 || math.Abs(float64(s)-fs*255) > 0.5+1e-9 || math.Abs(float64(v)-fv*255) > 1e-9 {
+					t.Fatalf("RGB255ToHsv(%v, %v, %v) => (%v, %v, %v), want (%v, %v, %v)", r, g, b, h, s, v, fh, fs*255, fv*255)
+				}
+
+				h, s, l := RGB255ToHsl(uint8(r), uint8(g), uint8(b))
+				fh, fs, fl := c.Hsl()
+				if hueDiff(float64(h), fh) > 0.5+1e-9 && fs > 0 || math.Abs(float64(s)-fs*255) > 0.5+1e-9 || math.Abs(float64(l)-fl*255) > 0.5+1e-9 {
+					t.Fatalf("RGB255ToHsl(%v, %v, %v) => (%v, %v, %v), want (%v, %v, %v)", r, g, b, h, s, l, fh, fs*255, fl*255)
+				}
+			}
+		}
+	}
+}
+
+func TestHsvHslToRGB255(t *testing.T) {
+	for h := 0; h < 720; h += 7 {
+		for s := 0; s < 256; s += 15 {
+			for v := 0; v < 256; v += 15 {
+				r, g, b := HsvToRGB255(uint16(h), uint8(s), uint8(v))
+				wr, wg, wb := Hsv(float64(h%360), float64(s)/255, float64(v)/255).RGB255()
+				if abs(int(r)-int(wr)) > 1 || abs(int(g)-int(wg)) > 1 || abs(int(b)-int(wb)) > 1 {
+					t.Fatalf("HsvToRGB255(%v, %v, %v) => (%v, %v, %v), want (%v, %v, %v)", h, s, v, r, g, b, wr, wg, wb)
+				}
+
+				r, g, b = HslToRGB255(uint16(h), uint8(s), uint8(v))
+				wr, wg, wb = Hsl(float64(h%360), float64(s)/255, float64(v)/255).RGB255()
+				if abs(int(r)-int(wr)) > 1 || abs(int(g)-int(wg)) > 1 || abs(int(b)-int(wb)) > 1 {
+					t.Fatalf("HslToRGB255(%v, %v, %v) => (%v, %v, %v), want (%v, %v, %v)", h, s, v, r, g, b, wr, wg, wb)
+				}
+			}
+		}
+	}
+
+	// Grays and primaries are exact in HSV. In HSL, the lightness of colors
+	// with odd max+min lies between two integers, so they may be off by one.
+	for _, c := range [][3]uint8{{0, 0, 0}, {255, 255, 255}, {128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {0, 0, 255}, {255, 255, 0}} {
+		if r, g, b := HsvToRGB255(RGB255ToHsv(c[0], c[1], c[2])); [3]uint8{r, g, b} != c {
+			t.Errorf("HsvToRGB255(RGB255ToHsv(%v)) => (%v, %v, %v)", c, r, g, b)
+		}
+		if r, g, b := HslToRGB255(RGB255ToHsl(c[0], c[1], c[2])); abs(int(r)-int(c[0])) > 1 || abs(int(g)-int(c[1])) > 1 || abs(int(b)-int(c[2])) > 1 {
+			t.Errorf("HslToRGB255(RGB255ToHsl(%v)) => (%v, %v, %v)", c, r, g, b)
+		}
+	}
+}
+
+func BenchmarkRGB255ToHsv(bench *testing.B) {
+	for n := 0; n < bench.N; n++ {
+		RGB255ToHsv(uint8(n), uint8(n>>8), uint8(n>>16))
+	}
+}
@@ -0,0 +1,261 @@
+// Statistics summarizing the colors of whole images.
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"sync"
+)
+
+// pixelAt returns the color of the pixel at (x, y) and its alpha in [0..1].
+func pixelAt(img image.Image, x, y int) (Color, float64) {
+	var c color.NRGBA
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		c = nrgba.NRGBAAt(x, y)
+	} else {
+		c = color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+	}
+	return Color{float64(c.R) / 255.0, float64(c.G) / 255.0, float64(c.B) / 255.0}, float64(c.A) / 255.0
+}
+
+// AverageColor returns the mean color of img, with every pixel weighted by
+// its alpha. The mean is taken in the given space: in linear RGB it is the
+// color of the image when viewed from afar or blurred, in Lab and OkLab it is
+// the perceptual average. Averaging the sRGB values, as is often done, gives
+// too dark results. An image without any visible pixels averages to black.
+func AverageColor(img image.Image, space PlaneSpace) Color {
+	to, from := space.convs()
+	b := img.Bounds()
+
+	var mu sync.Mutex
+	var sum vec3[float64]
+	var weight float64
+	parallelRows(b, func(y0, y1 int) {
+		var s vec3[float64]
+		var w float64
+		for y := y0; y < y1; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c, a := pixelAt(img, x, y)
+				if a == 0 {
+					continue
+				}
+				v := to(c.Color32().linearRgb())
+				s[0] += a * float64(v[0])
+				s[1] += a * float64(v[1])
+				s[2] += a * float64(v[2])
+				w += a
+			}
+		}
+		mu.Lock()
+		sum[0], sum[1], sum[2] = sum[0]+s[0], sum[1]+s[1], sum[2]+s[2]
+		weight += w
+		mu.Unlock()
+	})
+
+	if weight == 0 {
+		return Color{}
+	}
+	v := from(vec3[float32]{float32(sum[0] / weight), float32(sum[1] / weight), float32(sum[2] / weight)})
+	return LinearRgb(float64(v[0]), float64(v[1]), float64(v[2])).Clamped()
+}
+
+// A colorBin collects the pixels of one cell of the color histogram.
+type colorBin struct {
+	weight float64
+	sum    vec3[float64] // OkLab, weighted.
+}
+
+// DominantColors finds up to k colors which best summarize img, ordered by
+// the share of the image they stand for, most common first. It runs weighted
+// k-means in OkLab space on a histogram of the image, such that it is fast
+// and deterministic. Fewer colors are returned if the image doesn't have k
+// different ones. Pixels are weighted by their alpha.
+func DominantColors(img image.Image, k int) []Color {
+	if k <= 0 {
+		return nil
+	}
+
+	// A histogram with 5 bits per channel keeps the clustering cheap.
+	bins := make([]colorBin, 1<<15)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, a := pixelAt(img, x, y)
+			if a == 0 {
+				continue
+			}
+			r, g, bl := c.RGB255()
+			bin := &bins[int(r>>3)<<10|int(g>>3)<<5|int(bl>>3)]
+			l, aa, bb := c.OkLab()
+			bin.weight += a
+			bin.sum[0] += a * l
+			bin.sum[1] += a * aa
+			bin.sum[2] += a * bb
+		}
+	}
+
+	var points []vec3[float64]
+	var weights []float64
+	for _, bin := range bins {
+		if bin.weight > 0 {
+			points = append(points, vec3[float64]{bin.sum[0] / bin.weight, bin.sum[1] / bin.weight, bin.sum[2] / bin.weight})
+			weights = append(weights, bin.weight)
+		}
+	}
+	if len(points) < k {
+		k = len(points)
+	}
+	if k == 0 {
+		return nil
+	}
+
+	means, shares := weightedKMeans(points, weights, k, 16)
+	order := make([]int, k)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return shares[order[i]] > shares[order[j]]
+	})
+
+	colors := make([]Color, 0, k)
+	for _, i := range order {
+		if shares[i] > 0 {
+			colors = append(colors, OkLab(means[i].split()).Clamped())
+		}
+	}
+	return colors
+}
+
+// DominantColor returns the most common color of img, see DominantColors.
+// Unlike AverageColor it is a color which actually occurs in the image, and
+// not a mixture of all of them.
+func DominantColor(img image.Image) Color {
+	colors := DominantColors(img, 5)
+	if len(colors) == 0 {
+		return Color{}
+	}
+	return colors[0]
+}
+
+// weightedKMeans clusters the points into k clusters and returns their means
+// and total weights. The initial means are picked greedily, the heaviest
+// point first and then each the point contributing the most to the error.
+func weightedKMeans(points []vec3[float64], weights []float64, k, iterations int) ([]vec3[float64], []float64) {
+	means := make([]vec3[float64], 0, k)
+	heaviest := 0
+	for i, w := range weights {
+		if w > weights[heaviest] {
+			heaviest = i
+		}
+	}
+	means = append(means, points[heaviest])
+
+	mindist := make([]float64, len(points))
+	for i, p := range points {
+		mindist[i] = sqdist(p, means[0])
+	}
+	for len(means) < k {
+		best, bestErr := 0, -1.0
+		for i, d := range mindist {
+			if e := d * weights[i]; e > bestErr {
+				best, bestErr = i, e
+			}
+		}
+		means = append(means, points[best])
+		for i, p := range points {
+			if d := sqdist(p, points[best]); d < mindist[i] {
+				mindist[i] = d
+			}
+		}
+	}
+
+	sums := make([]vec3[float64], k)
+	shares := make([]float64, k)
+	for it := 0; it < iterations; it++ {
+		for i := range sums {
+			sums[i], shares[i] = vec3[float64]{}, 0
+		}
+		for i, p := range points {
+			nearest, nearestDist := 0, sqdist(p, means[0])
+			for j := 1; j < k; j++ {
+				if d := sqdist(p, means[j]); d < nearestDist {
+					nearest, nearestDist = j, d
+				}
+			}
+			w := weights[i]
+			sums[nearest][0] += w * p[0]
+			sums[nearest][1] += w * p[1]
+			sums[nearest][2] += w * p[2]
+			shares[nearest] += w
+		}
+		for j := range means {
+			if shares[j] > 0 {
+				means[j] = vec3[float64]{sums[j][0] / shares[j], sums[j][1] / shares[j], sums[j][2] / shares[j]}
+			}
+		}
+	}
+	return means, shares
+}
+
+func sqdist(v, w vec3[float64]) float64 {
+	d0, d1, d2 := v[0]-w[0], v[1]-w[1], v[2]-w[2]
+	return d0*d0 + d1*d1 + d2*d2
+}
+
+// Colorfulness returns the colorfulness metric of Hasler and Süsstrunk, which
+// matches how colorful people rate images: about 0 for grayscale images,
+// around 33 for moderately and above 80 for extremely colorful ones. It is
+// computed on the sRGB values in [0..255], with pixels weighted by alpha.
+// https://infoscience.epfl.ch/record/33994
+func Colorfulness(img image.Image) float64 {
+	var w, rg, yb, rg2, yb2 float64
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, a := pixelAt(img, x, y)
+			if a == 0 {
+				continue
+			}
+			vrg := 255.0 * (c.R - c.G)
+			vyb := 255.0 * (0.5*(c.R+c.G) - c.B)
+			w += a
+			rg += a * vrg
+			yb += a * vyb
+			rg2 += a * vrg * vrg
+			yb2 += a * vyb * vyb
+		}
+	}
+	if w == 0 {
+		return 0
+	}
+
+	mrg, myb := rg/w, yb/w
+	vrg, vyb := math.Max(0, rg2/w-mrg*mrg), math.Max(0, yb2/w-myb*myb)
+	return math.Sqrt(vrg+vyb) + 0.3*math.Sqrt(mrg*mrg+myb*myb)
+}
+
+// MeanChroma returns the average OkLch chroma of the pixels of img, weighted
+// by alpha, which is 0 for grayscale images and rarely above 0.2.
+func MeanChroma(img image.Image) float64 {
+	var w, sum float64
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, a := pixelAt(img, x, y)
+			if a == 0 {
+				continue
+			}
+			_, ch, _ := c.OkLch()
+			w += a
+			sum += a * ch
+		}
+	}
+	if w == 0 {
+		return 0
+	}
+	return sum / w
+}
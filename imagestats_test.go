@@ -0,0 +1,110 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAverageColor(t *testing.T) {
+	// Half black and half white.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.NRGBA{0, 0, 0, 255})
+		img.Set(x, 1, color.NRGBA{255, 255, 255, 255})
+	}
+
+	if got, want := AverageColor(img, PlanesLinearRgb), LinearRgb(0.5, 0.5, 0.5); !got.AlmostEqualRgb(want) {
+		t.Errorf("AverageColor(PlanesLinearRgb) => %v, want %v", got, want)
+	}
+	if got, want := AverageColor(img, PlanesOkLab), OkLab(0.5, 0, 0); got.DistanceRgb(want) > 1e-3 {
+		t.Errorf("AverageColor(PlanesOkLab) => %v, want %v", got, want)
+	}
+	if got, want := AverageColor(genericImage{img}, PlanesLab), Lab(0.5, 0, 0); got.DistanceRgb(want) > 1e-3 {
+		t.Errorf("AverageColor(PlanesLab) => %v, want %v", got, want)
+	}
+
+	// Transparent pixels don't count.
+	img.Set(0, 1, color.NRGBA{255, 0, 0, 0})
+	img.Set(1, 1, color.NRGBA{255, 0, 0, 0})
+	img.Set(2, 1, color.NRGBA{255, 0, 0, 0})
+	img.Set(3, 1, color.NRGBA{255, 0, 0, 0})
+	if got := AverageColor(img, PlanesLinearRgb); !got.AlmostEqualRgb(Color{0, 0, 0}) {
+		t.Errorf("AverageColor with transparent pixels => %v, want black", got)
+	}
+	if got := AverageColor(image.NewNRGBA(image.Rect(0, 0, 2, 2)), PlanesOkLab); got != (Color{}) {
+		t.Errorf("AverageColor of a transparent image => %v, want black", got)
+	}
+}
+
+func TestDominantColors(t *testing.T) {
+	red, blue, white := color.NRGBA{220, 20, 30, 255}, color.NRGBA{20, 40, 200, 255}, color.NRGBA{250, 250, 250, 255}
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			switch {
+			case y < 6:
+				img.Set(x, y, red)
+			case y < 9:
+				img.Set(x, y, blue)
+			default:
+				img.Set(x, y, white)
+			}
+		}
+	}
+	// Some noise around the red.
+	img.Set(0, 0, color.NRGBA{210, 25, 35, 255})
+	img.Set(1, 0, color.NRGBA{225, 15, 30, 255})
+
+	got := DominantColors(img, 3)
+	if len(got) != 3 {
+		t.Fatalf("DominantColors(3) => %v colors, want 3", len(got))
+	}
+	for i, want := range []color.NRGBA{red, blue, white} {
+		w, _ := MakeColor(want)
+		if got[i].DistanceLab(w) > 0.02 {
+			t.Errorf("DominantColors(3)[%v] => %v, want %v", i, got[i].Hex(), w.Hex())
+		}
+	}
+
+	if got := DominantColors(img, 50); len(got) != 5 {
+		t.Errorf("DominantColors(50) => %v colors, want the 5 the image has", len(got))
+	}
+	if r, _ := MakeColor(red); DominantColor(img).DistanceLab(r) > 0.02 {
+		t.Errorf("DominantColor => %v, want %v", DominantColor(img).Hex(), r.Hex())
+	}
+	if got := DominantColors(image.NewNRGBA(image.Rect(0, 0, 2, 2)), 3); len(got) != 0 {
+		t.Errorf("DominantColors of a transparent image => %v, want none", got)
+	}
+}
+
+func TestColorfulness(t *testing.T) {
+	gray := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	vivid := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	muted := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i, c := range []color.NRGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}, {255, 255, 0, 255}} {
+		for x := 0; x < 4; x++ {
+			gray.Set(x, i, color.NRGBA{uint8(60 * i), uint8(60 * i), uint8(60 * i), 255})
+			vivid.Set(x, i, c)
+			muted.Set(x, i, color.NRGBA{128 + c.R/16, 128 + c.G/16, 128 + c.B/16, 255})
+		}
+	}
+
+	if m := Colorfulness(gray); !almosteq(m, 0) {
+		t.Errorf("Colorfulness(gray) => %v, want 0", m)
+	}
+	if m := MeanChroma(gray); !almosteq(m, 0) {
+		t.Errorf("MeanChroma(gray) => %v, want 0", m)
+	}
+	mv, mm := Colorfulness(vivid), Colorfulness(muted)
+	if mv < 80 || mm > 33 || mm <= 0 {
+		t.Errorf("Colorfulness => %v for vivid and %v for muted colors", mv, mm)
+	}
+	cv, cm := MeanChroma(vivid), MeanChroma(muted)
+	if cv < 0.15 || cm >= cv || cm <= 0 {
+		t.Errorf("MeanChroma => %v for vivid and %v for muted colors", cv, cm)
+	}
+	if m := Colorfulness(image.NewNRGBA(image.Rect(0, 0, 2, 2))); m != 0 {
+		t.Errorf("Colorfulness of a transparent image => %v, want 0", m)
+	}
+}
@@ -0,0 +1,233 @@
+// Coarse color names in the vocabulary of the ISCC–NBS system of color
+// designation, such as "vivid reddish orange" or "dark grayish blue".
+// https://en.wikipedia.org/wiki/ISCC%E2%80%93NBS_system
+
+package colorful
+
+import "math"
+
+// The hue names of ISCC–NBS by the CIE LCh hue angle at which they start,
+// each ending where the next one starts.
+var isccHues = []struct {
+	from float64
+	name string
+	// tint is the adjective used for nearly neutral colors of the hue.
+	tint string
+}{
+	{0, "purplish red", "reddish"},
+	{25, "red", "reddish"},
+	{45, "reddish orange", "reddish"},
+	{58, "orange", "yellowish"},
+	{76, "orange yellow", "yellowish"},
+	{88, "yellow", "yellowish"},
+	{106, "greenish yellow", "olive"},
+	{115, "yellow green", "greenish"},
+	{130, "yellowish green", "greenish"},
+	{145, "green", "greenish"},
+	{170, "bluish green", "greenish"},
+	{195, "greenish blue", "bluish"},
+	{225, "blue", "bluish"},
+	{285, "purplish blue", "bluish"},
+	{310, "violet", "purplish"},
+	{322, "purple", "purplish"},
+	{340, "reddish purple", "purplish"},
+	{355, "purplish red", "reddish"},
+}
+
+// isccNeutrals are the names of nearly neutral colors by tint, from light to
+// dark. Not every tint has all five levels, so some names repeat.
+var isccNeutrals = map[string][5]string{
+	"":          {"white", "light gray", "medium gray", "dark gray", "black"},
+	"reddish":   {"pinkish white", "pinkish gray", "reddish gray", "dark reddish gray", "reddish black"},
+	"yellowish": {"yellowish white", "yellowish gray", "light brownish gray", "brownish gray", "brownish black"},
+	"olive":     {"yellowish white", "light olive gray", "light olive gray", "olive gray", "olive black"},
+	"greenish":  {"greenish white", "light greenish gray", "greenish gray", "dark greenish gray", "greenish black"},
+	"bluish":    {"bluish white", "light bluish gray", "bluish gray", "dark bluish gray", "bluish black"},
+	"purplish":  {"purplish white", "light purplish gray", "purplish gray", "dark purplish gray", "purplish black"},
+}
+
+// isccModifiers lists the modifiers ISCC–NBS actually uses with each hue
+// name, many hues lack the very light or very dark ones.
+var isccModifiers = map[string][]string{
+	"pink":            {"vivid", "strong", "deep", "light", "moderate", "dark", "pale", "grayish"},
+	"red":             {"vivid", "strong", "deep", "very deep", "moderate", "dark", "very dark", "light grayish", "grayish", "dark grayish", "blackish"},
+	"yellowish pink":  {"strong", "deep", "light", "moderate", "dark", "pale", "grayish"},
+	"reddish orange":  {"vivid", "strong", "deep", "moderate", "dark", "grayish"},
+	"reddish brown":   {"strong", "deep", "light", "moderate", "dark", "light grayish", "grayish", "dark grayish"},
+	"orange":          {"vivid", "brilliant", "strong", "deep", "light", "moderate"},
+	"brown":           {"strong", "deep", "light", "moderate", "dark", "light grayish", "grayish", "dark grayish"},
+	"orange yellow":   {"vivid", "brilliant", "strong", "deep", "light", "moderate", "dark", "pale"},
+	"yellowish brown": {"strong", "deep", "light", "moderate", "dark", "light grayish", "grayish", "dark grayish"},
+	"yellow":          {"vivid", "brilliant", "strong", "deep", "light", "moderate", "dark", "pale", "grayish", "dark grayish"},
+	"greenish yellow": {"vivid", "brilliant", "strong", "deep", "light", "moderate", "dark", "pale", "grayish"},
+	"olive":           {"light", "moderate", "dark", "light grayish", "grayish", "dark grayish"},
+	"yellow green":    {"vivid", "brilliant", "strong", "deep", "light", "moderate", "pale", "grayish"},
+	"olive green":     {"strong", "deep", "moderate", "dark", "grayish", "dark grayish"},
+	"yellowish green": {"vivid", "brilliant", "strong", "deep", "very deep", "very light", "light", "moderate", "dark", "very dark"},
+	"green":           {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very dark", "very pale", "pale", "grayish", "dark grayish", "blackish"},
+	"bluish green":    {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very dark"},
+	"greenish blue":   {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very dark"},
+	"blue":            {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very pale", "pale", "grayish", "dark grayish", "blackish"},
+	"purplish blue":   {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very pale", "pale", "grayish"},
+	"violet":          {"vivid", "brilliant", "strong", "deep", "very light", "light", "moderate", "dark", "very pale", "pale", "grayish"},
+	"purple":          {"vivid", "brilliant", "strong", "deep", "very deep", "very light", "light", "moderate", "dark", "very dark", "very pale", "pale", "grayish", "dark grayish", "blackish"},
+	"reddish purple":  {"vivid", "strong", "deep", "very deep", "light", "moderate", "dark", "very dark", "pale", "grayish"},
+	"purplish pink":   {"brilliant", "strong", "deep", "light", "moderate", "dark", "pale", "grayish"},
+	"purplish red":    {"vivid", "strong", "deep", "very deep", "moderate", "dark", "very dark", "light grayish", "grayish", "dark grayish"},
+}
+
+// isccModifierCenters are typical CIE L* and C* of each modifier, on the
+// scale of isccModifier, for finding the closest one a hue actually has.
+var isccModifierCenters = map[string][2]float64{
+	"vivid":         {50, 85},
+	"brilliant":     {82, 57},
+	"strong":        {55, 57},
+	"deep":          {32, 60},
+	"very deep":     {15, 80},
+	"very light":    {88, 35},
+	"light":         {72, 35},
+	"moderate":      {52, 35},
+	"dark":          {32, 35},
+	"very dark":     {18, 35},
+	"very pale":     {88, 20},
+	"pale":          {70, 20},
+	"light grayish": {62, 18},
+	"grayish":       {50, 20},
+	"dark grayish":  {32, 20},
+	"blackish":      {15, 20},
+}
+
+// isccModifier returns the modifier of a chromatic color of CIE L* and C*,
+// both on a scale of 0 to 100, which is one of the modifiers ISCC–NBS uses
+// with the given hue name.
+func isccModifier(name string, l, c float64) string {
+	var modifier string
+	switch {
+	case c >= 70:
+		switch {
+		case l < 20:
+			modifier = "very deep"
+		case l < 30:
+			modifier = "deep"
+		default:
+			modifier = "vivid"
+		}
+	case c >= 45:
+		switch {
+		case l >= 75:
+			modifier = "brilliant"
+		case l < 40:
+			modifier = "deep"
+		default:
+			modifier = "strong"
+		}
+	case c >= 25:
+		switch {
+		case l >= 80:
+			modifier = "very light"
+		case l >= 65:
+			modifier = "light"
+		case l >= 40:
+			modifier = "moderate"
+		case l >= 25:
+			modifier = "dark"
+		default:
+			modifier = "very dark"
+		}
+	default:
+		switch {
+		case l >= 80:
+			modifier = "very pale"
+		case l >= 60:
+			modifier = "pale"
+		case l >= 40:
+			modifier = "grayish"
+		case l >= 25:
+			modifier = "dark grayish"
+		default:
+			modifier = "blackish"
+		}
+	}
+
+	want := isccModifierCenters[modifier]
+	best, bestDist := "", math.Inf(1)
+	for _, m := range isccModifiers[name] {
+		if m == modifier {
+			return m
+		}
+		center := isccModifierCenters[m]
+		if d := sq(center[0]-want[0]) + sq(center[1]-want[1]); d < bestDist {
+			best, bestDist = m, d
+		}
+	}
+	return best
+}
+
+// ISCCNBSName returns a name of the color in the vocabulary of the ISCC–NBS
+// system, a hue name such as "reddish orange", "brown" or "olive green" with
+// a modifier such as "vivid", "light" or "dark grayish", or one of white,
+// gray and black, possibly tinted like "bluish gray". These coarse,
+// standardized names suit alt text and search tags better than the nearest
+// of many fanciful names.
+//
+// The official system delimits the names by blocks of Munsell notation,
+// which isn't bundled with this package. Instead, the boundaries are
+// approximated in CIE LCh, so colors close to a boundary may get the name of
+// a neighboring block. The names themselves are always among the 267 of the
+// system though, since each hue only takes the modifiers it has there.
+func (col Color) ISCCNBSName() string {
+	h, c, l := col.Clamped().Hcl()
+	l, c = 100*l, 100*c
+
+	hue := isccHues[0]
+	for _, e := range isccHues {
+		if h >= e.from {
+			hue = e
+		}
+	}
+
+	// Nearly neutral colors.
+	if c < 15 {
+		tint, white, light := hue.tint, 85.0, 65.0
+		if c < 8 {
+			tint, white, light = "", 90.0, 70.0
+		}
+		level := 4
+		switch {
+		case l >= white:
+			level = 0
+		case l >= light:
+			level = 1
+		case l >= 40:
+			level = 2
+		case l >= 20:
+			level = 3
+		}
+		return isccNeutrals[tint][level]
+	}
+
+	// Light reds are pinks, dark oranges and yellows browns and olives.
+	name := hue.name
+	switch {
+	case (h >= 340 || h < 58) && l >= 60 && c < 55:
+		name = "pink"
+		if h >= 340 {
+			name = "purplish pink"
+		} else if h >= 45 {
+			name = "yellowish pink"
+		}
+	case h >= 25 && h < 88 && l < 55 && c < 65:
+		name = "brown"
+		if h < 45 {
+			name = "reddish brown"
+		} else if h >= 72 {
+			name = "yellowish brown"
+		}
+	case h >= 88 && h < 130 && l < 55 && c < 65:
+		name = "olive"
+		if h >= 106 {
+			name = "olive green"
+		}
+	}
+	return isccModifier(name, l, math.Min(c, 100)) + " " + name
+}
@@ -0,0 +1,50 @@
+package colorful
+
+import "testing"
+
+func TestISCCNBSName(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want string
+	}{
+		{"#ff0000", "vivid red"},
+		{"#ff4500", "vivid reddish orange"},
+		{"#ffa500", "vivid orange"},
+		{"#ffff00", "vivid yellow"},
+		{"#0000ff", "vivid purplish blue"},
+		{"#000080", "deep purplish blue"},
+		{"#4682b4", "moderate blue"},
+		{"#800080", "deep purple"},
+		{"#ffc0cb", "pale pink"},
+		{"#8b4513", "deep brown"},
+		{"#808000", "moderate olive"},
+		{"#2f4f4f", "dark bluish gray"},
+		{"#f5f5dc", "yellowish white"},
+		{"#ffffff", "white"},
+		{"#808080", "medium gray"},
+		{"#000000", "black"},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.hex).ISCCNBSName(); got != tt.want {
+			t.Errorf("%v. %v.ISCCNBSName() => %q, want %q", i, tt.hex, got, tt.want)
+		}
+	}
+
+	// Every color gets one of the names of the system.
+	names := map[string]bool{}
+	for name, modifiers := range isccModifiers {
+		for _, m := range modifiers {
+			names[m+" "+name] = true
+		}
+	}
+	for _, neutrals := range isccNeutrals {
+		for _, name := range neutrals {
+			names[name] = true
+		}
+	}
+	for _, c := range randomColors(1000) {
+		if name := c.ISCCNBSName(); !names[name] {
+			t.Errorf("%v.ISCCNBSName() => %q, which isn't an ISCC–NBS name", c, name)
+		}
+	}
+}
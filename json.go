@@ -0,0 +1,161 @@
+// Configurable JSON representation of colors.
+
+package colorful
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A JSONFormat selects how a Color is represented in JSON.
+// Apart from the constants below, it may be the name of one of the color
+// spaces "linear-rgb", "hsv", "hsl", "xyz", "lab", "luv", "hcl", "oklab" and
+// "oklch", in which case colors are written as objects tagged with their
+// space, as in {"space":"oklch","l":0.628,"c":0.2577,"h":29.23}.
+type JSONFormat string
+
+const (
+	// JSONFields is Go's default encoding of the struct, {"R":1,"G":0.5,"B":0}.
+	JSONFields JSONFormat = ""
+
+	// JSONHex writes the hex representation, as in "#ff8000".
+	JSONHex JSONFormat = "hex"
+
+	// JSONRgb writes the R, G and B values, as in {"r":1,"g":0.5,"b":0}.
+	JSONRgb JSONFormat = "rgb"
+)
+
+// DefaultJSONFormat is the format used by Color's MarshalJSON. It defaults to
+// JSONFields, the encoding Color always had. To use different formats in
+// different places, call MarshalJSONAs instead of changing this.
+var DefaultJSONFormat = JSONFields
+
+// A jsonSpace describes how to write colors as objects in a space.
+type jsonSpace struct {
+	keys [3]string
+	from func(Color) (float64, float64, float64)
+	to   func(float64, float64, float64) Color
+}
+
+var jsonSpaces = map[JSONFormat]jsonSpace{
+	"linear-rgb": {[3]string{"r", "g", "b"}, Color.LinearRgb, LinearRgb},
+	"hsv":        {[3]string{"h", "s", "v"}, Color.Hsv, Hsv},
+	"hsl":        {[3]string{"h", "s", "l"}, Color.Hsl, Hsl},
+	"xyz":        {[3]string{"x", "y", "z"}, Color.Xyz, Xyz},
+	"lab":        {[3]string{"l", "a", "b"}, Color.Lab, Lab},
+	"luv":        {[3]string{"l", "u", "v"}, Color.Luv, Luv},
+	"hcl":        {[3]string{"h", "c", "l"}, Color.Hcl, Hcl},
+	"oklab":      {[3]string{"l", "a", "b"}, Color.OkLab, OkLab},
+	"oklch":      {[3]string{"l", "c", "h"}, Color.OkLch, OkLch},
+}
+
+// MarshalJSON implements json.Marshaler, using DefaultJSONFormat.
+func (col Color) MarshalJSON() ([]byte, error) {
+	return col.MarshalJSONAs(DefaultJSONFormat)
+}
+
+// MarshalJSONAs returns the JSON representation of the color in the given format.
+func (col Color) MarshalJSONAs(format JSONFormat) ([]byte, error) {
+	switch format {
+	case JSONFields:
+		return json.Marshal(struct{ R, G, B float64 }{col.R, col.G, col.B})
+	case JSONHex:
+		return json.Marshal(col.Hex())
+	case JSONRgb:
+		return jsonObject("", [3]string{"r", "g", "b"}, col.R, col.G, col.B)
+	}
+
+	space, ok := jsonSpaces[format]
+	if !ok {
+		return nil, fmt.Errorf("color: unknown JSON format %q", format)
+	}
+	a, b, c := space.from(col)
+	return jsonObject(string(format), space.keys, a, b, c)
+}
+
+// jsonObject writes the values as an object, with keys in the given order.
+func jsonObject(space string, keys [3]string, a, b, c float64) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if space != "" {
+		fmt.Fprintf(&buf, `"space":%q,`, space)
+	}
+	for i, v := range [3]float64{a, b, c} {
+		enc, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q:%s", keys[i], enc)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts all of the formats
+// MarshalJSONAs can write, regardless of DefaultJSONFormat, and in addition
+// any string which ParseCSS understands. Like Go's decoding of the struct,
+// untagged objects may omit channels, which then are 0; objects tagged with a
+// space need all of its keys.
+func (col *Color) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		c, err := ParseCSS(s)
+		if err != nil {
+			return err
+		}
+		*col = c
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("color: cannot unmarshal %s into a Color", data)
+	}
+	lower := make(map[string]json.RawMessage, len(obj))
+	for k, v := range obj {
+		lower[strings.ToLower(k)] = v
+	}
+
+	// Untagged objects are the plain struct fields, which encoding/json itself
+	// would decode leniently, leaving missing channels at 0.
+	space := jsonSpace{[3]string{"r", "g", "b"}, nil, func(r, g, b float64) Color { return Color{r, g, b} }}
+	tagged := false
+	if raw, ok := lower["space"]; ok {
+		tagged = true
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return fmt.Errorf("color: invalid JSON color space %s", raw)
+		}
+		if space, ok = jsonSpaces[JSONFormat(name)]; !ok {
+			return fmt.Errorf("color: unknown JSON color space %q", name)
+		}
+	}
+
+	var v [3]float64
+	for i, key := range space.keys {
+		raw, ok := lower[key]
+		if !ok && !tagged {
+			continue
+		} else if !ok {
+			return fmt.Errorf("color: JSON color %s is missing %q", data, key)
+		}
+		if err := json.Unmarshal(raw, &v[i]); err != nil {
+			return fmt.Errorf("color: JSON color %s has invalid %q", data, key)
+		}
+	}
+	*col = space.to(v[0], v[1], v[2])
+	return nil
+}
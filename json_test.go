@@ -0,0 +1,91 @@
+package colorful
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSONAs(t *testing.T) {
+	col := Color{1, 0.5, 0}
+	tests := []struct {
+		format JSONFormat
+		want   string
+	}{
+		{JSONFields, `{"R":1,"G":0.5,"B":0}`},
+		{JSONHex, `"#ff8000"`},
+		{JSONRgb, `{"r":1,"g":0.5,"b":0}`},
+		{"hsv", `{"space":"hsv","h":30,"s":1,"v":1}`},
+	}
+	for i, tt := range tests {
+		got, err := col.MarshalJSONAs(tt.format)
+		if err != nil {
+			t.Errorf("%v. MarshalJSONAs(%q) => error %v", i, tt.format, err)
+		} else if string(got) != tt.want {
+			t.Errorf("%v. MarshalJSONAs(%q) => (%s), want %s", i, tt.format, got, tt.want)
+		}
+	}
+
+	if _, err := col.MarshalJSONAs("cmyk"); err == nil {
+		t.Errorf("MarshalJSONAs(\"cmyk\") should have failed")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	col := Color{0.2, 0.6, 0.4}
+	formats := []JSONFormat{JSONFields, JSONRgb, "linear-rgb", "hsv", "hsl", "xyz", "lab", "luv", "hcl", "oklab", "oklch"}
+	for i, format := range formats {
+		data, err := col.MarshalJSONAs(format)
+		if err != nil {
+			t.Errorf("%v. MarshalJSONAs(%q) => error %v", i, format, err)
+			continue
+		}
+		var got Color
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("%v. Unmarshal(%s) => error %v", i, data, err)
+		} else if !got.AlmostEqualRgb(col) {
+			t.Errorf("%v. Unmarshal(%s) => (%v), want %v", i, data, got, col)
+		}
+	}
+}
+
+func TestDefaultJSONFormat(t *testing.T) {
+	defer func(f JSONFormat) { DefaultJSONFormat = f }(DefaultJSONFormat)
+
+	v := struct{ C Color }{Color{1, 0, 0}}
+	DefaultJSONFormat = JSONHex
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"C":"#ff0000"}` {
+		t.Errorf("Marshal with JSONHex => (%s, %v), want {\"C\":\"#ff0000\"}", data, err)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Color
+	}{
+		{`"#f00"`, Color{1, 0, 0}},
+		{`"rebeccapurple"`, Color{0.4, 0.2, 0.6}},
+		{`{"R":0,"G":1,"B":0}`, Color{0, 1, 0}},
+		{`{"R":0.5}`, Color{0.5, 0, 0}},
+		{`{"g":1,"b":1}`, Color{0, 1, 1}},
+		{`{}`, Color{0, 0, 0}},
+		{`{"space":"hsl","h":240,"s":1,"l":0.5}`, Color{0, 0, 1}},
+	}
+	for i, tt := range tests {
+		var got Color
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+			t.Errorf("%v. Unmarshal(%s) => error %v", i, tt.json, err)
+		} else if !got.AlmostEqualRgb(tt.want) {
+			t.Errorf("%v. Unmarshal(%s) => (%v), want %v", i, tt.json, got, tt.want)
+		}
+	}
+
+	bad := []string{`"nope"`, `{"space":"hsl","h":240,"s":1}`, `{"space":"rgb"}`, `{"space":"cmyk","c":1,"m":0,"y":0}`, `{"r":"1","g":0,"b":0}`, `[1,0,0]`}
+	for i, s := range bad {
+		var col Color
+		if err := json.Unmarshal([]byte(s), &col); err == nil {
+			t.Errorf("%v. Unmarshal(%s) should have failed", i, s)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Quantized keys for using colors in maps and for deduplication.
+
+package colorful
+
+// Key quantizes each channel of the color to the given number of bits in
+// [1..21] and packs them into a single comparable number, red in the highest
+// bits. Colors are clamped first. Unlike the Color struct itself, the key is
+// mostly robust against the tiny noise conversion round-trips leave behind.
+//
+// Channels are rounded to the nearest of the 2^bits evenly spaced levels
+// from 0 to 1, so every value representable with that many bits maps to its
+// own key. Values exactly halfway between two levels, such as 0.5, may end
+// up on either side after a conversion round-trip.
+func (c Color) Key(bits int) uint64 {
+	if bits < 1 || bits > 21 {
+		panic("colorful: Key needs 1 to 21 bits per channel")
+	}
+	max := uint64(1)<<uint(bits) - 1
+	q := func(v float64) uint64 {
+		return uint64(clamp01(v)*float64(max) + 0.5)
+	}
+	return q(c.R)<<uint(2*bits) | q(c.G)<<uint(bits) | q(c.B)
+}
+
+// Hash returns a key of the color with 16 bits per channel, which is the
+// precision of the Go color.Color interface. Use it as map key or for
+// deduplicating colors, it is the same as Key(16).
+func (c Color) Hash() uint64 {
+	return c.Key(16)
+}
@@ -0,0 +1,76 @@
+package colorful
+
+import (
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	keytests := []struct {
+		c    Color
+		bits int
+		key  uint64
+	}{
+		{Color{0, 0, 0}, 8, 0x000000},
+		{Color{1, 1, 1}, 8, 0xffffff},
+		{Color{1, 0.5, 0}, 8, 0xff8000},
+		{Color{2, -1, 0.5}, 8, 0xff0080},
+		{Color{1, 0, 1}, 1, 0x5},
+		{Color{1, 0, 1}, 16, 0xffff0000ffff},
+		{Color{1, 1, 1}, 21, 1<<63 - 1},
+	}
+
+	for i, tt := range keytests {
+		if key := tt.c.Key(tt.bits); key != tt.key {
+			t.Errorf("%v. %v.Key(%v) => (%x), want %x", i, tt.c, tt.bits, key, tt.key)
+		}
+	}
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	// Values halfway between two levels, such as 0.5, may land on either
+	// side after a round-trip, so allow neighboring levels per channel.
+	near := func(a, b uint64) bool {
+		for s := uint(0); s < 48; s += 16 {
+			x, y := int64(a>>s&0xffff), int64(b>>s&0xffff)
+			if x-y > 1 || y-x > 1 {
+				return false
+			}
+		}
+		return true
+	}
+	for i, tt := range vals {
+		for _, c := range []Color{Lab(tt.c.Lab()), Luv(tt.c.Luv()), OkLab(tt.c.OkLab()), Xyz(tt.c.Xyz())} {
+			if !near(c.Hash(), tt.c.Hash()) {
+				t.Errorf("%v. %v.Hash() => (%x), want %x", i, c, c.Hash(), tt.c.Hash())
+			}
+		}
+	}
+}
+
+func TestKeyPanics(t *testing.T) {
+	for _, bits := range []int{0, 22} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Key(%v) didn't panic", bits)
+				}
+			}()
+			Color{}.Key(bits)
+		}()
+	}
+}
+
+func TestKeyDistinctLevels(t *testing.T) {
+	seen := map[uint64]int{}
+	for i := 0; i < 256; i++ {
+		v := float64(i) / 255.0
+		key := Color{v, v, v}.Key(8)
+		if j, ok := seen[key]; ok {
+			t.Errorf("8-bit levels %v and %v share key %x", j, i, key)
+		}
+		seen[key] = i
+	}
+	if a, b := (Color{65534.0 / 65535.0, 0, 0}).Hash(), (Color{1, 0, 0}).Hash(); a == b {
+		t.Errorf("Hash of 65534/65535 and 1 collide: %x", a)
+	}
+}
@@ -0,0 +1,35 @@
+package colorful
+
+import "testing"
+
+func TestInSpectralLocus(t *testing.T) {
+	tests := []struct {
+		x, y float64
+		want bool
+	}{
+		{0.3127, 0.3290, true},  // D65
+		{0.4476, 0.4074, true},  // Illuminant A
+		{0.64, 0.33, true},      // sRGB red
+		{0.15, 0.06, true},      // sRGB blue
+		{0.30, 0.60, true},      // sRGB green
+		{0.35, 0.15, true},      // Purple
+		{0.7347, 0.2653, false}, // ProPhoto red, on the locus
+		{0.0366, 0.0001, false}, // ProPhoto blue
+		{0.05, 0.05, false},
+		{0.2, 0.9, false},
+		{0.5, 0.1, false},
+		{0.8, 0.2, false},
+		{-0.1, 0.3, false},
+	}
+	for i, tt := range tests {
+		if got := InSpectralLocus(tt.x, tt.y); got != tt.want {
+			t.Errorf("%v. InSpectralLocus(%v, %v) => %v, want %v", i, tt.x, tt.y, got, tt.want)
+		}
+
+		d := -2*tt.x + 12*tt.y + 3
+		u, v := 4*tt.x/d, 9*tt.y/d
+		if got := InSpectralLocusUv(u, v); got != tt.want {
+			t.Errorf("%v. InSpectralLocusUv(%v, %v) => %v, want %v", i, u, v, got, tt.want)
+		}
+	}
+}
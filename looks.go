@@ -0,0 +1,113 @@
+// Looks, sets of grading adjustments such as those emulating film stocks,
+// defined declaratively and built into Pipelines or CLUTs.
+
+package colorful
+
+// A Look describes a color grade by the adjustments it makes, all of which
+// are left out if zero, so the zero Look changes nothing. Build it into a
+// Pipeline to apply it, or into a CLUT to apply it faster.
+type Look struct {
+	Name string
+
+	// Exposure in stops, see Color.AdjustExposure.
+	Exposure float64
+	// Temperature and Tint shift the white balance, see
+	// Color.AdjustTemperature and Color.AdjustTint.
+	Temperature, Tint float64
+	// Contrast steepens the midtones of an S curve for positive values and
+	// flattens them for negative ones, in [-1..1].
+	Contrast float64
+	// Curve is a tone curve applied to all channels after the contrast.
+	Curve []CurvePoint
+	// Saturation changes chroma by the given fraction, see Color.Saturate.
+	Saturation float64
+	// ShadowTint and HighlightTint are OkLab a and b added to the dark and
+	// light colors respectively, for split toning.
+	ShadowTint, HighlightTint [2]float64
+	// Fade lifts black to the given gray and lowers white as much, like an
+	// aged print.
+	Fade float64
+}
+
+// Built-in looks, which are also examples of defining looks.
+var (
+	// FilmPrintLook emulates a neutral film print, with a gentle S curve,
+	// slightly muted colors, teal shadows and warm highlights.
+	FilmPrintLook = Look{
+		Name:          "film print",
+		Contrast:      0.3,
+		Saturation:    -0.1,
+		ShadowTint:    [2]float64{-0.015, -0.01},
+		HighlightTint: [2]float64{0.005, 0.02},
+		Fade:          0.02,
+	}
+
+	// BleachBypassLook emulates skipping the bleaching of color film, which
+	// leaves silver in it: harsh contrast and washed out colors.
+	BleachBypassLook = Look{
+		Name:       "bleach bypass",
+		Contrast:   0.6,
+		Saturation: -0.6,
+	}
+
+	// FadedLook emulates an old, faded photo: lifted blacks, low contrast and
+	// saturation, and a warm cast.
+	FadedLook = Look{
+		Name:        "faded",
+		Temperature: 400,
+		Contrast:    -0.3,
+		Saturation:  -0.35,
+		Fade:        0.1,
+	}
+)
+
+// Pipeline builds the look into a pipeline of the adjustments it makes.
+func (lk Look) Pipeline() *Pipeline {
+	var ts []Transform
+	if lk.Exposure != 0 {
+		ts = append(ts, TransformFunc(func(col Color) Color {
+			return col.AdjustExposure(lk.Exposure)
+		}))
+	}
+	if lk.Temperature != 0 || lk.Tint != 0 {
+		m := temperatureTint(lk.Temperature, lk.Tint)
+		ts = append(ts, TransformFunc(func(col Color) Color {
+			return LinearRgb(m.mul(col.LinearRgb()))
+		}))
+	}
+	if lk.Contrast != 0 {
+		d := 0.1 * lk.Contrast
+		ts = append(ts, NewCurve(CurvePoint{0, 0}, CurvePoint{0.25, 0.25 - d}, CurvePoint{0.75, 0.75 + d}, CurvePoint{1, 1}))
+	}
+	if len(lk.Curve) > 0 {
+		ts = append(ts, NewCurve(lk.Curve...))
+	}
+	if lk.Saturation != 0 {
+		ts = append(ts, TransformFunc(func(col Color) Color {
+			return col.Saturate(lk.Saturation)
+		}))
+	}
+	if lk.ShadowTint != ([2]float64{}) || lk.HighlightTint != ([2]float64{}) {
+		ts = append(ts, TransformFunc(func(col Color) Color {
+			l, a, b := col.OkLab()
+			s, h := sq(1-clamp01(l)), sq(clamp01(l))
+			a += s*lk.ShadowTint[0] + h*lk.HighlightTint[0]
+			b += s*lk.ShadowTint[1] + h*lk.HighlightTint[1]
+			return OkLab(l, a, b).ClampedOkLch()
+		}))
+	}
+	if lk.Fade != 0 {
+		cm := BrightnessMatrix(1 - 2*lk.Fade)
+		for i := 0; i < 3; i++ {
+			cm.M[i][4] = lk.Fade
+		}
+		ts = append(ts, cm)
+	}
+	return NewPipeline(ts...)
+}
+
+// CLUT bakes the look into a CLUT of the given size, see NewCLUT, which is
+// faster to apply to large images than the pipeline.
+func (lk Look) CLUT(size int) *CLUT {
+	return NewCLUT(size, lk.Pipeline().Apply)
+}
@@ -0,0 +1,51 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLooks(t *testing.T) {
+	if n := len((Look{}).Pipeline().Steps()); n != 0 {
+		t.Errorf("zero Look => %v steps, want none", n)
+	}
+
+	for _, lk := range []Look{FilmPrintLook, BleachBypassLook, FadedLook} {
+		p := lk.Pipeline()
+		for _, c := range randomColors(20) {
+			if got := p.Apply(c); !got.Clamped().AlmostEqualRgb(got) {
+				t.Errorf("%v look of %v => %v, which is out of gamut", lk.Name, c, got)
+			}
+		}
+	}
+
+	// Bleach bypass mutes colors and darkens shadows.
+	col := MustHex("#c05040")
+	_, c0, _ := col.OkLch()
+	if _, c, _ := BleachBypassLook.Pipeline().Apply(col).OkLch(); c >= c0*0.6 {
+		t.Errorf("bleach bypass look of %v => chroma %v, want below %v", col, c, c0*0.6)
+	}
+	dark := Color{0.2, 0.2, 0.2}
+	if got := BleachBypassLook.Pipeline().Apply(dark); got.R >= dark.R {
+		t.Errorf("bleach bypass look of %v => %v, want darker", dark, got)
+	}
+
+	// Faded lifts black and lowers white.
+	faded := FadedLook.Pipeline()
+	if black := faded.Apply(Color{0, 0, 0}); black.G < 0.09 {
+		t.Errorf("faded look of black => %v, want lifted to about 0.1", black)
+	}
+	if white := faded.Apply(Color{1, 1, 1}); white.G > 0.91 {
+		t.Errorf("faded look of white => %v, want lowered to about 0.9", white)
+	}
+
+	// Custom curves and the baked CLUT.
+	lk := Look{Curve: []CurvePoint{{0, 0.1}, {1, 0.9}}, Exposure: 0.5}
+	clut := lk.CLUT(33)
+	for _, c := range randomColors(20) {
+		want := lk.Pipeline().Apply(c)
+		if got := clut.Apply(c); math.Abs(got.R-want.R) > 0.01 || math.Abs(got.G-want.G) > 0.01 || math.Abs(got.B-want.B) > 0.01 {
+			t.Errorf("Look.CLUT.Apply(%v) => %v, want %v", c, got, want)
+		}
+	}
+}
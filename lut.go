@@ -0,0 +1,79 @@
+// Lookup tables for the sRGB transfer function of 8 and 16 bit values, which
+// avoid the costly math.Pow of linearize and delinearize.
+
+package colorful
+
+import "sync"
+
+var linearize8 [256]float64
+
+// delinearize8 holds the linear values at which the 8 bit sRGB values change,
+// i.e. the value at index k is the linear value of (k+0.5)/255.
+var delinearize8 [255]float64
+
+func init() {
+	for i := range linearize8 {
+		linearize8[i] = linearize(float64(i) / 255.0)
+	}
+	for i := range delinearize8 {
+		delinearize8[i] = linearize((float64(i) + 0.5) / 255.0)
+	}
+}
+
+// The 16 bit tables take 1 MB, so they are only built once they are needed.
+var (
+	lut16Once     sync.Once
+	linearize16   []float64
+	delinearize16 []float64
+)
+
+func initLut16() {
+	linearize16 = make([]float64, 1<<16)
+	for i := range linearize16 {
+		linearize16[i] = linearize(float64(i) / 65535.0)
+	}
+	delinearize16 = make([]float64, 1<<16-1)
+	for i := range delinearize16 {
+		delinearize16[i] = linearize((float64(i) + 0.5) / 65535.0)
+	}
+}
+
+// searchThresholds returns how many of the ascending thresholds are <= v.
+func searchThresholds(thresholds []float64, v float64) int {
+	lo, hi := 0, len(thresholds)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if thresholds[mid] <= v {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// LinearizeUint8 returns the linear value of the 8 bit sRGB value v, like
+// LinearRgb does for colors, using a lookup table.
+func LinearizeUint8(v uint8) float64 {
+	return linearize8[v]
+}
+
+// DelinearizeUint8 returns the 8 bit sRGB value of the linear value v,
+// clamped to [0..1] and rounded to nearest like RGB255 does, using a lookup
+// table instead of math.Pow.
+func DelinearizeUint8(v float64) uint8 {
+	return uint8(searchThresholds(delinearize8[:], v))
+}
+
+// LinearizeUint16 is like LinearizeUint8 for 16 bit sRGB values. The first
+// call builds the tables of the 16 bit functions.
+func LinearizeUint16(v uint16) float64 {
+	lut16Once.Do(initLut16)
+	return linearize16[v]
+}
+
+// DelinearizeUint16 is like DelinearizeUint8 for 16 bit sRGB values.
+func DelinearizeUint16(v float64) uint16 {
+	lut16Once.Do(initLut16)
+	return uint16(searchThresholds(delinearize16, v))
+}
@@ -0,0 +1,55 @@
+package colorful
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLinearizeLut(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		if v, want := LinearizeUint8(uint8(i)), linearize(float64(i)/255.0); v != want {
+			t.Errorf("LinearizeUint8(%v) => %v, want %v", i, v, want)
+		}
+		if v := DelinearizeUint8(LinearizeUint8(uint8(i))); v != uint8(i) {
+			t.Errorf("DelinearizeUint8(LinearizeUint8(%v)) => %v", i, v)
+		}
+	}
+	for i := 0; i < 1<<16; i += 7 {
+		if v, want := LinearizeUint16(uint16(i)), linearize(float64(i)/65535.0); v != want {
+			t.Errorf("LinearizeUint16(%v) => %v, want %v", i, v, want)
+		}
+		if v := DelinearizeUint16(LinearizeUint16(uint16(i))); v != uint16(i) {
+			t.Errorf("DelinearizeUint16(LinearizeUint16(%v)) => %v", i, v)
+		}
+	}
+
+	rand.Seed(42)
+	for i := 0; i < 10000; i++ {
+		v := rand.Float64()*1.2 - 0.1
+		c := LinearRgb(v, v, v).Clamped()
+		if got, _, _ := c.RGB255(); DelinearizeUint8(v) != got {
+			t.Errorf("DelinearizeUint8(%v) => %v, want %v", v, DelinearizeUint8(v), got)
+		}
+		if got := uint16(c.R*65535.0 + 0.5); DelinearizeUint16(v) != got {
+			t.Errorf("DelinearizeUint16(%v) => %v, want %v", v, DelinearizeUint16(v), got)
+		}
+	}
+}
+
+func BenchmarkLinearize(bench *testing.B) {
+	for n := 0; n < bench.N; n++ {
+		linearize(float64(n&0xff) / 255.0)
+	}
+}
+
+func BenchmarkLinearizeUint8(bench *testing.B) {
+	for n := 0; n < bench.N; n++ {
+		LinearizeUint8(uint8(n))
+	}
+}
+
+func BenchmarkDelinearizeUint8(bench *testing.B) {
+	for n := 0; n < bench.N; n++ {
+		DelinearizeUint8(float64(n&0xff) / 255.0)
+	}
+}
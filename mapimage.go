@@ -0,0 +1,81 @@
+// Applying color transforms to every pixel of an image, using one goroutine
+// per CPU.
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+)
+
+// MapImage returns a copy of img with fn applied to the color of every pixel,
+// keeping the alpha channel. fn is called concurrently from several
+// goroutines, and the colors it returns are clamped to the RGB gamut.
+func MapImage(img image.Image, fn func(Color) Color) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(b)
+	nrgba, isNrgba := img.(*image.NRGBA)
+	parallelRows(b, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				var c color.NRGBA
+				if isNrgba {
+					c = nrgba.NRGBAAt(x, y)
+				} else {
+					c = color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				}
+				r, g, b := fn(Color{float64(c.R) / 255.0, float64(c.G) / 255.0, float64(c.B) / 255.0}).Clamped().RGB255()
+				p := dst.Pix[dst.PixOffset(x, y):]
+				p[0], p[1], p[2], p[3] = r, g, b, c.A
+			}
+		}
+	})
+	return dst
+}
+
+// MapImageRGBA is like MapImage, but modifies img in place instead of
+// allocating a copy. Fully transparent pixels are left alone.
+func MapImageRGBA(img *image.RGBA, fn func(Color) Color) {
+	b := img.Bounds()
+	parallelRows(b, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				p := img.Pix[img.PixOffset(x, y):]
+				a := p[3]
+				if a == 0 {
+					continue
+				}
+				c, _ := MakeColor(color.RGBA{p[0], p[1], p[2], a})
+				c = fn(c).Clamped()
+				af := float64(a)
+				p[0] = uint8(c.R*af + 0.5)
+				p[1] = uint8(c.G*af + 0.5)
+				p[2] = uint8(c.B*af + 0.5)
+			}
+		}
+	})
+}
+
+// MapLightness returns a copy of img with every pixel replaced by the color
+// of the gradient at the pixel's OkLab lightness, in [0..1] from black to
+// white, keeping the alpha channel. This is the duotone effect of design
+// tools, generalized to any gradient.
+func MapLightness(img image.Image, g Gradient) *image.NRGBA {
+	lut := g.LUT(256)
+	return MapImage(img, func(c Color) Color {
+		l, _, _ := c.OkLab()
+		return lut.At(l)
+	})
+}
+
+// Duotone maps img through a gradient blending in OkLab space from the first
+// to the last of the colors, which are usually a dark and a light one, or
+// three colors for a tritone. See MapLightness.
+func Duotone(img image.Image, colors ...Color) *image.NRGBA {
+	if len(colors) < 2 {
+		panic("colorful: Duotone needs at least two colors")
+	}
+	g := NewGradient(colors...)
+	g.Blend = Color.BlendOkLab
+	return MapLightness(img, g)
+}
@@ -0,0 +1,90 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMapImage(t *testing.T) {
+	invert := func(c Color) Color { return Color{1 - c.R, 1 - c.G, 1 - c.B} }
+
+	src := image.NewNRGBA(image.Rect(-2, 1, 31, 20))
+	for i, c := range randomColors(33 * 19) {
+		r, g, b := c.RGB255()
+		src.Pix[4*i], src.Pix[4*i+1], src.Pix[4*i+2], src.Pix[4*i+3] = r, g, b, uint8(i)
+	}
+
+	for _, img := range []image.Image{src, genericImage{src}} {
+		dst := MapImage(img, invert)
+		if dst.Bounds() != src.Bounds() {
+			t.Fatalf("MapImage => bounds %v, want %v", dst.Bounds(), src.Bounds())
+		}
+		for i := 0; i < len(src.Pix); i += 4 {
+			a := src.Pix[i+3]
+			if a == 0 {
+				// The color of transparent pixels is lost by the conversion.
+				continue
+			}
+			want := color.NRGBA{255 - src.Pix[i], 255 - src.Pix[i+1], 255 - src.Pix[i+2], a}
+			if a != 255 {
+				// Other images are converted through premultiplied alpha.
+				if _, ok := img.(*image.NRGBA); !ok {
+					continue
+				}
+			}
+			if got := (color.NRGBA{dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3]}); got != want {
+				t.Fatalf("MapImage at %v => %v, want %v", i/4, got, want)
+			}
+		}
+	}
+}
+
+func TestMapImageRGBA(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{0, 64, 0, 128})
+	img.Set(2, 0, color.RGBA{0, 0, 0, 0})
+
+	MapImageRGBA(img, func(c Color) Color { return Color{c.G, c.R, 1} })
+	for x, want := range []color.RGBA{{0, 255, 255, 255}, {64, 0, 128, 128}, {0, 0, 0, 0}} {
+		if got := img.RGBAAt(x, 0); got != want {
+			t.Errorf("MapImageRGBA at %v => %v, want %v", x, got, want)
+		}
+	}
+}
+
+func BenchmarkMapImage(bench *testing.B) {
+	img := image.NewNRGBA(image.Rect(0, 0, 512, 512))
+	for n := 0; n < bench.N; n++ {
+		MapImage(img, func(c Color) Color { return c.BlendLab(Color{1, 1, 1}, 0.5) })
+	}
+}
+
+func TestDuotone(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, color.NRGBA{0, 0, 0, 255})
+	img.Set(1, 0, color.NRGBA{255, 0, 0, 100})
+	img.Set(2, 0, color.NRGBA{255, 255, 255, 255})
+
+	navy, pink := Color{0.1, 0.1, 0.4}, Color{1, 0.7, 0.8}
+	d := Duotone(img, navy, pink)
+	for x, want := range []Color{navy, {}, pink} {
+		got, _ := MakeColor(d.NRGBAAt(x, 0))
+		if x != 1 && got.DistanceRgb(want) > 1/255.0 {
+			t.Errorf("Duotone at %v => %v, want %v", x, got, want)
+		}
+	}
+	mid, _ := MakeColor(d.NRGBAAt(1, 0))
+	if l, _, _ := mid.OkLab(); l <= 0.2 || l >= 0.85 {
+		t.Errorf("Duotone of red => %v, want between the two colors", mid)
+	}
+	if a := d.NRGBAAt(1, 0).A; a != 100 {
+		t.Errorf("Duotone => alpha %v, want 100", a)
+	}
+
+	tri := Duotone(img, Color{0, 0, 0}, Color{1, 0, 0}, Color{1, 1, 0})
+	if got := tri.NRGBAAt(2, 0); got != (color.NRGBA{255, 255, 0, 255}) {
+		t.Errorf("tritone Duotone of white => %v, want yellow", got)
+	}
+}
@@ -0,0 +1,263 @@
+// Colors and color.Models of the other color spaces, for use with the image
+// packages of the standard library.
+
+package colorful
+
+import (
+	"image/color"
+	"math"
+)
+
+// A LabColor is a color in CIE L*a*b* space (D65), as returned by Color.Lab.
+// It implements the color.Color interface.
+type LabColor struct {
+	L, A, B float64
+}
+
+// A LuvColor is a color in CIE L*u*v* space (D65), as returned by Color.Luv.
+// It implements the color.Color interface.
+type LuvColor struct {
+	L, U, V float64
+}
+
+// An HclColor is a color in the HCL space, i.e. CIE-L*C*h° (D65), as returned
+// by Color.Hcl. It implements the color.Color interface.
+type HclColor struct {
+	H, C, L float64
+}
+
+// An HsvColor is a color in HSV space, as returned by Color.Hsv.
+// It implements the color.Color interface.
+type HsvColor struct {
+	H, S, V float64
+}
+
+// An XyzColor is a color in CIE XYZ space (D65), as returned by Color.Xyz.
+// It implements the color.Color interface.
+type XyzColor struct {
+	X, Y, Z float64
+}
+
+// An OkLabColor is a color in the OkLab space, as returned by Color.OkLab.
+// It implements the color.Color interface.
+type OkLabColor struct {
+	L, A, B float64
+}
+
+// LabColor returns the color in CIE L*a*b* space (D65).
+func (col Color) LabColor() LabColor {
+	l, a, b := col.Lab()
+	return LabColor{l, a, b}
+}
+
+// LuvColor returns the color in CIE L*u*v* space (D65).
+func (col Color) LuvColor() LuvColor {
+	l, u, v := col.Luv()
+	return LuvColor{l, u, v}
+}
+
+// HclColor returns the color in HCL space (D65).
+func (col Color) HclColor() HclColor {
+	h, c, l := col.Hcl()
+	return HclColor{h, c, l}
+}
+
+// HsvColor returns the color in HSV space.
+func (col Color) HsvColor() HsvColor {
+	h, s, v := col.Hsv()
+	return HsvColor{h, s, v}
+}
+
+// XyzColor returns the color in CIE XYZ space (D65).
+func (col Color) XyzColor() XyzColor {
+	x, y, z := col.Xyz()
+	return XyzColor{x, y, z}
+}
+
+// OkLabColor returns the color in OkLab space.
+func (col Color) OkLabColor() OkLabColor {
+	l, a, b := col.OkLab()
+	return OkLabColor{l, a, b}
+}
+
+// ToColor converts to a Color, which may be invalid.
+func (c LabColor) ToColor() Color { return Lab(c.L, c.A, c.B) }
+
+// ToColor converts to a Color, which may be invalid.
+func (c LuvColor) ToColor() Color { return Luv(c.L, c.U, c.V) }
+
+// ToColor converts to a Color, which may be invalid.
+func (c HclColor) ToColor() Color { return Hcl(c.H, c.C, c.L) }
+
+// ToColor converts to a Color.
+func (c HsvColor) ToColor() Color { return Hsv(c.H, c.S, c.V) }
+
+// ToColor converts to a Color, which may be invalid.
+func (c XyzColor) ToColor() Color { return Xyz(c.X, c.Y, c.Z) }
+
+// ToColor converts to a Color, which may be invalid.
+func (c OkLabColor) ToColor() Color { return OkLab(c.L, c.A, c.B) }
+
+// RGBA implements the color.Color interface. Colors outside of the sRGB gamut
+// are clamped.
+func (c LabColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// RGBA implements the color.Color interface. Colors outside of the sRGB gamut
+// are clamped.
+func (c LuvColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// RGBA implements the color.Color interface. Colors outside of the sRGB gamut
+// are clamped.
+func (c HclColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// RGBA implements the color.Color interface.
+func (c HsvColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// RGBA implements the color.Color interface. Colors outside of the sRGB gamut
+// are clamped.
+func (c XyzColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// RGBA implements the color.Color interface. Colors outside of the sRGB gamut
+// are clamped.
+func (c OkLabColor) RGBA() (r, g, b, a uint32) { return c.ToColor().Clamped().RGBA() }
+
+// Distance is the Euclidean distance in Lab space, like Color.DistanceLab.
+func (c1 LabColor) Distance(c2 LabColor) float64 {
+	return math.Sqrt(sq(c1.L-c2.L) + sq(c1.A-c2.A) + sq(c1.B-c2.B))
+}
+
+// Distance is the Euclidean distance in Luv space, like Color.DistanceLuv.
+func (c1 LuvColor) Distance(c2 LuvColor) float64 {
+	return math.Sqrt(sq(c1.L-c2.L) + sq(c1.U-c2.U) + sq(c1.V-c2.V))
+}
+
+// Distance is the Euclidean distance in the Lab space HCL is based on, which
+// is the same as Color.DistanceLab.
+func (c1 HclColor) Distance(c2 HclColor) float64 {
+	l1, a1, b1 := HclToLab(c1.H, c1.C, c1.L)
+	l2, a2, b2 := HclToLab(c2.H, c2.C, c2.L)
+	return LabColor{l1, a1, b1}.Distance(LabColor{l2, a2, b2})
+}
+
+// Distance is the Euclidean distance in XYZ space.
+func (c1 XyzColor) Distance(c2 XyzColor) float64 {
+	return math.Sqrt(sq(c1.X-c2.X) + sq(c1.Y-c2.Y) + sq(c1.Z-c2.Z))
+}
+
+// Distance is the Euclidean distance in OkLab space, like Color.DistanceOkLab.
+func (c1 OkLabColor) Distance(c2 OkLabColor) float64 {
+	return math.Sqrt(sq(c1.L-c2.L) + sq(c1.A-c2.A) + sq(c1.B-c2.B))
+}
+
+// Blend interpolates linearly in Lab space. Unlike Color.BlendLab, the result
+// isn't converted to RGB, so nothing is lost in between.
+// t == 0 results in c1, t == 1 results in c2.
+func (c1 LabColor) Blend(c2 LabColor, t float64) LabColor {
+	return LabColor{c1.L + t*(c2.L-c1.L), c1.A + t*(c2.A-c1.A), c1.B + t*(c2.B-c1.B)}
+}
+
+// Blend interpolates linearly in Luv space, see LabColor.Blend.
+func (c1 LuvColor) Blend(c2 LuvColor, t float64) LuvColor {
+	return LuvColor{c1.L + t*(c2.L-c1.L), c1.U + t*(c2.U-c1.U), c1.V + t*(c2.V-c1.V)}
+}
+
+// Blend interpolates in HCL space along the shorter hue arc, like
+// Color.BlendHcl, but without clamping the result.
+func (c1 HclColor) Blend(c2 HclColor, t float64) HclColor {
+	h1, h2 := c1.H, c2.H
+	if c1.C <= 0.00015 && c2.C >= 0.00015 {
+		h1 = h2
+	} else if c2.C <= 0.00015 && c1.C >= 0.00015 {
+		h2 = h1
+	}
+	return HclColor{interp_angle(h1, h2, t), c1.C + t*(c2.C-c1.C), c1.L + t*(c2.L-c1.L)}
+}
+
+// Blend interpolates in HSV space along the shorter hue arc, like Color.BlendHsv.
+func (c1 HsvColor) Blend(c2 HsvColor, t float64) HsvColor {
+	h1, h2 := c1.H, c2.H
+	if c1.S == 0 && c2.S != 0 {
+		h1 = h2
+	} else if c2.S == 0 && c1.S != 0 {
+		h2 = h1
+	}
+	return HsvColor{interp_angle(h1, h2, t), c1.S + t*(c2.S-c1.S), c1.V + t*(c2.V-c1.V)}
+}
+
+// Blend interpolates linearly in XYZ space, see LabColor.Blend.
+func (c1 XyzColor) Blend(c2 XyzColor, t float64) XyzColor {
+	return XyzColor{c1.X + t*(c2.X-c1.X), c1.Y + t*(c2.Y-c1.Y), c1.Z + t*(c2.Z-c1.Z)}
+}
+
+// Blend interpolates linearly in OkLab space, see LabColor.Blend.
+func (c1 OkLabColor) Blend(c2 OkLabColor, t float64) OkLabColor {
+	return OkLabColor{c1.L + t*(c2.L-c1.L), c1.A + t*(c2.A-c1.A), c1.B + t*(c2.B-c1.B)}
+}
+
+// The color.Models converting to the colors of this package. As with the
+// opaque models of the standard library, like color.GrayModel, translucent
+// colors are composited over black.
+var (
+	ColorModel color.Model = color.ModelFunc(colorModel)
+	LabModel   color.Model = color.ModelFunc(labModel)
+	LuvModel   color.Model = color.ModelFunc(luvModel)
+	HclModel   color.Model = color.ModelFunc(hclModel)
+	HsvModel   color.Model = color.ModelFunc(hsvModel)
+	XyzModel   color.Model = color.ModelFunc(xyzModel)
+	OkLabModel color.Model = color.ModelFunc(okLabModel)
+)
+
+// opaque converts to a Color, compositing translucent colors over black.
+func opaque(c color.Color) Color {
+	if col, ok := c.(Color); ok {
+		return col
+	}
+	r, g, b, _ := c.RGBA()
+	return Color{float64(r) / 65535.0, float64(g) / 65535.0, float64(b) / 65535.0}
+}
+
+func colorModel(c color.Color) color.Color {
+	return opaque(c)
+}
+
+func labModel(c color.Color) color.Color {
+	if _, ok := c.(LabColor); ok {
+		return c
+	}
+	return opaque(c).LabColor()
+}
+
+func luvModel(c color.Color) color.Color {
+	if _, ok := c.(LuvColor); ok {
+		return c
+	}
+	return opaque(c).LuvColor()
+}
+
+func hclModel(c color.Color) color.Color {
+	if _, ok := c.(HclColor); ok {
+		return c
+	}
+	return opaque(c).HclColor()
+}
+
+func hsvModel(c color.Color) color.Color {
+	if _, ok := c.(HsvColor); ok {
+		return c
+	}
+	return opaque(c).HsvColor()
+}
+
+func xyzModel(c color.Color) color.Color {
+	if _, ok := c.(XyzColor); ok {
+		return c
+	}
+	return opaque(c).XyzColor()
+}
+
+func okLabModel(c color.Color) color.Color {
+	if _, ok := c.(OkLabColor); ok {
+		return c
+	}
+	return opaque(c).OkLabColor()
+}
@@ -0,0 +1,104 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestModels(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+	tests := []struct {
+		model color.Model
+		want  color.Color
+	}{
+		{ColorModel, Color{1, 0, 0}},
+		{LabModel, LabColor{0.532408, 0.800925, 0.672032}},
+		{LuvModel, LuvColor{0.532408, 1.75015, 0.377564}},
+		{HclModel, HclColor{40.0, 1.045518, 0.532408}},
+		{HsvModel, HsvColor{0, 1, 1}},
+	}
+	for i, tt := range tests {
+		got := tt.model.Convert(red)
+		if got == nil || !MakeColorA(got).Color().AlmostEqualRgb(Color{1, 0, 0}) {
+			t.Errorf("%v. Convert(red) => %v, want %v", i, got, tt.want)
+			continue
+		}
+		// Compare the values with some tolerance by converting both.
+		if gc, wc := opaque(got), opaque(tt.want); !gc.AlmostEqualRgb(wc) {
+			t.Errorf("%v. Convert(red) => %v, want %v", i, got, tt.want)
+		}
+		if again := tt.model.Convert(got); again != got {
+			t.Errorf("%v. Convert(Convert(red)) => %v, want %v", i, again, got)
+		}
+	}
+
+	if got := HsvModel.Convert(color.NRGBA{255, 255, 255, 128}).(HsvColor); !almosteq(got.V, 128.0/255.0) {
+		t.Errorf("HsvModel.Convert of translucent white => %v, want it composited over black", got)
+	}
+}
+
+func TestModelColorsInImages(t *testing.T) {
+	// An out-of-gamut Lab color is clamped when drawn.
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	draw.Draw(img, img.Bounds(), image.NewUniform(LabColor{0.5, 1.5, 0}), image.Point{}, draw.Src)
+	if got := img.RGBAAt(0, 0); got.A != 255 || got.R != 255 {
+		t.Errorf("drawing LabColor{0.5, 1.5, 0} => %v", got)
+	}
+
+	col := HclColor{120, 0.3, 0.6}
+	draw.Draw(img, img.Bounds(), image.NewUniform(col), image.Point{}, draw.Src)
+	if got := HclModel.Convert(img.At(0, 0)).(HclColor); !got.ToColor().AlmostEqualRgb(col.ToColor()) {
+		t.Errorf("HclColor round trip through an image => %v, want %v", got, col)
+	}
+}
+
+func TestSpaceColors(t *testing.T) {
+	c1, c2 := Color{1, 0.5, 0}, Color{0.2, 0.4, 0.9}
+
+	if d1, d2 := c1.LabColor().Distance(c2.LabColor()), c1.DistanceLab(c2); !almosteq(d1, d2) {
+		t.Errorf("LabColor.Distance => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.HclColor().Distance(c2.HclColor()), c1.DistanceLab(c2); !almosteq(d1, d2) {
+		t.Errorf("HclColor.Distance => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.LuvColor().Distance(c2.LuvColor()), c1.DistanceLuv(c2); !almosteq(d1, d2) {
+		t.Errorf("LuvColor.Distance => %v, want %v", d1, d2)
+	}
+	if d1, d2 := c1.OkLabColor().Distance(c2.OkLabColor()), c1.DistanceOkLab(c2); !almosteq(d1, d2) {
+		t.Errorf("OkLabColor.Distance => %v, want %v", d1, d2)
+	}
+	if d := c1.XyzColor().Distance(c1.XyzColor()); d != 0 {
+		t.Errorf("XyzColor.Distance to itself => %v, want 0", d)
+	}
+
+	blends := []struct {
+		name      string
+		got, want Color
+	}{
+		{"Lab", c1.LabColor().Blend(c2.LabColor(), 0.3).ToColor(), c1.BlendLab(c2, 0.3)},
+		{"Luv", c1.LuvColor().Blend(c2.LuvColor(), 0.3).ToColor(), c1.BlendLuv(c2, 0.3)},
+		{"Hcl", c1.HclColor().Blend(c2.HclColor(), 0.3).ToColor().Clamped(), c1.BlendHcl(c2, 0.3)},
+		{"Hsv", c1.HsvColor().Blend(c2.HsvColor(), 0.3).ToColor(), c1.BlendHsv(c2, 0.3)},
+		{"OkLab", c1.OkLabColor().Blend(c2.OkLabColor(), 0.3).ToColor(), c1.BlendOkLab(c2, 0.3)},
+		{"Xyz", c1.XyzColor().Blend(c2.XyzColor(), 0.5).ToColor(), c1.BlendLinearRgb(c2, 0.5)},
+	}
+	for _, b := range blends {
+		if !b.got.AlmostEqualRgb(b.want) {
+			t.Errorf("%vColor.Blend => %v, want %v", b.name, b.got, b.want)
+		}
+	}
+
+	for i, col := range []color.Color{c1.LabColor(), c1.LuvColor(), c1.HclColor(), c1.HsvColor(), c1.XyzColor(), c1.OkLabColor()} {
+		if got := opaque(col); !got.AlmostEqualRgb(c1) {
+			t.Errorf("%v. %T round trip => %v, want %v", i, col, got, c1)
+		}
+	}
+	if got := XyzModel.Convert(c1).(XyzColor).ToColor(); !got.AlmostEqualRgb(c1) {
+		t.Errorf("XyzModel round trip => %v, want %v", got, c1)
+	}
+	if got := OkLabModel.Convert(c1).(OkLabColor).ToColor(); !got.AlmostEqualRgb(c1) {
+		t.Errorf("OkLabModel round trip => %v, want %v", got, c1)
+	}
+}
@@ -0,0 +1,279 @@
+// The named colors of CSS, which are in turn based on the X11 color names,
+// and the remaining X11 ones.
+
+package colorful
+
+import "strings"
+
+// https://www.w3.org/TR/css-color-4/#named-colors
+// Sorted by name, which conveniently also puts the preferred one of two names
+// for the same color first (aqua/cyan, fuchsia/magenta, gray/grey).
+var namedColors = []struct {
+	name string
+	hex  uint32
+}{
+	{"aliceblue", 0xf0f8ff},
+	{"antiquewhite", 0xfaebd7},
+	{"aqua", 0x00ffff},
+	{"aquamarine", 0x7fffd4},
+	{"azure", 0xf0ffff},
+	{"beige", 0xf5f5dc},
+	{"bisque", 0xffe4c4},
+	{"black", 0x000000},
+	{"blanchedalmond", 0xffebcd},
+	{"blue", 0x0000ff},
+	{"blueviolet", 0x8a2be2},
+	{"brown", 0xa52a2a},
+	{"burlywood", 0xdeb887},
+	{"cadetblue", 0x5f9ea0},
+	{"chartreuse", 0x7fff00},
+	{"chocolate", 0xd2691e},
+	{"coral", 0xff7f50},
+	{"cornflowerblue", 0x6495ed},
+	{"cornsilk", 0xfff8dc},
+	{"crimson", 0xdc143c},
+	{"cyan", 0x00ffff},
+	{"darkblue", 0x00008b},
+	{"darkcyan", 0x008b8b},
+	{"darkgoldenrod", 0xb8860b},
+	{"darkgray", 0xa9a9a9},
+	{"darkgreen", 0x006400},
+	{"darkgrey", 0xa9a9a9},
+	{"darkkhaki", 0xbdb76b},
+	{"darkmagenta", 0x8b008b},
+	{"darkolivegreen", 0x556b2f},
+	{"darkorange", 0xff8c00},
+	{"darkorchid", 0x9932cc},
+	{"darkred", 0x8b0000},
+	{"darksalmon", 0xe9967a},
+	{"darkseagreen", 0x8fbc8f},
+	{"darkslateblue", 0x483d8b},
+	{"darkslategray", 0x2f4f4f},
+	{"darkslategrey", 0x2f4f4f},
+	{"darkturquoise", 0x00ced1},
+	{"darkviolet", 0x9400d3},
+	{"deeppink", 0xff1493},
+	{"deepskyblue", 0x00bfff},
+	{"dimgray", 0x696969},
+	{"dimgrey", 0x696969},
+	{"dodgerblue", 0x1e90ff},
+	{"firebrick", 0xb22222},
+	{"floralwhite", 0xfffaf0},
+	{"forestgreen", 0x228b22},
+	{"fuchsia", 0xff00ff},
+	{"gainsboro", 0xdcdcdc},
+	{"ghostwhite", 0xf8f8ff},
+	{"gold", 0xffd700},
+	{"goldenrod", 0xdaa520},
+	{"gray", 0x808080},
+	{"green", 0x008000},
+	{"greenyellow", 0xadff2f},
+	{"grey", 0x808080},
+	{"honeydew", 0xf0fff0},
+	{"hotpink", 0xff69b4},
+	{"indianred", 0xcd5c5c},
+	{"indigo", 0x4b0082},
+	{"ivory", 0xfffff0},
+	{"khaki", 0xf0e68c},
+	{"lavender", 0xe6e6fa},
+	{"lavenderblush", 0xfff0f5},
+	{"lawngreen", 0x7cfc00},
+	{"lemonchiffon", 0xfffacd},
+	{"lightblue", 0xadd8e6},
+	{"lightcoral", 0xf08080},
+	{"lightcyan", 0xe0ffff},
+	{"lightgoldenrodyellow", 0xfafad2},
+	{"lightgray", 0xd3d3d3},
+	{"lightgreen", 0x90ee90},
+	{"lightgrey", 0xd3d3d3},
+	{"lightpink", 0xffb6c1},
+	{"lightsalmon", 0xffa07a},
+	{"lightseagreen", 0x20b2aa},
+	{"lightskyblue", 0x87cefa},
+	{"lightslategray", 0x778899},
+	{"lightslategrey", 0x778899},
+	{"lightsteelblue", 0xb0c4de},
+	{"lightyellow", 0xffffe0},
+	{"lime", 0x00ff00},
+	{"limegreen", 0x32cd32},
+	{"linen", 0xfaf0e6},
+	{"magenta", 0xff00ff},
+	{"maroon", 0x800000},
+	{"mediumaquamarine", 0x66cdaa},
+	{"mediumblue", 0x0000cd},
+	{"mediumorchid", 0xba55d3},
+	{"mediumpurple", 0x9370db},
+	{"mediumseagreen", 0x3cb371},
+	{"mediumslateblue", 0x7b68ee},
+	{"mediumspringgreen", 0x00fa9a},
+	{"mediumturquoise", 0x48d1cc},
+	{"mediumvioletred", 0xc71585},
+	{"midnightblue", 0x191970},
+	{"mintcream", 0xf5fffa},
+	{"mistyrose", 0xffe4e1},
+	{"moccasin", 0xffe4b5},
+	{"navajowhite", 0xffdead},
+	{"navy", 0x000080},
+	{"oldlace", 0xfdf5e6},
+	{"olive", 0x808000},
+	{"olivedrab", 0x6b8e23},
+	{"orange", 0xffa500},
+	{"orangered", 0xff4500},
+	{"orchid", 0xda70d6},
+	{"palegoldenrod", 0xeee8aa},
+	{"palegreen", 0x98fb98},
+	{"paleturquoise", 0xafeeee},
+	{"palevioletred", 0xdb7093},
+	{"papayawhip", 0xffefd5},
+	{"peachpuff", 0xffdab9},
+	{"peru", 0xcd853f},
+	{"pink", 0xffc0cb},
+	{"plum", 0xdda0dd},
+	{"powderblue", 0xb0e0e6},
+	{"purple", 0x800080},
+	{"rebeccapurple", 0x663399},
+	{"red", 0xff0000},
+	{"rosybrown", 0xbc8f8f},
+	{"royalblue", 0x4169e1},
+	{"saddlebrown", 0x8b4513},
+	{"salmon", 0xfa8072},
+	{"sandybrown", 0xf4a460},
+	{"seagreen", 0x2e8b57},
+	{"seashell", 0xfff5ee},
+	{"sienna", 0xa0522d},
+	{"silver", 0xc0c0c0},
+	{"skyblue", 0x87ceeb},
+	{"slateblue", 0x6a5acd},
+	{"slategray", 0x708090},
+	{"slategrey", 0x708090},
+	{"snow", 0xfffafa},
+	{"springgreen", 0x00ff7f},
+	{"steelblue", 0x4682b4},
+	{"tan", 0xd2b48c},
+	{"teal", 0x008080},
+	{"thistle", 0xd8bfd8},
+	{"tomato", 0xff6347},
+	{"turquoise", 0x40e0d0},
+	{"violet", 0xee82ee},
+	{"wheat", 0xf5deb3},
+	{"white", 0xffffff},
+	{"whitesmoke", 0xf5f5f5},
+	{"yellow", 0xffff00},
+	{"yellowgreen", 0x9acd32},
+}
+
+var (
+	namesToColors    = make(map[string]Color, len(namedColors))
+	colorsToNames    = make(map[Color]string, len(namedColors))
+	x11NamesToColors = make(map[string]Color, len(x11Colors))
+	x11ColorsToNames = make(map[Color]string, len(x11Colors))
+
+	// The swatches NearestName and NearestNameX11 search, the CSS ones
+	// first such that they win ties.
+	cssSwatches = make([]Swatch, 0, len(namedColors))
+	allSwatches = make([]Swatch, 0, len(namedColors)+len(x11Colors))
+)
+
+func hexToColor(hex uint32) Color {
+	return Color{
+		float64(hex>>16&0xff) / 255.0,
+		float64(hex>>8&0xff) / 255.0,
+		float64(hex&0xff) / 255.0,
+	}
+}
+
+func init() {
+	for _, nc := range namedColors {
+		col := hexToColor(nc.hex)
+		namesToColors[nc.name] = col
+		if _, ok := colorsToNames[col]; !ok {
+			colorsToNames[col] = nc.name
+		}
+		cssSwatches = append(cssSwatches, Swatch{Name: nc.name, Color: col})
+	}
+	allSwatches = append(allSwatches, cssSwatches...)
+	for _, nc := range x11Colors {
+		col := hexToColor(nc.hex)
+		x11NamesToColors[nc.name] = col
+		if _, ok := x11ColorsToNames[col]; !ok {
+			x11ColorsToNames[col] = nc.name
+		}
+		allSwatches = append(allSwatches, Swatch{Name: nc.name, Color: col})
+	}
+}
+
+func normalizeColorName(name string) string {
+	return strings.ToLower(strings.Replace(name, " ", "", -1))
+}
+
+// ByName returns the named color of the given name, such as "rebeccapurple",
+// out of the CSS and the X11 colors, the latter including numbered variants
+// like "lightblue3" and gray levels like "gray42". The lookup ignores case and
+// spaces, so X11 spellings like "Dark Slate Gray" work as well. Note that X11
+// and CSS disagree on gray, green, maroon and purple, in which case the CSS
+// color is returned, as browsers do. Use ByNameX11 to get the X11 ones.
+func ByName(name string) (Color, bool) {
+	name = normalizeColorName(name)
+	if col, ok := namesToColors[name]; ok {
+		return col, true
+	}
+	col, ok := x11NamesToColors[name]
+	return col, ok
+}
+
+// ByNameX11 is like ByName, but returns the X11 colors for gray, green,
+// maroon and purple, for example #bebebe for gray instead of CSS's #808080.
+func ByNameX11(name string) (Color, bool) {
+	if hex, ok := x11Overrides[normalizeColorName(name)]; ok {
+		return hexToColor(hex), true
+	}
+	return ByName(name)
+}
+
+// Name returns the name of the color, if there is one which matches it
+// exactly when rounded to 8 bits per channel. CSS names are preferred over
+// X11 ones, and where a color has two names, like gray and grey, the first
+// one in alphabetical order is returned.
+func (col Color) Name() (string, bool) {
+	r, g, b := col.Clamped().RGB255()
+	c := Color{float64(r) / 255.0, float64(g) / 255.0, float64(b) / 255.0}
+	if name, ok := colorsToNames[c]; ok {
+		return name, true
+	}
+	name, ok := x11ColorsToNames[c]
+	return name, ok
+}
+
+// NearestName returns the CSS name of the named color which is closest to col
+// according to the given distance metric, for instance Color.DistanceCIEDE2000,
+// together with that distance. If metric is nil, DistanceCIEDE2000 is used.
+func NearestName(col Color, metric func(c1, c2 Color) float64) (string, float64) {
+	s, d := NearestSwatch(col, cssSwatches, metric)
+	return s.Name, d
+}
+
+// NearestNameX11 is like NearestName, but also considers the X11 names which
+// ByName knows, such as "lightblue3" and "gray42". Of equally close colors,
+// the CSS name is preferred.
+func NearestNameX11(col Color, metric func(c1, c2 Color) float64) (string, float64) {
+	s, d := NearestSwatch(col, allSwatches, metric)
+	return s.Name, d
+}
+
+// NearestSwatch is like NearestName, but searches the given list of named
+// colors instead of the CSS ones. This allows using larger, for example
+// crowd-sourced, sets of color names. Of several equally close swatches, the
+// first one is returned. swatches must not be empty.
+func NearestSwatch(col Color, swatches []Swatch, metric func(c1, c2 Color) float64) (Swatch, float64) {
+	if metric == nil {
+		metric = Color.DistanceCIEDE2000
+	}
+
+	best, bestdist := 0, metric(col, swatches[0].Color)
+	for i := 1; i < len(swatches); i++ {
+		if d := metric(col, swatches[i].Color); d < bestdist {
+			best, bestdist = i, d
+		}
+	}
+	return swatches[best], bestdist
+}
@@ -0,0 +1,144 @@
+package colorful
+
+import "testing"
+
+func TestByName(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		hex  string
+	}{
+		{"rebeccapurple", "#663399"},
+		{"RebeccaPurple", "#663399"},
+		{"Dark Slate Gray", "#2f4f4f"},
+		{"grey", "#808080"},
+		{"green", "#008000"},
+		{"white", "#ffffff"},
+		{"gray50", "#7f7f7f"},
+		{"Light Blue 3", "#9ac0cd"},
+		{"lightgoldenrod", "#eedd82"},
+		{"x11 maroon", "#b03060"},
+	} {
+		col, ok := ByName(tt.name)
+		if !ok || col.Hex() != tt.hex {
+			t.Errorf("ByName(%q) => %v, %v, want %v", tt.name, col.Hex(), ok, tt.hex)
+		}
+	}
+
+	if col, ok := ByName("notacolor"); ok {
+		t.Errorf("ByName(\"notacolor\") => %v, want no color", col)
+	}
+	if len(namesToColors) != 148 {
+		t.Errorf("expected 148 CSS named colors, got %v", len(namesToColors))
+	}
+	if _, err := ParseCSS("gray50"); err == nil {
+		t.Errorf("ParseCSS should reject the X11 name gray50")
+	}
+}
+
+func TestByNameX11(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		hex  string
+	}{
+		{"gray", "#bebebe"},
+		{"Grey", "#bebebe"},
+		{"green", "#00ff00"},
+		{"maroon", "#b03060"},
+		{"purple", "#a020f0"},
+		{"web purple", "#800080"},
+		{"rebeccapurple", "#663399"},
+		{"gray50", "#7f7f7f"},
+	} {
+		col, ok := ByNameX11(tt.name)
+		if !ok || col.Hex() != tt.hex {
+			t.Errorf("ByNameX11(%q) => %v, %v, want %v", tt.name, col.Hex(), ok, tt.hex)
+		}
+	}
+
+	// No X11 name may shadow a CSS one.
+	for _, nc := range x11Colors {
+		if _, ok := namesToColors[nc.name]; ok {
+			t.Errorf("X11 color %q is also a CSS color", nc.name)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	for _, tt := range []struct {
+		c    Color
+		name string
+	}{
+		{Color{0.4, 0.2, 0.6}, "rebeccapurple"},
+		{Color{0, 1, 1}, "aqua"},
+		{Color{1, 0, 1}, "fuchsia"},
+		{Color{128.0 / 255.0, 128.0 / 255.0, 128.0 / 255.0}, "gray"},
+		{Color{0.5, 0.5, 0.5}, "gray"},
+		{Color{1.0001, 1.0, 1.0}, "white"},
+		{MustHex("#9ac0cd"), "lightblue3"},
+	} {
+		if name, ok := tt.c.Name(); !ok || name != tt.name {
+			t.Errorf("%v.Name() => %q, %v, want %q", tt.c, name, ok, tt.name)
+		}
+	}
+
+	if name, ok := (Color{0.1, 0.2, 0.3}).Name(); ok {
+		t.Errorf("Color{0.1, 0.2, 0.3}.Name() => %q, want no name", name)
+	}
+
+	// Every name must round trip, except for the aliases.
+	for _, nc := range namedColors {
+		col, _ := ByName(nc.name)
+		if name, ok := col.Name(); !ok {
+			t.Errorf("%v.Name() for %q failed", col, nc.name)
+		} else if back, _ := ByName(name); back != col {
+			t.Errorf("ByName(%q) => %v, want %v", name, back, col)
+		}
+	}
+}
+
+func TestNearestName(t *testing.T) {
+	for _, tt := range []struct {
+		c    Color
+		name string
+	}{
+		{Color{0.4, 0.2, 0.6}, "rebeccapurple"},
+		{Color{0.41, 0.2, 0.59}, "rebeccapurple"},
+		{Color{0.98, 0.01, 0.02}, "red"},
+		{Color{0.5, 0.5, 0.5}, "gray"},
+		{Color{0.02, 0.02, 0.03}, "black"},
+	} {
+		name, d := NearestName(tt.c, nil)
+		if name != tt.name {
+			t.Errorf("NearestName(%v) => %q, want %q", tt.c, name, tt.name)
+		}
+		if want := tt.c.DistanceCIEDE2000(namesToColors[tt.name]); !almosteq(d, want) {
+			t.Errorf("NearestName(%v) distance => %v, want %v", tt.c, d, want)
+		}
+	}
+
+	if name, d := NearestName(Color{0, 1, 1}, Color.DistanceRgb); name != "aqua" || d != 0 {
+		t.Errorf("NearestName(cyan, DistanceRgb) => %q, %v, want aqua, 0", name, d)
+	}
+
+	// X11 has names for colors between the CSS ones.
+	x11, _ := ByName("lightblue3")
+	if name, d := NearestNameX11(x11, nil); name != "lightblue3" || d != 0 {
+		t.Errorf("NearestNameX11(%v) => %q, %v, want lightblue3, 0", x11, name, d)
+	}
+	if name, _ := NearestName(x11, nil); name == "lightblue3" {
+		t.Errorf("NearestName(%v) => %q, want a CSS name", x11, name)
+	}
+	if name, _ := NearestNameX11(Color{0, 1, 1}, nil); name != "aqua" {
+		t.Errorf("NearestNameX11(cyan) => %q, want the CSS name aqua", name)
+	}
+}
+
+func TestNearestSwatch(t *testing.T) {
+	swatches := []Swatch{{"ink", Color{0.1, 0.1, 0.2}}, {"paper", Color{0.95, 0.95, 0.9}}}
+	if s, _ := NearestSwatch(Color{0.8, 0.8, 0.8}, swatches, Color.DistanceLab); s.Name != "paper" {
+		t.Errorf("NearestSwatch => %q, want paper", s.Name)
+	}
+	if s, _ := NearestSwatch(Color{0, 0, 0}, swatches, nil); s.Name != "ink" {
+		t.Errorf("NearestSwatch => %q, want ink", s.Name)
+	}
+}
@@ -0,0 +1,149 @@
+// Colors packed into integers, as used by platform APIs and embedded
+// displays.
+
+package colorful
+
+func (c ColorA) bytes() (r, g, b, a uint32) {
+	n := c.NRGBA()
+	return uint32(n.R), uint32(n.G), uint32(n.B), uint32(n.A)
+}
+
+func colorABytes(r, g, b, a uint32) ColorA {
+	return ColorA{
+		float64(r&0xff) / 255.0,
+		float64(g&0xff) / 255.0,
+		float64(b&0xff) / 255.0,
+		float64(a&0xff) / 255.0,
+	}
+}
+
+// FromARGB unpacks a color in 0xAARRGGBB order, as used by Android and
+// the .NET Color.FromArgb.
+func FromARGB(v uint32) ColorA {
+	return colorABytes(v>>16, v>>8, v, v>>24)
+}
+
+// ToARGB packs the color with 8 bits per channel in 0xAARRGGBB order.
+func (c ColorA) ToARGB() uint32 {
+	r, g, b, a := c.bytes()
+	return a<<24 | r<<16 | g<<8 | b
+}
+
+// FromRGBA unpacks a color in 0xRRGGBBAA order, as used by numeric CSS
+// colors (#RRGGBBAA) and OpenGL.
+func FromRGBA(v uint32) ColorA {
+	return colorABytes(v>>24, v>>16, v>>8, v)
+}
+
+// ToRGBA packs the color with 8 bits per channel in 0xRRGGBBAA order.
+func (c ColorA) ToRGBA() uint32 {
+	r, g, b, a := c.bytes()
+	return r<<24 | g<<16 | b<<8 | a
+}
+
+// FromABGR unpacks a color in 0xAABBGGRR order, the order of the Win32
+// COLORREF, which leaves the alpha byte zero, and of little-endian RGBA
+// bytes in memory.
+func FromABGR(v uint32) ColorA {
+	return colorABytes(v, v>>8, v>>16, v>>24)
+}
+
+// ToABGR packs the color with 8 bits per channel in 0xAABBGGRR order.
+func (c ColorA) ToABGR() uint32 {
+	r, g, b, a := c.bytes()
+	return a<<24 | b<<16 | g<<8 | r
+}
+
+/// Packed pixel formats ///
+////////////////////////////
+
+// packChannel rounds the value to the nearest of the levels 0..max.
+func packChannel(v float64, max uint32) uint32 {
+	return uint32(clamp01(v)*float64(max) + 0.5)
+}
+
+// ditherChannel chooses between the two levels of 0..max around the value,
+// according to where it lies between them in linear RGB and the threshold in
+// [0..1), like OrderedDither does.
+func ditherChannel(v float64, max uint32, threshold float64) uint32 {
+	v = clamp01(v)
+	lo := uint32(v * float64(max))
+	if lo >= max {
+		return max
+	}
+	l0 := linearize(float64(lo) / float64(max))
+	l1 := linearize(float64(lo+1) / float64(max))
+	if (linearize(v)-l0)/(l1-l0) > threshold {
+		return lo + 1
+	}
+	return lo
+}
+
+func unpackChannel(v, max uint32) float64 {
+	return float64(v&max) / float64(max)
+}
+
+// FromRGB565 unpacks a color with 5 bits of red, 6 of green and 5 of blue, as
+// used by many embedded displays.
+func FromRGB565(v uint16) Color {
+	return Color{unpackChannel(uint32(v)>>11, 31), unpackChannel(uint32(v)>>5, 63), unpackChannel(uint32(v), 31)}
+}
+
+// ToRGB565 packs the color with 5 bits of red, 6 of green and 5 of blue.
+func (col Color) ToRGB565() uint16 {
+	return uint16(packChannel(col.R, 31)<<11 | packChannel(col.G, 63)<<5 | packChannel(col.B, 31))
+}
+
+// ToRGB565Dithered is like ToRGB565, but dithers the color as the pixel at
+// (x, y) using the threshold map of d, which hides the banding of the few
+// levels in gradients. The zero OrderedDither doesn't dither.
+func (col Color) ToRGB565Dithered(d OrderedDither, x, y int) uint16 {
+	if d.Size == 0 {
+		return col.ToRGB565()
+	}
+	t := d.threshold(x, y)
+	return uint16(ditherChannel(col.R, 31, t)<<11 | ditherChannel(col.G, 63, t)<<5 | ditherChannel(col.B, 31, t))
+}
+
+// FromRGBA4444 unpacks a color with 4 bits per channel in 0xRGBA order, as
+// used by GPU textures.
+func FromRGBA4444(v uint16) ColorA {
+	return ColorA{
+		unpackChannel(uint32(v)>>12, 15),
+		unpackChannel(uint32(v)>>8, 15),
+		unpackChannel(uint32(v)>>4, 15),
+		unpackChannel(uint32(v), 15),
+	}
+}
+
+// ToRGBA4444 packs the color with 4 bits per channel in 0xRGBA order.
+func (c ColorA) ToRGBA4444() uint16 {
+	return uint16(packChannel(c.R, 15)<<12 | packChannel(c.G, 15)<<8 | packChannel(c.B, 15)<<4 | packChannel(c.A, 15))
+}
+
+// ToRGBA4444Dithered is like ToRGBA4444, but dithers the color as the pixel
+// at (x, y) using the threshold map of d. Alpha is dithered linearly.
+func (c ColorA) ToRGBA4444Dithered(d OrderedDither, x, y int) uint16 {
+	if d.Size == 0 {
+		return c.ToRGBA4444()
+	}
+	t := d.threshold(x, y)
+	a := uint32(clamp01(c.A)*15.0 + t)
+	if a > 15 {
+		a = 15
+	}
+	return uint16(ditherChannel(c.R, 15, t)<<12 | ditherChannel(c.G, 15, t)<<8 | ditherChannel(c.B, 15, t)<<4 | a)
+}
+
+// FromRGB30 unpacks a color with 10 bits per channel in the lower 30 bits,
+// red highest, as used by deep color displays. The upper two bits, which
+// usually hold alpha or padding, are ignored.
+func FromRGB30(v uint32) Color {
+	return Color{unpackChannel(v>>20, 1023), unpackChannel(v>>10, 1023), unpackChannel(v, 1023)}
+}
+
+// ToRGB30 packs the color with 10 bits per channel into the lower 30 bits,
+// and sets the upper two bits, such that they are opaque if used as alpha.
+func (col Color) ToRGB30() uint32 {
+	return 3<<30 | packChannel(col.R, 1023)<<20 | packChannel(col.G, 1023)<<10 | packChannel(col.B, 1023)
+}
@@ -0,0 +1,142 @@
+package colorful
+
+import (
+	"testing"
+)
+
+func TestPackedUint32(t *testing.T) {
+	tests := []struct {
+		c                ColorA
+		argb, rgba, abgr uint32
+	}{
+		{ColorA{0, 0, 0, 0}, 0x00000000, 0x00000000, 0x00000000},
+		{ColorA{1, 1, 1, 1}, 0xffffffff, 0xffffffff, 0xffffffff},
+		{ColorA{1, 0, 0.5, 1}, 0xffff0080, 0xff0080ff, 0xff8000ff},
+		{ColorA{0.2, 0.4, 0.6, 0.8}, 0xcc336699, 0x336699cc, 0xcc996633},
+		{ColorA{1.5, -1, 0, 2}, 0xffff0000, 0xff0000ff, 0xff0000ff},
+	}
+
+	for i, tt := range tests {
+		if v := tt.c.ToARGB(); v != tt.argb {
+			t.Errorf("%v. %v.ToARGB() => (%08x), want %08x", i, tt.c, v, tt.argb)
+		}
+		if v := tt.c.ToRGBA(); v != tt.rgba {
+			t.Errorf("%v. %v.ToRGBA() => (%08x), want %08x", i, tt.c, v, tt.rgba)
+		}
+		if v := tt.c.ToABGR(); v != tt.abgr {
+			t.Errorf("%v. %v.ToABGR() => (%08x), want %08x", i, tt.c, v, tt.abgr)
+		}
+
+		want := tt.c.Clamped()
+		for _, c := range []ColorA{FromARGB(tt.argb), FromRGBA(tt.rgba), FromABGR(tt.abgr)} {
+			if !c.Color().AlmostEqualRgb(want.Color()) || !almosteq_eps(c.A, want.A, Delta) {
+				t.Errorf("%v. unpacking => %v, want %v", i, c, want)
+			}
+		}
+	}
+}
+
+func TestPackedFormats(t *testing.T) {
+	tests := []struct {
+		c        Color
+		rgb565   uint16
+		rgba4444 uint16
+		rgb30    uint32
+	}{
+		{Color{0, 0, 0}, 0x0000, 0x000f, 0xc0000000},
+		{Color{1, 1, 1}, 0xffff, 0xffff, 0xffffffff},
+		{Color{1, 0, 0}, 0xf800, 0xf00f, 0xfff00000},
+		{Color{0, 1, 0}, 0x07e0, 0x0f0f, 0xc00ffc00},
+		{Color{0, 0, 1}, 0x001f, 0x00ff, 0xc00003ff},
+		{Color{0.5, 0.5, 0.5}, 0x8410, 0x888f, 0xe0080200},
+		{Color{2, -1, 0}, 0xf800, 0xf00f, 0xfff00000},
+	}
+
+	for i, tt := range tests {
+		if v := tt.c.ToRGB565(); v != tt.rgb565 {
+			t.Errorf("%v. %v.ToRGB565() => (%04x), want %04x", i, tt.c, v, tt.rgb565)
+		}
+		if v := tt.c.WithAlpha(1).ToRGBA4444(); v != tt.rgba4444 {
+			t.Errorf("%v. %v.ToRGBA4444() => (%04x), want %04x", i, tt.c, v, tt.rgba4444)
+		}
+		if v := tt.c.ToRGB30(); v != tt.rgb30 {
+			t.Errorf("%v. %v.ToRGB30() => (%08x), want %08x", i, tt.c, v, tt.rgb30)
+		}
+
+		want := tt.c.Clamped()
+		if c := FromRGB565(tt.rgb565); !almosteq_eps(c.R, want.R, 1.0/31) || !almosteq_eps(c.G, want.G, 1.0/63) || !almosteq_eps(c.B, want.B, 1.0/31) {
+			t.Errorf("%v. FromRGB565(%04x) => %v, want %v", i, tt.rgb565, c, want)
+		}
+		if c := FromRGBA4444(tt.rgba4444); !almosteq_eps(c.R, want.R, 1.0/15) || !almosteq_eps(c.B, want.B, 1.0/15) || c.A != 1 {
+			t.Errorf("%v. FromRGBA4444(%04x) => %v, want %v", i, tt.rgba4444, c, want)
+		}
+		if c := FromRGB30(tt.rgb30); !almosteq_eps(c.R, want.R, 1.0/1023) || !almosteq_eps(c.G, want.G, 1.0/1023) || !almosteq_eps(c.B, want.B, 1.0/1023) {
+			t.Errorf("%v. FromRGB30(%08x) => %v, want %v", i, tt.rgb30, c, want)
+		}
+	}
+
+	// Packing is exact for all values of the format.
+	for v := 0; v < 1<<16; v++ {
+		if got := FromRGB565(uint16(v)).ToRGB565(); got != uint16(v) {
+			t.Fatalf("FromRGB565(%04x).ToRGB565() => %04x", v, got)
+		}
+		if got := FromRGBA4444(uint16(v)).ToRGBA4444(); got != uint16(v) {
+			t.Fatalf("FromRGBA4444(%04x).ToRGBA4444() => %04x", v, got)
+		}
+	}
+}
+
+func TestPackedDithered(t *testing.T) {
+	// Over a tile of the threshold map, the dithered pixels average to the
+	// color's light in linear RGB, not to its nearest level.
+	d := Bayer(8)
+	for i, c := range []Color{{0.3, 0.5, 0.7}, {0.01, 0.99, 0.5}, {0, 1, 0.123}} {
+		var sum [3]float64
+		for y := 0; y < d.Size; y++ {
+			for x := 0; x < d.Size; x++ {
+				r, g, b := FromRGB565(c.ToRGB565Dithered(d, x, y)).LinearRgb()
+				sum[0], sum[1], sum[2] = sum[0]+r, sum[1]+g, sum[2]+b
+			}
+		}
+		n := float64(d.Size * d.Size)
+		r, g, b := c.LinearRgb()
+		if !almosteq_eps(sum[0]/n, r, 0.01) || !almosteq_eps(sum[1]/n, g, 0.01) || !almosteq_eps(sum[2]/n, b, 0.01) {
+			t.Errorf("%v. %v.ToRGB565Dithered averages to (%v, %v, %v), want (%v, %v, %v)", i, c, sum[0]/n, sum[1]/n, sum[2]/n, r, g, b)
+		}
+
+		var a float64
+		for y := 0; y < d.Size; y++ {
+			for x := 0; x < d.Size; x++ {
+				a += FromRGBA4444(c.WithAlpha(0.3).ToRGBA4444Dithered(d, x, y)).A
+			}
+		}
+		if !almosteq_eps(a/n, 0.3, 0.01) {
+			t.Errorf("%v. %v.ToRGBA4444Dithered averages to alpha %v, want 0.3", i, c, a/n)
+		}
+	}
+}
+
+func TestPackedDitheredEdgeCases(t *testing.T) {
+	c := Color{0.3, 0.5, 0.7}
+	ca := c.WithAlpha(0.3)
+
+	// The zero OrderedDither doesn't dither.
+	if got, want := c.ToRGB565Dithered(OrderedDither{}, 3, 5), c.ToRGB565(); got != want {
+		t.Errorf("ToRGB565Dithered(OrderedDither{}) => %x, want %x", got, want)
+	}
+	if got, want := ca.ToRGBA4444Dithered(OrderedDither{}, 3, 5), ca.ToRGBA4444(); got != want {
+		t.Errorf("ToRGBA4444Dithered(OrderedDither{}) => %x, want %x", got, want)
+	}
+
+	// Negative coordinates continue the tiling of the map.
+	d := Bayer(4)
+	for _, p := range [][2]int{{-1, -1}, {-4, 2}, {-13, -7}} {
+		x, y := p[0]+16, p[1]+16
+		if got, want := c.ToRGB565Dithered(d, p[0], p[1]), c.ToRGB565Dithered(d, x, y); got != want {
+			t.Errorf("ToRGB565Dithered at (%v, %v) => %x, want %x as at (%v, %v)", p[0], p[1], got, want, x, y)
+		}
+		if got, want := ca.ToRGBA4444Dithered(d, p[0], p[1]), ca.ToRGBA4444Dithered(d, x, y); got != want {
+			t.Errorf("ToRGBA4444Dithered at (%v, %v) => %x, want %x as at (%v, %v)", p[0], p[1], got, want, x, y)
+		}
+	}
+}
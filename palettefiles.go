@@ -0,0 +1,427 @@
+// Readers and writers for the palette file formats designers commonly use:
+// GIMP .gpl, Adobe .ase and .aco, and JASC (Paint Shop Pro) .pal files.
+
+package colorful
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// A Swatch is a single color of a palette file, together with the name it has
+// been given in that file. The name may be empty.
+type Swatch struct {
+	Name  string
+	Color Color
+}
+
+// Converts naive, uncalibrated CMYK values in [0..1] to a color.
+// This is what most applications do when they don't have an ICC profile.
+func cmyk(c, m, y, k float64) Color {
+	return Color{(1.0 - c) * (1.0 - k), (1.0 - m) * (1.0 - k), (1.0 - y) * (1.0 - k)}
+}
+
+/// GIMP ///
+////////////
+// https://developer.gimp.org/core/standards/gpl/ (there is no real spec,
+// this follows what GIMP itself reads and writes.)
+
+// ReadGPL reads a GIMP palette (.gpl) file, returning the palette's name
+// (which may be empty) and its swatches.
+func ReadGPL(r io.Reader) (name string, swatches []Swatch, err error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "GIMP Palette" {
+		if err = scanner.Err(); err == nil {
+			err = fmt.Errorf("palettefile: missing \"GIMP Palette\" header")
+		}
+		return "", nil, err
+	}
+
+	for lineno := 2; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "Name:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "Name:"))
+			continue
+		case strings.HasPrefix(line, "Columns:"):
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return name, swatches, fmt.Errorf("palettefile: gpl line %v: expected \"r g b [name]\", got %q", lineno, line)
+		}
+		var rgb [3]float64
+		for i := range rgb {
+			v, err := strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				return name, swatches, fmt.Errorf("palettefile: gpl line %v: invalid channel value %q", lineno, fields[i])
+			}
+			if v > 255 {
+				return name, swatches, fmt.Errorf("palettefile: gpl line %v: %w", lineno, ErrOutOfRange{"RGB"[i : i+1], float64(v), 0, 255})
+			}
+			rgb[i] = float64(v) / 255.0
+		}
+		swatches = append(swatches, Swatch{
+			Name:  strings.Join(fields[3:], " "),
+			Color: Color{rgb[0], rgb[1], rgb[2]},
+		})
+	}
+	return name, swatches, scanner.Err()
+}
+
+// WriteGPL writes the swatches as a GIMP palette (.gpl) file with the given name.
+func WriteGPL(w io.Writer, name string, swatches []Swatch) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "GIMP Palette\nName: %s\n#\n", name)
+	for _, s := range swatches {
+		r, g, b := s.Color.Clamped().RGB255()
+		fmt.Fprintf(bw, "%3d %3d %3d\t%s\n", r, g, b, s.Name)
+	}
+	return bw.Flush()
+}
+
+/// JASC ///
+////////////
+
+// ReadJASC reads a JASC (Paint Shop Pro) palette (.pal) file.
+// The format does not support color names.
+func ReadJASC(r io.Reader) ([]Color, error) {
+	scanner := bufio.NewScanner(r)
+	var header [3]string
+	for i := range header {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("palettefile: truncated jasc header")
+		}
+		header[i] = strings.TrimSpace(scanner.Text())
+	}
+	if header[0] != "JASC-PAL" {
+		return nil, fmt.Errorf("palettefile: missing \"JASC-PAL\" header")
+	}
+	count, err := strconv.Atoi(header[2])
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("palettefile: invalid jasc color count %q", header[2])
+	}
+
+	// Don't trust the count for preallocating, the file may lie.
+	prealloc := count
+	if prealloc > 256 {
+		prealloc = 256
+	}
+	cols := make([]Color, 0, prealloc)
+	for lineno := 4; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return cols, fmt.Errorf("palettefile: jasc line %v: expected \"r g b\", got %q", lineno, line)
+		}
+		var rgb [3]float64
+		for i := range rgb {
+			v, err := strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				return cols, fmt.Errorf("palettefile: jasc line %v: invalid channel value %q", lineno, fields[i])
+			}
+			if v > 255 {
+				return cols, fmt.Errorf("palettefile: jasc line %v: %w", lineno, ErrOutOfRange{"RGB"[i : i+1], float64(v), 0, 255})
+			}
+			rgb[i] = float64(v) / 255.0
+		}
+		cols = append(cols, Color{rgb[0], rgb[1], rgb[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return cols, err
+	}
+	if len(cols) != count {
+		return cols, fmt.Errorf("palettefile: jasc header announces %v colors, but file contains %v", count, len(cols))
+	}
+	return cols, nil
+}
+
+// WriteJASC writes the colors as a JASC (Paint Shop Pro) palette (.pal) file.
+func WriteJASC(w io.Writer, cols []Color) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "JASC-PAL\r\n0100\r\n%d\r\n", len(cols))
+	for _, c := range cols {
+		r, g, b := c.Clamped().RGB255()
+		fmt.Fprintf(bw, "%d %d %d\r\n", r, g, b)
+	}
+	return bw.Flush()
+}
+
+/// ACO ///
+///////////
+// https://www.adobe.com/devnet-apps/photoshop/fileformatashtml/#50577411_pgfId-1055819
+
+const (
+	acoRGB       = 0
+	acoHSB       = 1
+	acoCMYK      = 2
+	acoLab       = 7
+	acoGrayscale = 8
+)
+
+// maxACOName limits the length of swatch names, in UTF-16 code units. Real
+// names are short, this only guards against corrupt files.
+const maxACOName = 1 << 16
+
+// ReadACO reads an Adobe Photoshop color swatch (.aco) file. If the file
+// contains the version 2 section, the swatch names are read from there.
+// RGB, HSB, CMYK, Lab and grayscale swatches are supported, CMYK is converted
+// naively since no ICC profile is available.
+func ReadACO(r io.Reader) ([]Swatch, error) {
+	swatches, err := readACOSection(r, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version 2 section repeats all colors, this time with names.
+	// Older files simply end after the first section.
+	named, err := readACOSection(r, 2)
+	if err == io.EOF {
+		return swatches, nil
+	}
+	return named, err
+}
+
+func readACOSection(r io.Reader, version uint16) ([]Swatch, error) {
+	var header [2]uint16
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		if err == io.EOF && version == 2 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("palettefile: reading aco header: %v", err)
+	}
+	if header[0] != version {
+		return nil, fmt.Errorf("palettefile: expected aco version %v, got %v", version, header[0])
+	}
+
+	swatches := make([]Swatch, header[1])
+	for i := range swatches {
+		var raw struct {
+			Space uint16
+			W     [4]uint16
+		}
+		if err := binary.Read(r, binary.BigEndian, &raw); err != nil {
+			return nil, fmt.Errorf("palettefile: reading aco color %v: %v", i, err)
+		}
+
+		col, err := acoColor(raw.Space, raw.W)
+		if err != nil {
+			return nil, err
+		}
+		swatches[i].Color = col
+
+		if version == 2 {
+			var length uint32
+			if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+				return nil, fmt.Errorf("palettefile: reading aco name %v: %v", i, err)
+			}
+			if length > maxACOName {
+				return nil, fmt.Errorf("palettefile: aco name %v: %w", i, ErrOutOfRange{"name length", float64(length), 0, maxACOName})
+			}
+			name := make([]uint16, length)
+			if err := binary.Read(r, binary.BigEndian, name); err != nil {
+				return nil, fmt.Errorf("palettefile: reading aco name %v: %v", i, err)
+			}
+			swatches[i].Name = decodeUTF16(name)
+		}
+	}
+	return swatches, nil
+}
+
+func acoColor(space uint16, w [4]uint16) (Color, error) {
+	switch space {
+	case acoRGB:
+		return Color{float64(w[0]) / 65535.0, float64(w[1]) / 65535.0, float64(w[2]) / 65535.0}, nil
+	case acoHSB:
+		return Hsv(float64(w[0])/65536.0*360.0, float64(w[1])/65535.0, float64(w[2])/65535.0), nil
+	case acoCMYK:
+		// 0 means 100% ink here.
+		return cmyk(1.0-float64(w[0])/65535.0, 1.0-float64(w[1])/65535.0, 1.0-float64(w[2])/65535.0, 1.0-float64(w[3])/65535.0), nil
+	case acoLab:
+		// L is in [0..10000], a and b are signed and in [-12800..12700].
+		return LabWhiteRef(float64(w[0])/10000.0, float64(int16(w[1]))/10000.0, float64(int16(w[2]))/10000.0, D50).Clamped(), nil
+	case acoGrayscale:
+		v := float64(w[0]) / 10000.0
+		return Color{v, v, v}, nil
+	}
+	return Color{}, fmt.Errorf("palettefile: unsupported aco color space %v", space)
+}
+
+// WriteACO writes the swatches as an Adobe Photoshop color swatch (.aco)
+// file, containing both the version 1 and the named version 2 sections.
+func WriteACO(w io.Writer, swatches []Swatch) error {
+	bw := bufio.NewWriter(w)
+	for _, version := range []uint16{1, 2} {
+		binary.Write(bw, binary.BigEndian, [2]uint16{version, uint16(len(swatches))})
+		for _, s := range swatches {
+			c := s.Color.Clamped()
+			binary.Write(bw, binary.BigEndian, [5]uint16{
+				acoRGB,
+				uint16(c.R*65535.0 + 0.5),
+				uint16(c.G*65535.0 + 0.5),
+				uint16(c.B*65535.0 + 0.5),
+				0,
+			})
+			if version == 2 {
+				name := encodeUTF16(s.Name)
+				binary.Write(bw, binary.BigEndian, uint32(len(name)))
+				binary.Write(bw, binary.BigEndian, name)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+/// ASE ///
+///////////
+// There is no official spec, see http://www.selapa.net/swatches/colors/fileformats.php#adobe_ase
+
+// maxASEBlock is larger than any valid block, a color entry with the longest
+// possible name takes up 128KiB.
+const maxASEBlock = 1 << 18
+
+const (
+	aseGroupStart = 0xc001
+	aseGroupEnd   = 0xc002
+	aseColorEntry = 0x0001
+)
+
+// ReadASE reads an Adobe Swatch Exchange (.ase) file. Groups are flattened,
+// i.e. the swatches of all groups are returned in file order. CMYK is
+// converted naively since no ICC profile is available.
+func ReadASE(r io.Reader) ([]Swatch, error) {
+	var header struct {
+		Signature [4]byte
+		Version   [2]uint16
+		Blocks    uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("palettefile: reading ase header: %v", err)
+	}
+	if string(header.Signature[:]) != "ASEF" {
+		return nil, fmt.Errorf("palettefile: missing \"ASEF\" signature")
+	}
+	if header.Version[0] != 1 {
+		return nil, fmt.Errorf("palettefile: unsupported ase version %v.%v", header.Version[0], header.Version[1])
+	}
+
+	var swatches []Swatch
+	for i := uint32(0); i < header.Blocks; i++ {
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			return swatches, fmt.Errorf("palettefile: reading ase block %v: %v", i, err)
+		}
+		if block.Length > maxASEBlock {
+			return swatches, fmt.Errorf("palettefile: ase block %v: %w", i, ErrOutOfRange{"block length", float64(block.Length), 0, maxASEBlock})
+		}
+		data := make([]byte, block.Length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return swatches, fmt.Errorf("palettefile: reading ase block %v: %v", i, err)
+		}
+		if block.Type != aseColorEntry {
+			continue // Group start/end markers.
+		}
+
+		s, err := aseSwatch(data)
+		if err != nil {
+			return swatches, fmt.Errorf("palettefile: ase block %v: %v", i, err)
+		}
+		swatches = append(swatches, s)
+	}
+	return swatches, nil
+}
+
+func aseSwatch(data []byte) (Swatch, error) {
+	if len(data) < 2 {
+		return Swatch{}, fmt.Errorf("truncated color entry")
+	}
+	namelen := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if len(data) < 2*namelen+4 {
+		return Swatch{}, fmt.Errorf("truncated color entry")
+	}
+	name := make([]uint16, namelen)
+	for i := range name {
+		name[i] = binary.BigEndian.Uint16(data[2*i:])
+	}
+	data = data[2*namelen:]
+	model := string(data[:4])
+	data = data[4:]
+
+	nvalues := map[string]int{"RGB ": 3, "LAB ": 3, "CMYK": 4, "Gray": 1}[model]
+	if nvalues == 0 {
+		return Swatch{}, fmt.Errorf("unsupported color model %q", model)
+	}
+	if len(data) < 4*nvalues {
+		return Swatch{}, fmt.Errorf("truncated color entry")
+	}
+	var v [4]float64
+	for i := 0; i < nvalues; i++ {
+		v[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(data[4*i:])))
+	}
+
+	s := Swatch{Name: decodeUTF16(name)}
+	switch model {
+	case "RGB ":
+		s.Color = Color{v[0], v[1], v[2]}
+	case "LAB ":
+		// L is in [0..1], a and b are in [-128..127].
+		s.Color = LabWhiteRef(v[0], v[1]/100.0, v[2]/100.0, D50).Clamped()
+	case "CMYK":
+		s.Color = cmyk(v[0], v[1], v[2], v[3])
+	case "Gray":
+		s.Color = Color{v[0], v[0], v[0]}
+	}
+	return s, nil
+}
+
+// WriteASE writes the swatches as an Adobe Swatch Exchange (.ase) file,
+// using the RGB color model and no groups.
+func WriteASE(w io.Writer, swatches []Swatch) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("ASEF")
+	binary.Write(bw, binary.BigEndian, [2]uint16{1, 0})
+	binary.Write(bw, binary.BigEndian, uint32(len(swatches)))
+	for _, s := range swatches {
+		name := encodeUTF16(s.Name)
+		c := s.Color.Clamped()
+		binary.Write(bw, binary.BigEndian, uint16(aseColorEntry))
+		binary.Write(bw, binary.BigEndian, uint32(2+2*len(name)+4+3*4+2))
+		binary.Write(bw, binary.BigEndian, uint16(len(name)))
+		binary.Write(bw, binary.BigEndian, name)
+		bw.WriteString("RGB ")
+		binary.Write(bw, binary.BigEndian, [3]float32{float32(c.R), float32(c.G), float32(c.B)})
+		binary.Write(bw, binary.BigEndian, uint16(2)) // "Normal" color type.
+	}
+	return bw.Flush()
+}
+
+// Both Adobe formats store names as null-terminated UTF-16.
+func encodeUTF16(s string) []uint16 {
+	return append(utf16.Encode([]rune(s)), 0)
+}
+
+func decodeUTF16(s []uint16) string {
+	for len(s) > 0 && s[len(s)-1] == 0 {
+		s = s[:len(s)-1]
+	}
+	return string(utf16.Decode(s))
+}
@@ -0,0 +1,145 @@
+package colorful
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var paletteFileSwatches = []Swatch{
+	{"Black", Color{0.0, 0.0, 0.0}},
+	{"Red", Color{1.0, 0.0, 0.0}},
+	{"", Color{0.2, 0.4, 0.6}},
+	{"Überblau", Color{0.0, 0.0, 1.0}},
+}
+
+func checkSwatches(t *testing.T, format string, got []Swatch, names bool) {
+	if len(got) != len(paletteFileSwatches) {
+		t.Fatalf("%v: got %v swatches, want %v", format, len(got), len(paletteFileSwatches))
+	}
+	for i, want := range paletteFileSwatches {
+		if names && got[i].Name != want.Name {
+			t.Errorf("%v: swatch %v has name %q, want %q", format, i, got[i].Name, want.Name)
+		}
+		if !got[i].Color.AlmostEqualRgb(want.Color) {
+			t.Errorf("%v: swatch %v has color %v, want %v", format, i, got[i].Color, want.Color)
+		}
+	}
+}
+
+func TestGPLRoundtrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGPL(&buf, "Test", paletteFileSwatches); err != nil {
+		t.Fatalf("WriteGPL: %v", err)
+	}
+	name, got, err := ReadGPL(&buf)
+	if err != nil {
+		t.Fatalf("ReadGPL: %v", err)
+	}
+	if name != "Test" {
+		t.Errorf("ReadGPL name => %q, want %q", name, "Test")
+	}
+	checkSwatches(t, "gpl", got, true)
+}
+
+func TestReadGPL(t *testing.T) {
+	in := "GIMP Palette\nName: Bears\nColumns: 2\n#\n# A comment\n  0   0   0\tBlack bear\n255 255 255\tPolar bear\n"
+	name, got, err := ReadGPL(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadGPL: %v", err)
+	}
+	if name != "Bears" || len(got) != 2 || got[0].Name != "Black bear" || got[1].Color != (Color{1, 1, 1}) {
+		t.Errorf("ReadGPL => %q, %v", name, got)
+	}
+
+	for _, bad := range []string{"", "JASC-PAL\n", "GIMP Palette\n1 2\n", "GIMP Palette\n1 2 300\n"} {
+		if _, _, err := ReadGPL(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadGPL(%q) should have failed", bad)
+		}
+	}
+}
+
+func TestJASCRoundtrip(t *testing.T) {
+	cols := make([]Color, len(paletteFileSwatches))
+	for i, s := range paletteFileSwatches {
+		cols[i] = s.Color
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJASC(&buf, cols); err != nil {
+		t.Fatalf("WriteJASC: %v", err)
+	}
+	got, err := ReadJASC(&buf)
+	if err != nil {
+		t.Fatalf("ReadJASC: %v", err)
+	}
+	swatches := make([]Swatch, len(got))
+	for i, c := range got {
+		swatches[i].Color = c
+	}
+	checkSwatches(t, "jasc", swatches, false)
+
+	if _, err := ReadJASC(strings.NewReader("JASC-PAL\n0100\n2\n0 0 0\n")); err == nil {
+		t.Errorf("ReadJASC should fail on a wrong color count")
+	}
+}
+
+func TestACORoundtrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteACO(&buf, paletteFileSwatches); err != nil {
+		t.Fatalf("WriteACO: %v", err)
+	}
+	got, err := ReadACO(&buf)
+	if err != nil {
+		t.Fatalf("ReadACO: %v", err)
+	}
+	checkSwatches(t, "aco", got, true)
+}
+
+func TestReadACOVersion1(t *testing.T) {
+	// Version 1 only, one HSB red and one grayscale swatch.
+	in := []byte{0, 1, 0, 2, 0, 1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 8, 0x13, 0x88, 0, 0, 0, 0, 0, 0}
+	got, err := ReadACO(bytes.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadACO: %v", err)
+	}
+	if len(got) != 2 || !got[0].Color.AlmostEqualRgb(Color{1, 0, 0}) || !got[1].Color.AlmostEqualRgb(Color{0.5, 0.5, 0.5}) {
+		t.Errorf("ReadACO => %v", got)
+	}
+}
+
+func TestASERoundtrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteASE(&buf, paletteFileSwatches); err != nil {
+		t.Fatalf("WriteASE: %v", err)
+	}
+	got, err := ReadASE(&buf)
+	if err != nil {
+		t.Fatalf("ReadASE: %v", err)
+	}
+	checkSwatches(t, "ase", got, true)
+
+	if _, err := ReadASE(strings.NewReader("GIMP Palette\n")); err == nil {
+		t.Errorf("ReadASE should fail on a non-ase file")
+	}
+}
+
+func TestPaletteFileHugeLengths(t *testing.T) {
+	// Version 1 and 2 headers with one black swatch, whose name claims to be
+	// 4G code units long.
+	aco := []byte{0, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}
+	if _, err := ReadACO(bytes.NewReader(aco)); !errors.As(err, &ErrOutOfRange{}) {
+		t.Errorf("ReadACO with a huge name => %v, want ErrOutOfRange", err)
+	}
+
+	// One color entry block claiming to be 4GB long.
+	ase := []byte{'A', 'S', 'E', 'F', 0, 1, 0, 0, 0, 0, 0, 1, 0, 1, 0xff, 0xff, 0xff, 0xff}
+	if _, err := ReadASE(bytes.NewReader(ase)); !errors.As(err, &ErrOutOfRange{}) {
+		t.Errorf("ReadASE with a huge block => %v, want ErrOutOfRange", err)
+	}
+
+	if _, err := ReadJASC(strings.NewReader("JASC-PAL\n0100\n2000000000\n0 0 0\n")); err == nil {
+		t.Errorf("ReadJASC with a wrong count should fail")
+	}
+}
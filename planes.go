@@ -0,0 +1,224 @@
+// Conversions of whole images to and from planes of float32 values in the
+// linear RGB, Lab and OkLab spaces, using one goroutine per CPU.
+
+package colorful
+
+import (
+	"image"
+	"runtime"
+	"sync"
+)
+
+// parallelRows calls fn for consecutive bands of the rows of r, in as many
+// goroutines as GOMAXPROCS allows, and waits for all of them.
+func parallelRows(r image.Rectangle, fn func(y0, y1 int)) {
+	n := runtime.GOMAXPROCS(0)
+	if h := r.Dy(); h < n {
+		n = h
+	}
+	if n <= 1 {
+		fn(r.Min.Y, r.Max.Y)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		y0 := r.Min.Y + r.Dy()*i/n
+		y1 := r.Min.Y + r.Dy()*(i+1)/n
+		go func() {
+			defer wg.Done()
+			fn(y0, y1)
+		}()
+	}
+	wg.Wait()
+}
+
+// imageToPlanes converts every pixel of img to linear RGB and then with conv
+// into three planes, stored row by row with a stride of the image's width.
+// The planes of dst are reused if they are large enough.
+func imageToPlanes(dst [3][]float32, img image.Image, conv func(vec3[float32]) vec3[float32]) [3][]float32 {
+	b := img.Bounds()
+	planes := reusePlanes(dst, b.Dx()*b.Dy())
+
+	nrgba, isNrgba := img.(*image.NRGBA)
+	parallelRows(b, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			row := (y - b.Min.Y) * b.Dx()
+			for x := b.Min.X; x < b.Max.X; x++ {
+				var v vec3[float32]
+				if isNrgba {
+					if p := nrgba.Pix[nrgba.PixOffset(x, y):]; p[3] != 0 {
+						v = vec3[float32]{linearizeSample(p[0]), linearizeSample(p[1]), linearizeSample(p[2])}
+					}
+				} else if c, ok := MakeColor(img.At(x, y)); ok {
+					v = c.Color32().linearRgb()
+				}
+				v = conv(v)
+				i := row + x - b.Min.X
+				planes[0][i], planes[1][i], planes[2][i] = v[0], v[1], v[2]
+			}
+		}
+	})
+	return planes
+}
+
+// planesToImage is the inverse of imageToPlanes, conv converts to linear RGB.
+func planesToImage(planes [3][]float32, r image.Rectangle, conv func(vec3[float32]) vec3[float32]) *image.NRGBA {
+	for _, p := range planes {
+		if len(p) != r.Dx()*r.Dy() {
+			panic("colorful: plane size doesn't match the image size")
+		}
+	}
+
+	img := image.NewNRGBA(r)
+	parallelRows(r, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			row := (y - r.Min.Y) * r.Dx()
+			for x := r.Min.X; x < r.Max.X; x++ {
+				i := row + x - r.Min.X
+				v := conv(vec3[float32]{planes[0][i], planes[1][i], planes[2][i]})
+				p := img.Pix[img.PixOffset(x, y):]
+				p[0], p[1], p[2], p[3] = delinearizeSample[uint8](v[0]), delinearizeSample[uint8](v[1]), delinearizeSample[uint8](v[2]), 0xff
+			}
+		}
+	})
+	return img
+}
+
+// ImageToLinearRgb converts img into three planes of linear R, G and B
+// values, stored row by row with a stride of the image's width. Alpha is
+// ignored, and fully transparent pixels are black. Other pixels keep their
+// straight, non-premultiplied color, which is less precise for semi-transparent
+// pixels of premultiplied images such as *image.RGBA. The planes may be handed
+// back using ReleasePlanes once they aren't needed anymore.
+func ImageToLinearRgb(img image.Image) [3][]float32 {
+	return imageToPlanes([3][]float32{}, img, identity32)
+}
+
+// ImageToLinearRgbInto is like ImageToLinearRgb, but reuses the planes of
+// dst if they are large enough, and returns the planes it filled.
+func ImageToLinearRgbInto(dst [3][]float32, img image.Image) [3][]float32 {
+	return imageToPlanes(dst, img, identity32)
+}
+
+// LinearRgbToImage is the inverse of ImageToLinearRgb, creating an opaque
+// image of the given bounds. Colors outside of the gamut are clamped.
+func LinearRgbToImage(planes [3][]float32, r image.Rectangle) *image.NRGBA {
+	return planesToImage(planes, r, identity32)
+}
+
+// ImageToLab converts img into three planes of CIE L*, a* and b* values
+// (D65), see ImageToLinearRgb.
+func ImageToLab(img image.Image) [3][]float32 {
+	return imageToPlanes([3][]float32{}, img, linearRgbToLab32)
+}
+
+// ImageToLabInto is like ImageToLab, but reuses the planes of dst if they
+// are large enough, and returns the planes it filled.
+func ImageToLabInto(dst [3][]float32, img image.Image) [3][]float32 {
+	return imageToPlanes(dst, img, linearRgbToLab32)
+}
+
+// LabToImage is the inverse of ImageToLab, see LinearRgbToImage.
+func LabToImage(planes [3][]float32, r image.Rectangle) *image.NRGBA {
+	return planesToImage(planes, r, labToLinearRgb32)
+}
+
+// ImageToOkLab converts img into three planes of OkLab L, a and b values,
+// see ImageToLinearRgb.
+func ImageToOkLab(img image.Image) [3][]float32 {
+	return imageToPlanes([3][]float32{}, img, linearRgbToOkLab[float32])
+}
+
+// ImageToOkLabInto is like ImageToOkLab, but reuses the planes of dst if they
+// are large enough, and returns the planes it filled.
+func ImageToOkLabInto(dst [3][]float32, img image.Image) [3][]float32 {
+	return imageToPlanes(dst, img, linearRgbToOkLab[float32])
+}
+
+// OkLabToImage is the inverse of ImageToOkLab, see LinearRgbToImage.
+func OkLabToImage(planes [3][]float32, r image.Rectangle) *image.NRGBA {
+	return planesToImage(planes, r, okLabToLinearRgb[float32])
+}
+
+/// PlanarImage ///
+///////////////////
+
+// A PlaneSpace is the color space of the planes of a PlanarImage.
+type PlaneSpace int
+
+const (
+	PlanesLinearRgb PlaneSpace = iota
+	PlanesLab
+	PlanesOkLab
+)
+
+func (s PlaneSpace) convs() (to, from func(vec3[float32]) vec3[float32]) {
+	switch s {
+	case PlanesLinearRgb:
+		return identity32, identity32
+	case PlanesLab:
+		return linearRgbToLab32, labToLinearRgb32
+	case PlanesOkLab:
+		return linearRgbToOkLab[float32], okLabToLinearRgb[float32]
+	}
+	panic("colorful: unknown plane space")
+}
+
+// A PlanarImage is an image stored as three planes of float32 values, such as
+// L*, a* and b*, for algorithms which work on one channel at a time. The pixel
+// at (x, y) is at index PixOffset(x, y) of each plane.
+type PlanarImage struct {
+	Space  PlaneSpace
+	Rect   image.Rectangle
+	Planes [3][]float32
+}
+
+// NewPlanarImage returns a black PlanarImage of the given space and bounds.
+func NewPlanarImage(space PlaneSpace, r image.Rectangle) *PlanarImage {
+	p := &PlanarImage{Space: space, Rect: r}
+	for i := range p.Planes {
+		p.Planes[i] = make([]float32, r.Dx()*r.Dy())
+	}
+	return p
+}
+
+// FromImage converts img into p, taking over its bounds. The planes of p are
+// reused if they are large enough, so converting many images of similar size
+// with the same PlanarImage doesn't allocate.
+func (p *PlanarImage) FromImage(img image.Image) {
+	to, _ := p.Space.convs()
+	p.Planes = imageToPlanes(p.Planes, img, to)
+	p.Rect = img.Bounds()
+}
+
+// ToImage converts p back into an opaque image, clamping colors outside of
+// the gamut.
+func (p *PlanarImage) ToImage() *image.NRGBA {
+	_, from := p.Space.convs()
+	return planesToImage(p.Planes, p.Rect, from)
+}
+
+// PixOffset returns the index of the pixel at (x, y) in the planes.
+func (p *PlanarImage) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Rect.Dx() + x - p.Rect.Min.X
+}
+
+// At returns the three values of the pixel at (x, y).
+func (p *PlanarImage) At(x, y int) (v0, v1, v2 float32) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return 0, 0, 0
+	}
+	i := p.PixOffset(x, y)
+	return p.Planes[0][i], p.Planes[1][i], p.Planes[2][i]
+}
+
+// Set sets the three values of the pixel at (x, y).
+func (p *PlanarImage) Set(x, y int, v0, v1, v2 float32) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	p.Planes[0][i], p.Planes[1][i], p.Planes[2][i] = v0, v1, v2
+}
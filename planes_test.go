@@ -0,0 +1,168 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func testImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(3, 5, 40, 33))
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(x * 6), uint8(y * 7), uint8(x * y), 255})
+		}
+	}
+	return img
+}
+
+func TestImagePlanes(t *testing.T) {
+	img := testImage()
+	b := img.Bounds()
+
+	conversions := []struct {
+		name string
+		to   func(image.Image) [3][]float32
+		from func([3][]float32, image.Rectangle) *image.NRGBA
+		conv func(Color) (float64, float64, float64)
+	}{
+		{"LinearRgb", ImageToLinearRgb, LinearRgbToImage, Color.LinearRgb},
+		{"Lab", ImageToLab, LabToImage, Color.Lab},
+		{"OkLab", ImageToOkLab, OkLabToImage, Color.OkLab},
+	}
+	for _, conv := range conversions {
+		// Both the fast path for NRGBA and the generic one.
+		for _, src := range []image.Image{img, genericImage{img}} {
+			planes := conv.to(src)
+			for y := b.Min.Y; y < b.Max.Y; y++ {
+				for x := b.Min.X; x < b.Max.X; x++ {
+					c, _ := MakeColor(img.At(x, y))
+					v0, v1, v2 := conv.conv(c)
+					i := (y-b.Min.Y)*b.Dx() + x - b.Min.X
+					if !almosteq32(planes[0][i], v0) || !almosteq32(planes[1][i], v1) || !almosteq32(planes[2][i], v2) {
+						t.Fatalf("ImageTo%v at (%v, %v) => (%v, %v, %v), want (%v, %v, %v)", conv.name, x, y, planes[0][i], planes[1][i], planes[2][i], v0, v1, v2)
+					}
+				}
+			}
+
+			back := conv.from(planes, b)
+			if back.Bounds() != b {
+				t.Errorf("%vToImage => bounds %v, want %v", conv.name, back.Bounds(), b)
+			}
+			for i := range img.Pix {
+				if back.Pix[i] != img.Pix[i] {
+					t.Fatalf("%vToImage(ImageTo%v(img)) differs at %v: %v, want %v", conv.name, conv.name, i, back.Pix[i], img.Pix[i])
+				}
+			}
+		}
+	}
+}
+
+func TestImagePlanesTransparent(t *testing.T) {
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	nrgba.SetNRGBA(0, 0, color.NRGBA{255, 0, 0, 0})
+	nrgba.SetNRGBA(1, 0, color.NRGBA{255, 0, 0, 128})
+	rgba := image.NewRGBA(nrgba.Bounds())
+	rgba.SetRGBA(0, 0, color.RGBA{0, 0, 0, 0})
+	rgba.SetRGBA(1, 0, color.RGBA{128, 0, 0, 128})
+
+	for _, img := range []image.Image{nrgba, genericImage{nrgba}, rgba} {
+		planes := ImageToLinearRgb(img)
+		if planes[0][0] != 0 || planes[1][0] != 0 || planes[2][0] != 0 {
+			t.Errorf("%T: transparent pixel => (%v, %v, %v), want black", img, planes[0][0], planes[1][0], planes[2][0])
+		}
+		if planes[0][1] != 1 || planes[1][1] != 0 || planes[2][1] != 0 {
+			t.Errorf("%T: semi-transparent red => (%v, %v, %v), want (1, 0, 0)", img, planes[0][1], planes[1][1], planes[2][1])
+		}
+	}
+}
+
+// genericImage hides the type of an image from the fast paths.
+type genericImage struct {
+	image.Image
+}
+
+func BenchmarkImageToOkLab(bench *testing.B) {
+	img := image.NewNRGBA(image.Rect(0, 0, 512, 512))
+	for n := 0; n < bench.N; n++ {
+		ImageToOkLab(img)
+	}
+}
+
+func TestImageToPlanesInto(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 13, 7))
+	for i, c := range randomColors(13 * 7) {
+		r, g, b := c.RGB255()
+		img.Pix[4*i], img.Pix[4*i+1], img.Pix[4*i+2], img.Pix[4*i+3] = r, g, b, 255
+	}
+
+	want := ImageToLab(img)
+	dst := [3][]float32{make([]float32, 200), make([]float32, 200), make([]float32, 200)}
+	got := ImageToLabInto(dst, img)
+	for c := range got {
+		if &got[c][0] != &dst[c][0] {
+			t.Errorf("ImageToLabInto didn't reuse plane %v", c)
+		}
+		if len(got[c]) != len(want[c]) {
+			t.Fatalf("ImageToLabInto => plane %v of length %v, want %v", c, len(got[c]), len(want[c]))
+		}
+		for i := range want[c] {
+			if got[c][i] != want[c][i] {
+				t.Fatalf("ImageToLabInto => %v at %v in plane %v, want %v", got[c][i], i, c, want[c][i])
+			}
+		}
+	}
+
+	// Too small planes are replaced, and released ones are reused.
+	ReleasePlanes(want)
+	got = ImageToOkLabInto([3][]float32{make([]float32, 3)}, img)
+	for c := range got {
+		if len(got[c]) != 13*7 {
+			t.Errorf("ImageToOkLabInto => plane %v of length %v, want %v", c, len(got[c]), 13*7)
+		}
+	}
+}
+
+func TestPlanarImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(-3, 2, 9, 7))
+	for i, c := range randomColors(12 * 5) {
+		r, g, b := c.RGB255()
+		img.Pix[4*i], img.Pix[4*i+1], img.Pix[4*i+2], img.Pix[4*i+3] = r, g, b, 255
+	}
+
+	for _, space := range []PlaneSpace{PlanesLinearRgb, PlanesLab, PlanesOkLab} {
+		p := NewPlanarImage(space, image.Rect(0, 0, 1, 1))
+		p.FromImage(img)
+		if p.Rect != img.Bounds() {
+			t.Errorf("%v. FromImage => bounds %v, want %v", space, p.Rect, img.Bounds())
+		}
+
+		c, _ := MakeColor(img.At(4, 5))
+		var want [3]float64
+		switch space {
+		case PlanesLinearRgb:
+			want[0], want[1], want[2] = c.LinearRgb()
+		case PlanesLab:
+			want[0], want[1], want[2] = c.Lab()
+		case PlanesOkLab:
+			want[0], want[1], want[2] = c.OkLab()
+		}
+		v0, v1, v2 := p.At(4, 5)
+		if !almosteq32(v0, want[0]) || !almosteq32(v1, want[1]) || !almosteq32(v2, want[2]) {
+			t.Errorf("%v. At(4, 5) => (%v, %v, %v), want %v", space, v0, v1, v2, want)
+		}
+
+		back := p.ToImage()
+		for i := range img.Pix {
+			if back.Pix[i] != img.Pix[i] {
+				t.Fatalf("%v. ToImage differs at %v: %v, want %v", space, i, back.Pix[i], img.Pix[i])
+			}
+		}
+
+		p.Set(4, 5, 0, 0, 0)
+		if v0, v1, v2 := p.At(4, 5); v0 != 0 || v1 != 0 || v2 != 0 {
+			t.Errorf("%v. At after Set => (%v, %v, %v), want zeros", space, v0, v1, v2)
+		}
+	}
+}
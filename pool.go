@@ -0,0 +1,64 @@
+// Pools of the large temporary buffers of the image functions, such that
+// long-running servers converting many images don't churn the garbage
+// collector.
+
+package colorful
+
+import "sync"
+
+var planePool sync.Pool
+
+// getPlane returns a plane of n values from the pool, or a new one. Its
+// contents are undefined.
+func getPlane(n int) []float32 {
+	if p, ok := planePool.Get().(*[]float32); ok {
+		if cap(*p) >= n {
+			return (*p)[:n]
+		}
+		planePool.Put(p)
+	}
+	return make([]float32, n)
+}
+
+// ReleasePlanes hands planes returned by ImageToLinearRgb, ImageToLab or
+// ImageToOkLab back for reuse by later calls. This is optional, but saves
+// allocating new planes for every image. The planes must not be used
+// afterwards.
+func ReleasePlanes(planes [3][]float32) {
+	for _, p := range planes {
+		if cap(p) > 0 {
+			p := p
+			planePool.Put(&p)
+		}
+	}
+}
+
+// reusePlanes returns planes of n values, using those of dst if they are
+// large enough and the pool otherwise.
+func reusePlanes(dst [3][]float32, n int) [3][]float32 {
+	for i := range dst {
+		if cap(dst[i]) >= n {
+			dst[i] = dst[i][:n]
+		} else {
+			dst[i] = getPlane(n)
+		}
+	}
+	return dst
+}
+
+var paletteCachePool = sync.Pool{
+	New: func() interface{} {
+		return make(map[Color]int)
+	},
+}
+
+func getPaletteCache() map[Color]int {
+	return paletteCachePool.Get().(map[Color]int)
+}
+
+func putPaletteCache(cache map[Color]int) {
+	for c := range cache {
+		delete(cache, c)
+	}
+	paletteCachePool.Put(cache)
+}
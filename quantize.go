@@ -0,0 +1,136 @@
+// Functions for reducing images to a fixed palette of colors.
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+)
+
+// A Ditherer decides which palette entry every pixel of an image is mapped to.
+// The simplest one just picks the nearest palette entry for every pixel, more
+// elaborate ones spread the quantization error over neighbouring pixels.
+type Ditherer interface {
+	// Dither sets all pixels of dst, which has the same bounds as src, to the
+	// index of a palette entry. nearest returns the index of the entry of
+	// palette which is closest to the given color.
+	Dither(dst *image.Paletted, src image.Image, palette []Color, nearest func(Color) int)
+}
+
+// noDither maps every pixel to its nearest palette entry, nothing more.
+type noDither struct{}
+
+func (noDither) Dither(dst *image.Paletted, src image.Image, palette []Color, nearest func(Color) int) {
+	b := src.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, _ := MakeColor(src.At(x, y))
+			dst.SetColorIndex(x, y, uint8(nearest(c)))
+		}
+	}
+}
+
+// Images usually contain lots of repeated colors, so remembering the nearest
+// palette entry of colors we've seen before saves a lot of distance
+// computations. We stop remembering at some point to bound the memory usage.
+const paletteIndexCacheSize = 1 << 16
+
+// A paletteIndex finds the nearest palette entry for a color.
+type paletteIndex struct {
+	palette []Color
+	dist    func(c1, c2 Color) float64
+	cache   map[Color]int
+}
+
+// newPaletteIndex returns an index whose cache comes from a pool, it needs to
+// be handed back by calling release.
+func newPaletteIndex(palette []Color, dist func(c1, c2 Color) float64) *paletteIndex {
+	return &paletteIndex{palette, dist, getPaletteCache()}
+}
+
+func (p *paletteIndex) release() {
+	putPaletteCache(p.cache)
+	p.cache = nil
+}
+
+func (p *paletteIndex) nearest(c Color) int {
+	if i, ok := p.cache[c]; ok {
+		return i
+	}
+
+	best, bestdist := 0, p.dist(c, p.palette[0])
+	for i := 1; i < len(p.palette); i++ {
+		if d := p.dist(c, p.palette[i]); d < bestdist {
+			best, bestdist = i, d
+		}
+	}
+
+	if len(p.cache) < paletteIndexCacheSize {
+		p.cache[c] = best
+	}
+	return best
+}
+
+// QuantizeImage reduces img to the colors of the given palette, which must
+// contain between 1 and 256 colors. Nearest colors are found using
+// DistanceLab, and the ditherer decides how the pixels are assigned to palette
+// entries; if it is nil, every pixel simply gets its nearest palette entry.
+// The alpha channel of img is ignored.
+func QuantizeImage(img image.Image, palette []Color, ditherer Ditherer) *image.Paletted {
+	return quantize("QuantizeImage", img, palette, Color.DistanceLab, ditherer)
+}
+
+func quantize(fn string, img image.Image, palette []Color, metric func(c1, c2 Color) float64, ditherer Ditherer) *image.Paletted {
+	if len(palette) == 0 || len(palette) > 256 {
+		panic("colorful: " + fn + " needs a palette of 1 to 256 colors")
+	}
+	if metric == nil {
+		metric = Color.DistanceLab
+	}
+	if ditherer == nil {
+		ditherer = noDither{}
+	}
+
+	pal := make(color.Palette, len(palette))
+	for i, c := range palette {
+		pal[i] = c
+	}
+	dst := image.NewPaletted(img.Bounds(), pal)
+
+	index := newPaletteIndex(palette, metric)
+	ditherer.Dither(dst, img, palette, index.nearest)
+	index.release()
+	return dst
+}
+
+// MapToPalette recolors img such that every pixel is replaced by a color of
+// the given palette, which must contain between 1 and 256 colors. Nearest
+// colors are found using metric, such as Color.DistanceLab (the default if
+// nil) or Color.DistanceCIEDE2000, and the ditherer works as for
+// QuantizeImage. Unlike QuantizeImage, the alpha channel of img is kept.
+func MapToPalette(img image.Image, palette []Color, metric func(c1, c2 Color) float64, ditherer Ditherer) *image.NRGBA {
+	q := quantize("MapToPalette", img, palette, metric, ditherer)
+
+	var rgb [256][3]uint8
+	for i, c := range palette {
+		rgb[i][0], rgb[i][1], rgb[i][2] = c.Clamped().RGB255()
+	}
+
+	b := img.Bounds()
+	dst := image.NewNRGBA(b)
+	nrgba, isNrgba := img.(*image.NRGBA)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			var a uint8
+			if isNrgba {
+				a = nrgba.Pix[nrgba.PixOffset(x, y)+3]
+			} else {
+				a = color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA).A
+			}
+			c := rgb[q.Pix[q.PixOffset(x, y)]]
+			p := dst.Pix[dst.PixOffset(x, y):]
+			p[0], p[1], p[2], p[3] = c[0], c[1], c[2], a
+		}
+	}
+	return dst
+}
@@ -0,0 +1,84 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// Creates a horizontal grayscale ramp from black to white.
+func grayRamp(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(x * 255 / (w - 1))
+			img.Set(x, y, color.RGBA{v, v, v, 255})
+		}
+	}
+	return img
+}
+
+func TestQuantizeImage(t *testing.T) {
+	palette := []Color{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}}
+	img := image.NewRGBA(image.Rect(2, 3, 5, 4))
+	img.Set(2, 3, color.RGBA{10, 10, 10, 255})
+	img.Set(3, 3, color.RGBA{240, 250, 245, 255})
+	img.Set(4, 3, color.RGBA{200, 30, 20, 255})
+
+	q := QuantizeImage(img, palette, nil)
+	if q.Bounds() != img.Bounds() {
+		t.Errorf("QuantizeImage bounds => %v, want %v", q.Bounds(), img.Bounds())
+	}
+	for i, want := range []uint8{0, 1, 2} {
+		if got := q.ColorIndexAt(2+i, 3); got != want {
+			t.Errorf("QuantizeImage pixel %v => index %v, want %v", i, got, want)
+		}
+	}
+	if c, _ := MakeColor(q.At(4, 3)); c != palette[2] {
+		t.Errorf("QuantizeImage palette entry => %v, want %v", c, palette[2])
+	}
+}
+
+type countingDitherer struct{ calls int }
+
+func (d *countingDitherer) Dither(dst *image.Paletted, src image.Image, palette []Color, nearest func(Color) int) {
+	d.calls++
+	noDither{}.Dither(dst, src, palette, nearest)
+}
+
+func TestQuantizeImageDitherer(t *testing.T) {
+	d := &countingDitherer{}
+	q := QuantizeImage(grayRamp(16, 2), []Color{{0, 0, 0}, {1, 1, 1}}, d)
+	if d.calls != 1 {
+		t.Errorf("Ditherer was called %v times, want 1", d.calls)
+	}
+	if q.ColorIndexAt(0, 0) != 0 || q.ColorIndexAt(15, 1) != 1 {
+		t.Errorf("QuantizeImage with custom ditherer gave wrong ends of the ramp")
+	}
+}
+
+func TestMapToPalette(t *testing.T) {
+	palette := []Color{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}}
+	img := image.NewNRGBA(image.Rect(2, 3, 5, 4))
+	img.Set(2, 3, color.NRGBA{10, 10, 10, 255})
+	img.Set(3, 3, color.NRGBA{240, 250, 245, 128})
+	img.Set(4, 3, color.NRGBA{200, 30, 20, 0})
+
+	for _, metric := range []func(c1, c2 Color) float64{nil, Color.DistanceCIEDE2000, Color.DistanceRgb} {
+		m := MapToPalette(img, palette, metric, nil)
+		if m.Bounds() != img.Bounds() {
+			t.Errorf("MapToPalette bounds => %v, want %v", m.Bounds(), img.Bounds())
+		}
+		for i, want := range []color.NRGBA{{0, 0, 0, 255}, {255, 255, 255, 128}, {255, 0, 0, 0}} {
+			if got := m.NRGBAAt(2+i, 3); got != want {
+				t.Errorf("MapToPalette pixel %v => %v, want %v", i, got, want)
+			}
+		}
+	}
+
+	// Also works on other image types and with dithering.
+	m := MapToPalette(grayRamp(16, 2), []Color{{0, 0, 0}, {1, 1, 1}}, nil, FloydSteinberg)
+	if m.NRGBAAt(0, 0) != (color.NRGBA{0, 0, 0, 255}) || m.NRGBAAt(15, 1) != (color.NRGBA{255, 255, 255, 255}) {
+		t.Errorf("MapToPalette with dithering gave wrong ends of the ramp")
+	}
+}
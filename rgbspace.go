@@ -0,0 +1,276 @@
+// RGB color spaces other than sRGB, such as the wide-gamut Display P3.
+// Colors in those spaces are converted to and from (possibly invalid) sRGB
+// Colors through D65 XYZ.
+
+package colorful
+
+import (
+	"math"
+	"strings"
+)
+
+type mat3 [3][3]float64
+
+func (m *mat3) mul(x, y, z float64) (float64, float64, float64) {
+	return m[0][0]*x + m[0][1]*y + m[0][2]*z,
+		m[1][0]*x + m[1][1]*y + m[1][2]*z,
+		m[2][0]*x + m[2][1]*y + m[2][2]*z
+}
+
+func (m *mat3) dot(n *mat3) (p mat3) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			p[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return
+}
+
+func (m *mat3) inverse() (inv mat3) {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	inv[0][0] = (m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det
+	inv[0][1] = (m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det
+	inv[0][2] = (m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det
+	inv[1][0] = (m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det
+	inv[1][1] = (m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det
+	inv[1][2] = (m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det
+	inv[2][0] = (m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det
+	inv[2][1] = (m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det
+	inv[2][2] = (m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det
+	return
+}
+
+// Bradford chromatic adaptation between the D65 and D50 whites, as used by CSS.
+// https://www.w3.org/TR/css-color-4/#color-conversion-code
+var (
+	bradfordD65ToD50 = mat3{
+		{1.0479298208405488, 0.022946793341019088, -0.05019222954313557},
+		{0.029627815688159344, 0.990434484573249, -0.01707382502938514},
+		{-0.009243058152591178, 0.015055144896577895, 0.7518742899580008},
+	}
+	bradfordD50ToD65 = mat3{
+		{0.9554734527042182, -0.023098536874261423, 0.0632593086610217},
+		{-0.028369706963208136, 1.0099954580058226, 0.021041398966943008},
+		{0.012314001688319899, -0.020507696433477912, 1.3303659366080753},
+	}
+)
+
+// An RGBSpace is an RGB color space defined by its primaries (as a matrix
+// from linear values to D65 XYZ) and its transfer function, such as
+// DisplayP3. Colors are always sRGB, an RGBSpace converts them to and from
+// the values of the space and tells whether they fit into its gamut.
+type RGBSpace struct {
+	toXyz   mat3
+	fromXyz mat3
+
+	// decode converts from encoded values to linear light, encode back.
+	decode func(float64) float64
+	encode func(float64) float64
+}
+
+func newRgbSpace(toXyz mat3, decode, encode func(float64) float64) *RGBSpace {
+	return &RGBSpace{toXyz, toXyz.inverse(), decode, encode}
+}
+
+// toColor converts encoded values of this space to a Color, which is not
+// clamped since many colors of wide gamut spaces are out of the sRGB gamut.
+func (s *RGBSpace) toColor(r, g, b float64) Color {
+	return Xyz(s.toXyz.mul(s.decode(r), s.decode(g), s.decode(b)))
+}
+
+// fromColor converts a Color to encoded values of this space.
+func (s *RGBSpace) fromColor(col Color) (r, g, b float64) {
+	r, g, b = s.fromXyz.mul(col.Xyz())
+	return s.encode(r), s.encode(g), s.encode(b)
+}
+
+// The transfer functions are extended to negative values by mirroring, like
+// CSS does, such that out-of-gamut colors survive round trips.
+func mirrored(f func(float64) float64) func(float64) float64 {
+	return func(v float64) float64 {
+		if v < 0 {
+			return -f(-v)
+		}
+		return f(v)
+	}
+}
+
+func identity(v float64) float64 {
+	return v
+}
+
+var (
+	srgbSpace = newRgbSpace(linearRgbToXyzMat, mirrored(linearize), mirrored(delinearize))
+
+	srgbLinearSpace = newRgbSpace(srgbSpace.toXyz, identity, identity)
+
+	displayP3Space = newRgbSpace(mat3{
+		{0.4865709486482162, 0.26566769316909306, 0.1982172852343625},
+		{0.2289745640697488, 0.6917385218365064, 0.079286914093745},
+		{0.0000000000000000, 0.04511338185890264, 1.043944368900976},
+	}, mirrored(linearize), mirrored(delinearize))
+
+	a98RgbSpace = newRgbSpace(mat3{
+		{0.5766690429101305, 0.1855582379065463, 0.1882286462349947},
+		{0.29734497525053605, 0.6273635662554661, 0.07529145849399788},
+		{0.02703136138641234, 0.07068885253582723, 0.9913375368376388},
+	}, mirrored(func(v float64) float64 {
+		return math.Pow(v, 563.0/256.0)
+	}), mirrored(func(v float64) float64 {
+		return math.Pow(v, 256.0/563.0)
+	}))
+
+	// ProPhoto uses D50 as its white, so we need to adapt it to D65.
+	prophotoRgbSpace = newRgbSpace(bradfordD50ToD65.dot(&mat3{
+		{0.7977604896723027, 0.13518583717574031, 0.0313493495815248},
+		{0.2880711282292934, 0.7118432178101014, 0.00008565396060525902},
+		{0.0, 0.0, 0.8251046025104601},
+	}), mirrored(func(v float64) float64 {
+		if v <= 16.0/512.0 {
+			return v / 16.0
+		}
+		return math.Pow(v, 1.8)
+	}), mirrored(func(v float64) float64 {
+		if v < 1.0/512.0 {
+			return 16.0 * v
+		}
+		return math.Pow(v, 1.0/1.8)
+	}))
+
+	rec2020Space = newRgbSpace(mat3{
+		{0.6369580483012914, 0.14461690358620832, 0.1688809751641721},
+		{0.2627002120112671, 0.6779980715188708, 0.05930171646986196},
+		{0.0, 0.028072693049087428, 1.060985057710791},
+	}, mirrored(func(v float64) float64 {
+		const alpha, beta = 1.09929682680944, 0.018053968510807
+		if v < beta*4.5 {
+			return v / 4.5
+		}
+		return math.Pow((v+alpha-1.0)/alpha, 1.0/0.45)
+	}), mirrored(func(v float64) float64 {
+		const alpha, beta = 1.09929682680944, 0.018053968510807
+		if v < beta {
+			return 4.5 * v
+		}
+		return alpha*math.Pow(v, 0.45) - (alpha - 1.0)
+	}))
+)
+
+// The RGB spaces known to the package, which are those of the CSS color()
+// function.
+var (
+	SRGB        = srgbSpace
+	LinearSRGB  = srgbLinearSpace
+	DisplayP3   = displayP3Space
+	A98RGB      = a98RgbSpace
+	ProPhotoRGB = prophotoRgbSpace
+	Rec2020     = rec2020Space
+)
+
+// LookupRGBSpace returns the RGB space of the given name, as in the CSS
+// color() function, such as "display-p3" or "rec2020".
+func LookupRGBSpace(name string) (*RGBSpace, bool) {
+	s, ok := cssColorSpaces[strings.ToLower(name)]
+	return s, ok
+}
+
+// Color converts the encoded values of the space, usually in [0..1], to a
+// Color, which is out of the sRGB gamut for many colors of wide gamut spaces.
+func (s *RGBSpace) Color(r, g, b float64) Color {
+	return s.toColor(r, g, b)
+}
+
+// Values converts the color to encoded values of the space, which are in
+// [0..1] if it is in the space's gamut.
+func (s *RGBSpace) Values(col Color) (r, g, b float64) {
+	return s.fromColor(col)
+}
+
+// Contains reports whether the color is within the gamut of the space,
+// allowing for rounding noise.
+func (s *RGBSpace) Contains(col Color) bool {
+	const eps = 1e-9
+	r, g, b := s.fromColor(col)
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+}
+
+// clip clips the values of the color in the space to [0..1].
+func (s *RGBSpace) clip(col Color) Color {
+	r, g, b := s.fromColor(col)
+	return s.toColor(clamp01(r), clamp01(g), clamp01(b))
+}
+
+// IsValidIn is like IsValid, but checks whether the color is representable
+// in the given RGB space, such as DisplayP3, rather than sRGB.
+func (col Color) IsValidIn(s *RGBSpace) bool {
+	if s == SRGB {
+		return col.IsValid()
+	}
+	return s.Contains(col)
+}
+
+// ClampedIn is like Clamped, but clips the values of the color in the given
+// RGB space, so the result is in its gamut but may be outside of sRGB's.
+func (col Color) ClampedIn(s *RGBSpace) Color {
+	if s == SRGB {
+		return col.Clamped()
+	}
+	return s.clip(col)
+}
+
+// FitsInSRGB reports whether the color, which may have been converted from a
+// wide gamut space, is representable in sRGB, ignoring the rounding noise of
+// the conversion which would make IsValid fail.
+func (col Color) FitsInSRGB() bool {
+	return SRGB.Contains(col)
+}
+
+// DisplayP3ToSRGB converts the values of a Display P3 color to a valid sRGB
+// color. Colors outside of sRGB are mapped perceptually with ToGamut, keeping
+// their hue and lightness and as much saturation as possible, rather than
+// being clipped.
+func DisplayP3ToSRGB(r, g, b float64) Color {
+	return DisplayP3.Color(r, g, b).ToGamut()
+}
+
+// DisplayP3 returns the values of the color in Display P3, each in [0..1].
+// Every sRGB color fits into P3, colors outside of it are mapped perceptually
+// with ToGamutIn.
+func (col Color) DisplayP3() (r, g, b float64) {
+	r, g, b = DisplayP3.Values(col.ToGamutIn(DisplayP3))
+	return clamp01(r), clamp01(g), clamp01(b)
+}
+
+// A GamutFit tells whether a color is within the gamut of an RGB space.
+type GamutFit struct {
+	// Name is the name of the space, as in LookupRGBSpace.
+	Name  string
+	Space *RGBSpace
+	// Contains tells whether the color is within the gamut.
+	Contains bool
+	// Headroom is the OkLch chroma by which the color could grow at the same
+	// hue and lightness and stay within the gamut. It is negative for colors
+	// outside of it, telling how much chroma they need to lose.
+	Headroom float64
+}
+
+// Representability reports for the standard gamuts of sRGB, Display P3,
+// Adobe RGB (1998) and Rec. 2020 whether they contain the color, which may
+// be constructed from Lab, OkLab or any LCh, and how much chroma headroom it
+// has in each.
+func (col Color) Representability() []GamutFit {
+	l, c, h := col.OkLch()
+	fits := []GamutFit{
+		{Name: "srgb", Space: SRGB},
+		{Name: "display-p3", Space: DisplayP3},
+		{Name: "a98-rgb", Space: A98RGB},
+		{Name: "rec2020", Space: Rec2020},
+	}
+	for i := range fits {
+		fits[i].Contains = fits[i].Space.Contains(col)
+		fits[i].Headroom = maxChroma(h, l, SpaceOkLch, fits[i].Space) - c
+	}
+	return fits
+}
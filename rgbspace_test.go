@@ -0,0 +1,107 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRGBSpaceGamut(t *testing.T) {
+	// Display P3 red is outside of sRGB but inside of P3.
+	p3red := DisplayP3.Color(1, 0, 0)
+	if p3red.IsValid() || p3red.IsValidIn(SRGB) {
+		t.Errorf("P3 red %v is valid in sRGB", p3red)
+	}
+	if !p3red.IsValidIn(DisplayP3) {
+		t.Errorf("P3 red %v is invalid in P3", p3red)
+	}
+	if r, g, b := DisplayP3.Values(p3red); !almosteq(r, 1) || !almosteq(g, 0) || !almosteq(b, 0) {
+		t.Errorf("DisplayP3.Values(P3 red) => (%v, %v, %v), want (1, 0, 0)", r, g, b)
+	}
+
+	// Rec. 2020 green is outside of P3, clipping brings it into P3 only.
+	green := Rec2020.Color(0, 1, 0)
+	clipped := green.ClampedIn(DisplayP3)
+	if green.IsValidIn(DisplayP3) || !clipped.IsValidIn(DisplayP3) || clipped.IsValid() {
+		t.Errorf("%v.ClampedIn(DisplayP3) => %v", green, clipped)
+	}
+	if got := green.ClampedIn(SRGB); got != green.Clamped() {
+		t.Errorf("ClampedIn(SRGB) => %v, want %v", got, green.Clamped())
+	}
+
+	mapped := green.ToGamutIn(DisplayP3)
+	if !mapped.IsValidIn(DisplayP3) {
+		t.Errorf("%v.ToGamutIn(DisplayP3) => %v, which is invalid in P3", green, mapped)
+	}
+	if _, c1, _ := mapped.OkLch(); c1 <= MaxChroma(142, 0.5, SpaceOkLch, "") {
+		t.Errorf("%v.ToGamutIn(DisplayP3) => chroma %v, which sRGB would fit", green, c1)
+	}
+
+	for _, name := range []string{"srgb", "Display-P3", "rec2020"} {
+		if _, ok := LookupRGBSpace(name); !ok {
+			t.Errorf("LookupRGBSpace(%q) failed", name)
+		}
+	}
+	if s, ok := LookupRGBSpace("display-p3"); !ok || s != DisplayP3 {
+		t.Errorf("LookupRGBSpace(display-p3) => %p, want DisplayP3", s)
+	}
+	if _, ok := LookupRGBSpace("cmyk"); ok {
+		t.Errorf("LookupRGBSpace(cmyk) succeeded")
+	}
+}
+
+func TestDisplayP3Mapping(t *testing.T) {
+	for _, c := range randomColors(20) {
+		r, g, b := c.DisplayP3()
+		if got := DisplayP3ToSRGB(r, g, b); got.DistanceRgb(c) > 1e-6 {
+			t.Errorf("DisplayP3ToSRGB(%v.DisplayP3()) => %v", c, got)
+		}
+		if !c.FitsInSRGB() {
+			t.Errorf("%v.FitsInSRGB() => false", c)
+		}
+	}
+
+	p3red := DisplayP3.Color(1, 0, 0)
+	if p3red.FitsInSRGB() {
+		t.Errorf("P3 red fits into sRGB")
+	}
+	srgb := DisplayP3ToSRGB(1, 0, 0)
+	if !srgb.IsValid() || srgb.DistanceOkLab(p3red) > p3red.Clamped().DistanceOkLab(p3red)+0.02 {
+		t.Errorf("DisplayP3ToSRGB(1, 0, 0) => %v, from %v", srgb, p3red)
+	}
+	if r, g, b := p3red.DisplayP3(); !almosteq(r, 1) || !almosteq(g, 0) || !almosteq(b, 0) {
+		t.Errorf("P3 red's DisplayP3() => (%v, %v, %v), want (1, 0, 0)", r, g, b)
+	}
+
+	// Slightly out of gamut values due to rounding still fit.
+	if !(Color{1 + 1e-12, 0.5, -1e-12}).FitsInSRGB() {
+		t.Errorf("FitsInSRGB doesn't allow for rounding noise")
+	}
+}
+
+func TestRepresentability(t *testing.T) {
+	p3red := DisplayP3.Color(1, 0, 0)
+	fits := p3red.Representability()
+	// P3 red is slightly more yellow than the line between the Rec. 2020 red
+	// and green primaries, and thus not in Rec. 2020 either.
+	want := []bool{false, true, false, false}
+	if len(fits) != len(want) {
+		t.Fatalf("Representability() => %v gamuts, want %v", len(fits), len(want))
+	}
+	for i, fit := range fits {
+		if fit.Contains != want[i] {
+			t.Errorf("%v. Representability() of P3 red => %v contains %v, want %v", i, fit.Name, fit.Contains, want[i])
+		}
+		if fit.Contains != (fit.Headroom >= -1e-6) {
+			t.Errorf("%v. Representability() of P3 red => %v headroom %v, contains %v", i, fit.Name, fit.Headroom, fit.Contains)
+		}
+	}
+	if math.Abs(fits[1].Headroom) > 1e-6 {
+		t.Errorf("Representability() of P3 red => P3 headroom %v, want 0", fits[1].Headroom)
+	}
+
+	for _, fit := range Hcl(30, 0.1, 0.5).Representability() {
+		if !fit.Contains || fit.Headroom <= 0 {
+			t.Errorf("Representability() of a muted color => %v contains %v with headroom %v", fit.Name, fit.Contains, fit.Headroom)
+		}
+	}
+}
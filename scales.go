@@ -0,0 +1,110 @@
+// Monochromatic scales of a color, such as those of design tokens.
+
+package colorful
+
+// scale returns n colors blending from the color towards the target in OkLab,
+// starting with the color itself and stopping one step short of the target.
+func (col Color) scale(n int, target Color) []Color {
+	if n < 1 {
+		return nil
+	}
+	colors := make([]Color, n)
+	colors[0] = col
+	for i := 1; i < n; i++ {
+		colors[i] = col.BlendOkLab(target, float64(i)/float64(n)).ClampedOkLch()
+	}
+	return colors
+}
+
+// Shades returns n increasingly dark colors, blending from the color towards
+// black in OkLab, which makes their OkLch lightness fall in even steps. The
+// first is the color itself, black itself isn't included.
+func (col Color) Shades(n int) []Color {
+	return col.scale(n, Color{0, 0, 0})
+}
+
+// Tints is like Shades, but blends towards white.
+func (col Color) Tints(n int) []Color {
+	return col.scale(n, Color{1, 1, 1})
+}
+
+// Tones is like Shades, but blends towards the gray of the same OkLch
+// lightness, such that lightness stays the same and chroma falls in even
+// steps.
+func (col Color) Tones(n int) []Color {
+	l, _, _ := col.OkLab()
+	return col.scale(n, OkLab(l, 0, 0))
+}
+
+/// Mixing ///
+//////////////
+
+// midGray is the middle gray of CIE L* 50, reflecting about 18% of light.
+var midGray = Lab(0.5, 0, 0)
+
+// mix blends the color towards the target using blend, or BlendOkLab if nil.
+func (col Color) mix(target Color, t float64, blend func(c1, c2 Color, t float64) Color) Color {
+	if blend == nil {
+		blend = Color.BlendOkLab
+	}
+	return blend(col, target, t).Clamped()
+}
+
+// Tint mixes the color with the sRGB white by the fraction t, where 0 returns
+// the color and 1 white. The mix is done by blend, such as Color.BlendLab,
+// where nil means Color.BlendOkLab, as Tints does.
+func (col Color) Tint(t float64, blend func(c1, c2 Color, t float64) Color) Color {
+	return col.mix(Color{1, 1, 1}, t, blend)
+}
+
+// Shade is like Tint, but mixes the color with black.
+func (col Color) Shade(t float64, blend func(c1, c2 Color, t float64) Color) Color {
+	return col.mix(Color{0, 0, 0}, t, blend)
+}
+
+// Tone is like Tint, but mixes the color with the middle gray of CIE L* 50,
+// which is #777777 and, unlike #808080, looks halfway between black and
+// white. Unlike Tones, it thus changes the lightness of most colors.
+func (col Color) Tone(t float64, blend func(c1, c2 Color, t float64) Color) Color {
+	return col.mix(midGray, t, blend)
+}
+
+/// Tonal palettes ///
+//////////////////////
+
+// MaterialTones are the tones of the tonal palettes of Material Design.
+var MaterialTones = []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 95, 99, 100}
+
+// A TonalPalette holds the hue and chroma of a seed color, and produces
+// colors of any tone from them, as in the color schemes of Material Design.
+// Tones are CIE L* lightnesses from 0 (black) to 100 (white), such that equal
+// differences of tone give the same contrast whatever the hue.
+type TonalPalette struct {
+	// Hue and Chroma are those of the seed color in HCL space.
+	Hue, Chroma float64
+}
+
+// NewTonalPalette creates the tonal palette of the seed color.
+func NewTonalPalette(seed Color) TonalPalette {
+	h, c, _ := seed.Hcl()
+	return TonalPalette{Hue: h, Chroma: c}
+}
+
+// Tone returns the color of the given tone in [0..100], with as much of the
+// palette's chroma as fits into the gamut at that tone.
+func (p TonalPalette) Tone(tone float64) Color {
+	return Hcl(p.Hue, p.Chroma, tone/100.0).ClampedHcl()
+}
+
+// Tones returns the colors of the given tones, or of MaterialTones if none are
+// given.
+func (p TonalPalette) Tones(tones ...float64) []Color {
+	if len(tones) == 0 {
+		tones = MaterialTones
+	}
+	colors := make([]Color, len(tones))
+	for i, tone := range tones {
+		colors[i] = p.Tone(tone)
+	}
+	return colors
+}
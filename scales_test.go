@@ -0,0 +1,95 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScales(t *testing.T) {
+	col := MustHex("#3a7bd5")
+	l0, c0, h0 := col.OkLch()
+
+	for _, tt := range []struct {
+		name   string
+		colors []Color
+		target float64
+	}{
+		{"Shades", col.Shades(5), 0},
+		{"Tints", col.Tints(5), 1},
+	} {
+		if len(tt.colors) != 5 || tt.colors[0] != col {
+			t.Errorf("%v(5) => %v, want 5 colors starting with %v", tt.name, tt.colors, col)
+			continue
+		}
+		for i, c := range tt.colors {
+			l, _, _ := c.OkLch()
+			if want := l0 + (tt.target-l0)*float64(i)/5; math.Abs(l-want) > 1e-3 {
+				t.Errorf("%v(5)[%v] => OkLch lightness %v, want %v", tt.name, i, l, want)
+			}
+		}
+	}
+
+	for i, c := range col.Tones(4) {
+		l, ch, h := c.OkLch()
+		if want := c0 * (1 - float64(i)/4); math.Abs(l-l0) > 1e-6 || math.Abs(ch-want) > 1e-6 || hueDiff(h, h0) > 0.01 {
+			t.Errorf("Tones(4)[%v] => OkLch (%v, %v, %v), want (%v, %v, %v)", i, l, ch, h, l0, want, h0)
+		}
+	}
+
+	if got := col.Shades(0); got != nil {
+		t.Errorf("Shades(0) => %v, want nil", got)
+	}
+}
+
+func TestTonalPalette(t *testing.T) {
+	seed := MustHex("#6750a4")
+	h0, c0, l0 := seed.Hcl()
+	p := NewTonalPalette(seed)
+
+	colors := p.Tones()
+	if len(colors) != len(MaterialTones) {
+		t.Fatalf("Tones() => %v colors, want %v", len(colors), len(MaterialTones))
+	}
+	for i, c := range colors {
+		h, ch, l := c.Hcl()
+		if !c.IsValid() || math.Abs(l-MaterialTones[i]/100) > 1e-3 || ch > c0+1e-6 {
+			t.Errorf("%v. Tones() => %v, HCL (%v, %v, %v), want tone %v", i, c, h, ch, l, MaterialTones[i])
+		}
+		if ch > 0.05 && hueDiff(h, h0) > 0.5 {
+			t.Errorf("%v. Tones() => hue %v, want %v", i, h, h0)
+		}
+	}
+	if !colors[0].AlmostEqualRgb(Color{0, 0, 0}) || !colors[len(colors)-1].AlmostEqualRgb(Color{1, 1, 1}) {
+		t.Errorf("Tones() => %v ... %v, want black ... white", colors[0], colors[len(colors)-1])
+	}
+
+	if got := p.Tone(l0 * 100); !got.AlmostEqualRgb(seed) {
+		t.Errorf("Tone(%v) => %v, want the seed %v", l0*100, got, seed)
+	}
+}
+
+func TestMixers(t *testing.T) {
+	col := MustHex("#3a7bd5")
+	white, black := Color{1, 1, 1}, Color{0, 0, 0}
+	for _, blend := range []func(c1, c2 Color, t float64) Color{nil, Color.BlendRgb, Color.BlendLab} {
+		if got := col.Tint(0, blend); !got.AlmostEqualRgb(col) {
+			t.Errorf("Tint(0) => %v, want %v", got, col)
+		}
+		if got := col.Tint(1, blend); !got.AlmostEqualRgb(white) {
+			t.Errorf("Tint(1) => %v, want white", got)
+		}
+		if got := col.Shade(1, blend); !got.AlmostEqualRgb(black) {
+			t.Errorf("Shade(1) => %v, want black", got)
+		}
+		if got := col.Tone(1, blend); got.Hex() != "#777777" {
+			t.Errorf("Tone(1) => %v, want #777777", got.Hex())
+		}
+	}
+
+	if got, want := col.Shade(0.4, nil), col.Shades(5)[2]; !got.AlmostEqualRgb(want) {
+		t.Errorf("Shade(0.4, nil) => %v, want %v like Shades", got, want)
+	}
+	if got, want := col.Tint(0.5, Color.BlendRgb), col.BlendRgb(white, 0.5); !got.AlmostEqualRgb(want) {
+		t.Errorf("Tint(0.5, BlendRgb) => %v, want %v", got, want)
+	}
+}
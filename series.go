@@ -0,0 +1,102 @@
+// Categorical colors for the series of charts.
+
+package colorful
+
+import "math"
+
+// SeriesOptions configure SeriesColors.
+type SeriesOptions struct {
+	// MinLightness and MaxLightness bound the OkLch lightness of the colors,
+	// zero means 0.5 and 0.78, which keeps them readable as lines on both
+	// white and black backgrounds.
+	MinLightness, MaxLightness float64
+	// Chroma is the OkLch chroma the colors have where the gamut allows it.
+	// Zero means 0.14.
+	Chroma float64
+	// CVDSafe keeps the colors apart for people with protanopia and
+	// deuteranopia as well, at the cost of less distinct colors for others.
+	CVDSafe bool
+}
+
+// The hue of the first series color, a blue.
+const seriesStartHue = 255.0
+
+// candidates returns the colors SeriesColors picks from, on a grid of
+// three lightnesses and 72 hues.
+func (opts SeriesOptions) candidates() []Color {
+	lo, hi, chroma := opts.MinLightness, opts.MaxLightness, opts.Chroma
+	if lo == 0 {
+		lo = 0.5
+	}
+	if hi == 0 {
+		hi = 0.78
+	}
+	if chroma == 0 {
+		chroma = 0.14
+	}
+
+	var cands []Color
+	for _, l := range []float64{(lo + hi) / 2, lo, hi} {
+		for i := 0; i < 72; i++ {
+			h := math.Mod(seriesStartHue+5*float64(i), 360)
+			cands = append(cands, OkLch(l, chroma, h).ClampedOkLch())
+		}
+	}
+	return cands
+}
+
+// SeriesColors returns n distinct colors for the series of line and bar
+// charts. The first one is a blue of medium lightness, and each following one
+// is the one of a fixed set of candidates of balanced lightness and chroma
+// farthest in OkLab from all previous ones, so the first colors stay the
+// same as n grows, and adding a series doesn't change the existing ones.
+//
+// There are 216 candidates, so at most that many colors are distinct, and
+// beyond that the sequence repeats from its start. Long before, neighboring
+// colors become hard to tell apart, so charts with many series are better
+// served by labels or grouping.
+func SeriesColors(n int, opts SeriesOptions) []Color {
+	if n <= 0 {
+		return nil
+	}
+	cands := opts.candidates()
+
+	// The views of each candidate to keep apart, normal and simulated.
+	views := make([][]vec3[float64], len(cands))
+	for i, c := range cands {
+		views[i] = append(views[i], c.okLab())
+		if opts.CVDSafe {
+			views[i] = append(views[i], c.SimulateCVD(Protan, 1).okLab(), c.SimulateCVD(Deutan, 1).okLab())
+		}
+	}
+
+	// The smallest distance of each candidate to the colors picked so far.
+	mindist := make([]float64, len(cands))
+	for i := range mindist {
+		mindist[i] = math.Inf(1)
+	}
+	colors := make([]Color, 0, n)
+	pick := 0
+	for len(colors) < n && len(colors) < len(cands) {
+		colors = append(colors, cands[pick])
+		for i := range cands {
+			for k, v := range views[i] {
+				if d := sqdist(v, views[pick][k]); d < mindist[i] {
+					mindist[i] = d
+				}
+			}
+		}
+		// Of equally far candidates, the first one wins, keeping the order
+		// deterministic.
+		pick = 0
+		for i, d := range mindist {
+			if d > mindist[pick] {
+				pick = i
+			}
+		}
+	}
+	for len(colors) < n {
+		colors = append(colors, colors[len(colors)-len(cands)])
+	}
+	return colors
+}
@@ -0,0 +1,74 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+// minPairDistance returns the smallest OkLab distance of two colors of the
+// palette after applying view to them.
+func minPairDistance(palette []Color, view func(Color) Color) float64 {
+	min := math.Inf(1)
+	for i := range palette {
+		for j := i + 1; j < len(palette); j++ {
+			min = math.Min(min, view(palette[i]).DistanceOkLab(view(palette[j])))
+		}
+	}
+	return min
+}
+
+func TestSeriesColors(t *testing.T) {
+	colors := SeriesColors(12, SeriesOptions{})
+	if len(colors) != 12 {
+		t.Fatalf("SeriesColors(12) => %v colors", len(colors))
+	}
+
+	// Adding series keeps the existing colors.
+	for n := 1; n < 12; n++ {
+		for i, c := range SeriesColors(n, SeriesOptions{}) {
+			if c != colors[i] {
+				t.Errorf("SeriesColors(%v)[%v] => %v, but SeriesColors(12)[%v] => %v", n, i, c, i, colors[i])
+			}
+		}
+	}
+
+	for i, c := range colors {
+		if l, _, _ := c.OkLch(); !c.IsValid() || l < 0.5-1e-6 || l > 0.78+1e-6 {
+			t.Errorf("SeriesColors(12)[%v] => %v with lightness %v", i, c, l)
+		}
+	}
+	same := func(c Color) Color { return c }
+	if d := minPairDistance(colors[:8], same); d < 0.08 {
+		t.Errorf("SeriesColors(8) => colors only %v apart", d)
+	}
+
+	// CVD safe colors stay further apart for deuteranopes.
+	deutan := func(c Color) Color { return c.SimulateCVD(Deutan, 1) }
+	safe := SeriesColors(6, SeriesOptions{CVDSafe: true})
+	if d, d0 := minPairDistance(safe, deutan), minPairDistance(colors[:6], deutan); d <= d0 {
+		t.Errorf("SeriesColors(6, CVDSafe) => colors %v apart for deuteranopes, want more than %v", d, d0)
+	}
+
+	if got := SeriesColors(0, SeriesOptions{}); got != nil {
+		t.Errorf("SeriesColors(0) => %v, want nil", got)
+	}
+}
+
+func TestSeriesColorsBeyondCandidates(t *testing.T) {
+	colors := SeriesColors(300, SeriesOptions{})
+	if len(colors) != 300 {
+		t.Fatalf("SeriesColors(300) => %v colors", len(colors))
+	}
+	seen := map[Color]int{}
+	for i, c := range colors[:216] {
+		if j, ok := seen[c]; ok {
+			t.Errorf("colors %v and %v are both %v", j, i, c)
+		}
+		seen[c] = i
+	}
+	for i := 216; i < 300; i++ {
+		if colors[i] != colors[i-216] {
+			t.Errorf("color %v => %v, want it to repeat color %v, %v", i, colors[i], i-216, colors[i-216])
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Batch conversions of slices of colors. They loop over flat slices such
+// that the compiler can eliminate bounds checks and inline the conversions,
+// and leave room for vectorizing them in the future.
+
+package colorful
+
+func checkSliceLens(ndst int, nsrc ...int) {
+	for _, n := range nsrc {
+		if n != ndst {
+			panic("colorful: slice lengths don't match")
+		}
+	}
+}
+
+func (col Color) linearRgb() vec3[float64] {
+	return vec3[float64]{linearize(col.R), linearize(col.G), linearize(col.B)}
+}
+
+func fromLinearRgb(v vec3[float64]) Color {
+	return Color{delinearize(v[0]), delinearize(v[1]), delinearize(v[2])}
+}
+
+func (col Color) lab() vec3[float64] {
+	l, a, b := col.Lab()
+	return vec3[float64]{l, a, b}
+}
+
+func fromLab(v vec3[float64]) Color {
+	return Lab(v[0], v[1], v[2])
+}
+
+func (col Color) okLab() vec3[float64] {
+	l, a, b := col.OkLab()
+	return vec3[float64]{l, a, b}
+}
+
+func fromOkLab(v vec3[float64]) Color {
+	return OkLab(v[0], v[1], v[2])
+}
+
+// LabSlice converts all colors of src to CIE L*a*b* (D65) into dst, which
+// needs to have the same length.
+func LabSlice(dst []LabColor, src []Color) {
+	checkSliceLens(len(dst), len(src))
+	src = src[:len(dst)]
+	for i := range dst {
+		v := src[i].lab()
+		dst[i] = LabColor{v[0], v[1], v[2]}
+	}
+}
+
+// FromLabSlice is the inverse of LabSlice.
+func FromLabSlice(dst []Color, src []LabColor) {
+	checkSliceLens(len(dst), len(src))
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] = fromLab(vec3[float64]{src[i].L, src[i].A, src[i].B})
+	}
+}
+
+// OkLabSlice converts all colors of src to OkLab into dst, which needs to
+// have the same length.
+func OkLabSlice(dst []OkLabColor, src []Color) {
+	checkSliceLens(len(dst), len(src))
+	src = src[:len(dst)]
+	for i := range dst {
+		v := src[i].okLab()
+		dst[i] = OkLabColor{v[0], v[1], v[2]}
+	}
+}
+
+// FromOkLabSlice is the inverse of OkLabSlice.
+func FromOkLabSlice(dst []Color, src []OkLabColor) {
+	checkSliceLens(len(dst), len(src))
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] = fromOkLab(vec3[float64]{src[i].L, src[i].A, src[i].B})
+	}
+}
+
+// DistanceLabSlice computes the Lab distance of each pair of colors of c1
+// and c2 into dst, see Color.DistanceLab. All slices need to have the same
+// length.
+func DistanceLabSlice(dst []float64, c1, c2 []Color) {
+	checkSliceLens(len(dst), len(c1), len(c2))
+	c1, c2 = c1[:len(dst)], c2[:len(dst)]
+	for i := range dst {
+		dst[i] = c1[i].lab().dist(c2[i].lab())
+	}
+}
+
+// BlendLinearRgbSlice blends each pair of colors of c1 and c2 in linear RGB
+// into dst, see Color.BlendLinearRgb. All slices need to have the same
+// length, and dst may be c1 or c2.
+func BlendLinearRgbSlice(dst, c1, c2 []Color, t float64) {
+	checkSliceLens(len(dst), len(c1), len(c2))
+	c1, c2 = c1[:len(dst)], c2[:len(dst)]
+	for i := range dst {
+		dst[i] = fromLinearRgb(c1[i].linearRgb().lerp(c2[i].linearRgb(), t))
+	}
+}
+
+// BlendLabSlice blends each pair of colors of c1 and c2 in CIE L*a*b* into
+// dst, see Color.BlendLab. All slices need to have the same length, and dst
+// may be c1 or c2.
+func BlendLabSlice(dst, c1, c2 []Color, t float64) {
+	checkSliceLens(len(dst), len(c1), len(c2))
+	c1, c2 = c1[:len(dst)], c2[:len(dst)]
+	for i := range dst {
+		dst[i] = fromLab(c1[i].lab().lerp(c2[i].lab(), t))
+	}
+}
+
+// BlendOkLabSlice blends each pair of colors of c1 and c2 in OkLab into dst,
+// see Color.BlendOkLab. All slices need to have the same length, and dst may
+// be c1 or c2.
+func BlendOkLabSlice(dst, c1, c2 []Color, t float64) {
+	checkSliceLens(len(dst), len(c1), len(c2))
+	c1, c2 = c1[:len(dst)], c2[:len(dst)]
+	for i := range dst {
+		dst[i] = fromOkLab(c1[i].okLab().lerp(c2[i].okLab(), t))
+	}
+}
@@ -0,0 +1,95 @@
+package colorful
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomColors(n int) []Color {
+	cols := make([]Color, n)
+	for i := range cols {
+		cols[i] = Color{rand.Float64(), rand.Float64(), rand.Float64()}
+	}
+	return cols
+}
+
+func TestSlices(t *testing.T) {
+	rand.Seed(3)
+	c1, c2 := randomColors(100), randomColors(100)
+
+	labs := make([]LabColor, len(c1))
+	LabSlice(labs, c1)
+	oklabs := make([]OkLabColor, len(c1))
+	OkLabSlice(oklabs, c1)
+	dists := make([]float64, len(c1))
+	DistanceLabSlice(dists, c1, c2)
+	cols := make([]Color, len(c1))
+
+	for i, c := range c1 {
+		if want := c.LabColor(); !almosteq(labs[i].L, want.L) || !almosteq(labs[i].A, want.A) || !almosteq(labs[i].B, want.B) {
+			t.Errorf("%v. LabSlice => %v, want %v", i, labs[i], want)
+		}
+		if want := c.OkLabColor(); !almosteq(oklabs[i].L, want.L) || !almosteq(oklabs[i].A, want.A) || !almosteq(oklabs[i].B, want.B) {
+			t.Errorf("%v. OkLabSlice => %v, want %v", i, oklabs[i], want)
+		}
+		if want := c.DistanceLab(c2[i]); !almosteq(dists[i], want) {
+			t.Errorf("%v. DistanceLabSlice => %v, want %v", i, dists[i], want)
+		}
+	}
+
+	FromLabSlice(cols, labs)
+	for i := range cols {
+		if !cols[i].AlmostEqualRgb(c1[i]) {
+			t.Errorf("%v. FromLabSlice => %v, want %v", i, cols[i], c1[i])
+		}
+	}
+	FromOkLabSlice(cols, oklabs)
+	for i := range cols {
+		if !cols[i].AlmostEqualRgb(c1[i]) {
+			t.Errorf("%v. FromOkLabSlice => %v, want %v", i, cols[i], c1[i])
+		}
+	}
+
+	blends := []struct {
+		name  string
+		slice func(dst, c1, c2 []Color, t float64)
+		blend func(c1, c2 Color, t float64) Color
+	}{
+		{"BlendLinearRgbSlice", BlendLinearRgbSlice, Color.BlendLinearRgb},
+		{"BlendLabSlice", BlendLabSlice, Color.BlendLab},
+		{"BlendOkLabSlice", BlendOkLabSlice, Color.BlendOkLab},
+	}
+	for _, b := range blends {
+		b.slice(cols, c1, c2, 0.3)
+		for i := range cols {
+			if want := b.blend(c1[i], c2[i], 0.3); !cols[i].AlmostEqualRgb(want) {
+				t.Errorf("%v. %v => %v, want %v", i, b.name, cols[i], want)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BlendLabSlice with different lengths didn't panic")
+		}
+	}()
+	BlendLabSlice(cols, c1, c2[1:], 0.5)
+}
+
+func BenchmarkLabSlice(bench *testing.B) {
+	src := randomColors(1024)
+	dst := make([]LabColor, len(src))
+	for n := 0; n < bench.N; n++ {
+		LabSlice(dst, src)
+	}
+}
+
+func BenchmarkLabLoop(bench *testing.B) {
+	src := randomColors(1024)
+	dst := make([]LabColor, len(src))
+	for n := 0; n < bench.N; n++ {
+		for i, c := range src {
+			dst[i] = c.LabColor()
+		}
+	}
+}
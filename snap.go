@@ -0,0 +1,54 @@
+// Snapping colors to small fixed palettes, such as the web-safe colors.
+
+package colorful
+
+import "math"
+
+// WebSafePalette returns the 216 web-safe colors, those whose channels are
+// all multiples of 0x33, ordered by red, then green, then blue.
+func WebSafePalette() []Color {
+	palette := make([]Color, 0, 216)
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				palette = append(palette, Color{float64(r) / 5.0, float64(g) / 5.0, float64(b) / 5.0})
+			}
+		}
+	}
+	return palette
+}
+
+// NearestWebSafe returns the web-safe color closest to col in RGB, by
+// rounding each channel to the nearest multiple of 0x33, halves rounding up.
+// For the perceptually closest one, use
+// col.SnapTo(WebSafePalette(), Color.DistanceCIEDE2000) instead.
+func (col Color) NearestWebSafe() Color {
+	c := col.Clamped()
+	return Color{
+		math.Floor(c.R*5.0+0.5) / 5.0,
+		math.Floor(c.G*5.0+0.5) / 5.0,
+		math.Floor(c.B*5.0+0.5) / 5.0,
+	}
+}
+
+// SnapTo returns the color of the palette closest to col according to the
+// given distance metric, where nil means Color.DistanceCIEDE2000. Of several
+// equally close colors, the first one in the palette is returned, so results
+// don't depend on anything but the order of the palette. It panics if the
+// palette is empty.
+func (col Color) SnapTo(palette []Color, metric func(c1, c2 Color) float64) Color {
+	if len(palette) == 0 {
+		panic("colorful: cannot snap to an empty palette")
+	}
+	if metric == nil {
+		metric = Color.DistanceCIEDE2000
+	}
+
+	best, bestdist := 0, metric(col, palette[0])
+	for i := 1; i < len(palette); i++ {
+		if d := metric(col, palette[i]); d < bestdist {
+			best, bestdist = i, d
+		}
+	}
+	return palette[best]
+}
@@ -0,0 +1,60 @@
+package colorful
+
+import "testing"
+
+func TestNearestWebSafe(t *testing.T) {
+	palette := WebSafePalette()
+	if len(palette) != 216 || palette[0].Hex() != "#000000" || palette[215].Hex() != "#ffffff" {
+		t.Errorf("WebSafePalette() => %v colors from %v to %v", len(palette), palette[0].Hex(), palette[len(palette)-1].Hex())
+	}
+
+	tests := []struct {
+		hex  string
+		want string
+	}{
+		{"#000000", "#000000"},
+		{"#ff0000", "#ff0000"},
+		{"#3a7bd5", "#3366cc"},
+		{"#1a1a1a", "#333333"}, // Just past halfway between 0x00 and 0x33.
+		{"#191919", "#000000"},
+		{"#fefefe", "#ffffff"},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.hex).NearestWebSafe().Hex(); got != tt.want {
+			t.Errorf("%v. %v.NearestWebSafe() => %v, want %v", i, tt.hex, got, tt.want)
+		}
+	}
+
+	// The nearest in RGB is the nearest of the palette in RGB.
+	for _, c := range randomColors(20) {
+		if got, want := c.NearestWebSafe(), c.SnapTo(palette, Color.DistanceRgb); !got.AlmostEqualRgb(want) {
+			t.Errorf("%v.NearestWebSafe() => %v, want %v", c, got, want)
+		}
+	}
+}
+
+func TestSnapTo(t *testing.T) {
+	palette := []Color{MustHex("#000000"), MustHex("#ffffff"), MustHex("#ff0000")}
+	tests := []struct {
+		hex  string
+		want string
+	}{
+		{"#202020", "#000000"},
+		{"#e0e0e0", "#ffffff"},
+		{"#c03030", "#ff0000"},
+	}
+	for i, tt := range tests {
+		if got := MustHex(tt.hex).SnapTo(palette, nil).Hex(); got != tt.want {
+			t.Errorf("%v. %v.SnapTo() => %v, want %v", i, tt.hex, got, tt.want)
+		}
+	}
+
+	// Ties go to the first color of the palette.
+	gray := Color{0.5, 0.5, 0.5}
+	if got := gray.SnapTo([]Color{{1, 1, 1}, {0, 0, 0}}, Color.DistanceRgb); got != (Color{1, 1, 1}) {
+		t.Errorf("%v.SnapTo(white, black) => %v, want white", gray, got)
+	}
+	if got := gray.SnapTo([]Color{{0, 0, 0}, {1, 1, 1}}, Color.DistanceRgb); got != (Color{0, 0, 0}) {
+		t.Errorf("%v.SnapTo(black, white) => %v, want black", gray, got)
+	}
+}
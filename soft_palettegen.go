@@ -33,52 +33,104 @@ type SoftPaletteSettings struct {
 // happens to fall outside of the color-space, which can only happen if you
 // specify a CheckColor function.
 func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, error) {
+	colors := make([]Color, colorsCount)
+	if err := NewSoftPaletteGenerator(settings).GenerateInto(colors); err != nil {
+		return nil, err
+	}
+	return colors, nil
+}
+
+// A SoftPaletteGenerator generates palettes like SoftPaletteEx, but keeps the
+// samples of the color-space and all other buffers around, such that
+// generating many palettes, for example one per frame of an animation,
+// doesn't allocate. It is not safe for concurrent use.
+type SoftPaletteGenerator struct {
+	settings SoftPaletteSettings
+
+	samples      []lab_t
+	sampled      bool
+	means        []lab_t
+	clusters     []int
+	samples_used []bool
+	sums         []lab_t
+	counts       []int
+}
+
+// NewSoftPaletteGenerator creates a generator using the given settings.
+func NewSoftPaletteGenerator(settings SoftPaletteSettings) *SoftPaletteGenerator {
+	return &SoftPaletteGenerator{settings: settings}
+}
 
-	// Checks whether it's a valid RGB and also fulfills the potentially provided constraint.
-	check := func(col lab_t) bool {
-		c := Lab(col.L, col.A, col.B)
-		return c.IsValid() && (settings.CheckColor == nil || settings.CheckColor(col.L, col.A, col.B))
+// Checks whether it's a valid RGB and also fulfills the potentially provided constraint.
+func (g *SoftPaletteGenerator) check(col lab_t) bool {
+	c := Lab(col.L, col.A, col.B)
+	return c.IsValid() && (g.settings.CheckColor == nil || g.settings.CheckColor(col.L, col.A, col.B))
+}
+
+// Sample the color space. These will be the points k-means is run on.
+// They only depend on the settings, so we only do this once.
+func (g *SoftPaletteGenerator) sample() {
+	if g.sampled {
+		return
 	}
+	g.sampled = true
 
-	// Sample the color space. These will be the points k-means is run on.
 	dl := 0.05
 	dab := 0.1
-	if settings.ManySamples {
+	if g.settings.ManySamples {
 		dl = 0.01
 		dab = 0.05
 	}
 
-	samples := make([]lab_t, 0, int(1.0/dl*2.0/dab*2.0/dab))
+	g.samples = make([]lab_t, 0, int(1.0/dl*2.0/dab*2.0/dab))
 	for l := 0.0; l <= 1.0; l += dl {
 		for a := -1.0; a <= 1.0; a += dab {
 			for b := -1.0; b <= 1.0; b += dab {
-				if check(lab_t{l, a, b}) {
-					samples = append(samples, lab_t{l, a, b})
+				if g.check(lab_t{l, a, b}) {
+					g.samples = append(g.samples, lab_t{l, a, b})
 				}
 			}
 		}
 	}
+	g.clusters = make([]int, len(g.samples))
+	g.samples_used = make([]bool, len(g.samples))
+}
+
+// GenerateInto fills dst with a palette of len(dst) colors. It only
+// allocates on the first call, and when dst is longer than ever before.
+func (g *SoftPaletteGenerator) GenerateInto(dst []Color) error {
+	colorsCount := len(dst)
+	g.sample()
+	samples := g.samples
 
 	// That would cause some infinite loops down there...
 	if len(samples) < colorsCount {
-		return nil, fmt.Errorf("palettegen: more colors requested (%v) than samples available (%v). Your requested color count may be wrong, you might want to use many samples or your constraint function makes the valid color space too small", colorsCount, len(samples))
+		return fmt.Errorf("palettegen: more colors requested (%v) than samples available (%v). Your requested color count may be wrong, you might want to use many samples or your constraint function makes the valid color space too small", colorsCount, len(samples))
 	} else if len(samples) == colorsCount {
-		return labs2cols(samples), nil // Oops?
+		labs2colsInto(dst, samples) // Oops?
+		return nil
+	}
+	if colorsCount == 0 {
+		return nil
 	}
 
+	if cap(g.means) < colorsCount {
+		g.means = make([]lab_t, colorsCount)
+		g.sums = make([]lab_t, colorsCount)
+		g.counts = make([]int, colorsCount)
+	}
+	means, sums, counts := g.means[:colorsCount], g.sums[:colorsCount], g.counts[:colorsCount]
+	clusters, samples_used := g.clusters, g.samples_used
+
 	// We take the initial means out of the samples, so they are in fact medoids.
 	// This helps us avoid infinite loops or arbitrary cutoffs with too restrictive constraints.
-	means := make([]lab_t, colorsCount)
 	for i := 0; i < colorsCount; i++ {
 		for means[i] = samples[rand.Intn(len(samples))]; in(means, i, means[i]); means[i] = samples[rand.Intn(len(samples))] {
 		}
 	}
 
-	clusters := make([]int, len(samples))
-	samples_used := make([]bool, len(samples))
-
 	// The actual k-means/medoid iterations
-	for i := 0; i < settings.Iterations; i++ {
+	for i := 0; i < g.settings.Iterations; i++ {
 		// Reassing the samples to clusters, i.e. to their closest mean.
 		// By the way, also check if any sample is used as a medoid and if so, mark that.
 		for isample, sample := range samples {
@@ -98,19 +150,24 @@ func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, erro
 			}
 		}
 
+		// Sum up the samples of each cluster in a single pass.
+		for imean := range means {
+			sums[imean] = lab_t{0.0, 0.0, 0.0}
+			counts[imean] = 0
+		}
+		for isample, sample := range samples {
+			imean := clusters[isample]
+			counts[imean]++
+			sums[imean].L += sample.L
+			sums[imean].A += sample.A
+			sums[imean].B += sample.B
+		}
+
 		// Compute new means according to the samples.
 		for imean := range means {
 			// The new mean is the average of all samples belonging to it..
-			nsamples := 0
-			newmean := lab_t{0.0, 0.0, 0.0}
-			for isample, sample := range samples {
-				if clusters[isample] == imean {
-					nsamples++
-					newmean.L += sample.L
-					newmean.A += sample.A
-					newmean.B += sample.B
-				}
-			}
+			nsamples := counts[imean]
+			newmean := sums[imean]
 			if nsamples > 0 {
 				newmean.L /= float64(nsamples)
 				newmean.A /= float64(nsamples)
@@ -125,7 +182,7 @@ func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, erro
 			}
 
 			// But now we still need to check whether the new mean is an allowed color.
-			if nsamples > 0 && check(newmean) {
+			if nsamples > 0 && g.check(newmean) {
 				// It does, life's good (TM)
 				means[imean] = newmean
 			} else {
@@ -145,7 +202,8 @@ func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, erro
 			}
 		}
 	}
-	return labs2cols(means), nil
+	labs2colsInto(dst, means)
+	return nil
 }
 
 // A wrapper which uses common parameters.
@@ -176,10 +234,8 @@ func lab_dist(lab1, lab2 lab_t) float64 {
 	return math.Sqrt(sq(lab1.L-lab2.L) + sq(lab1.A-lab2.A) + sq(lab1.B-lab2.B))
 }
 
-func labs2cols(labs []lab_t) (cols []Color) {
-	cols = make([]Color, len(labs))
+func labs2colsInto(cols []Color, labs []lab_t) {
 	for k, v := range labs {
 		cols[k] = Lab(v.L, v.A, v.B)
 	}
-	return cols
 }
@@ -63,3 +63,37 @@ func TestConstraint(t *testing.T) {
 		}
 	}
 }
+
+func TestSoftPaletteGenerator(t *testing.T) {
+	g := NewHappyPaletteGenerator()
+	dst := make([]Color, 8)
+	for i := 0; i < 3; i++ {
+		if err := g.GenerateInto(dst); err != nil {
+			t.Fatalf("GenerateInto => %v", err)
+		}
+		for icol, col := range dst {
+			if !col.IsValid() {
+				t.Errorf("Color %v in generated palette is invalid: %v", icol, col)
+			}
+			if _, c, l := col.Hcl(); c < 0.3-0.01 || l < 0.4-0.01 || l > 0.8+0.01 {
+				t.Errorf("Color %v in generated palette violates the constraint: %v", icol, col)
+			}
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(3, func() { g.GenerateInto(dst[:5]) }); allocs != 0 {
+		t.Errorf("GenerateInto allocates %v times, want 0", allocs)
+	}
+
+	never := func(l, a, b float64) bool { return false }
+	if err := NewSoftPaletteGenerator(SoftPaletteSettings{never, 50, false}).GenerateInto(dst); err == nil {
+		t.Error("Should error-out on impossible constraint!")
+	}
+
+	FastWarmPaletteInto(dst)
+	for icol, col := range dst {
+		if !col.IsValid() {
+			t.Errorf("Color %v in fast warm palette is invalid: %v", icol, col)
+		}
+	}
+}
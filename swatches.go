@@ -0,0 +1,167 @@
+// Rendering palettes and gradients into images, for debugging and
+// documentation.
+
+package colorful
+
+import (
+	"image"
+	"image/color"
+)
+
+// SwatchOptions configure RenderPalette and RenderGradient.
+type SwatchOptions struct {
+	// Width and Height are the size of each color of a palette, or of the
+	// whole strip of a gradient. Zero means 64 by 64 for palettes and 512 by
+	// 64 for gradients.
+	Width, Height int
+	// Labels writes the hex code of each color of a palette, or of the stops
+	// of a gradient, onto it in black or white, whichever reads better.
+	Labels bool
+}
+
+// A 3 by 5 pixel font of the characters of hex codes, one row per byte with
+// the leftmost pixel in bit 2.
+var hexFont = map[byte][5]uint8{
+	'#': {5, 7, 5, 7, 5},
+	'0': {7, 5, 5, 5, 7}, '1': {2, 6, 2, 2, 7}, '2': {7, 1, 7, 4, 7}, '3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1}, '5': {7, 4, 7, 1, 7}, '6': {7, 4, 7, 5, 7}, '7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7}, '9': {7, 5, 7, 1, 7}, 'a': {2, 5, 7, 5, 5}, 'b': {6, 5, 6, 5, 6},
+	'c': {3, 4, 4, 4, 3}, 'd': {6, 5, 5, 5, 6}, 'e': {7, 4, 6, 4, 7}, 'f': {7, 4, 6, 4, 4},
+}
+
+// The width of a hex code in font pixels, each character being 3 pixels wide
+// with 1 pixel of space after it.
+const hexWidth = 4*7 - 1
+
+// hexScale returns how large hex codes fit into the rectangle, taking up to a
+// third of its height, or 0 if they don't fit at all.
+func hexScale(r image.Rectangle) int {
+	scale := r.Dy() / 3 / 5
+	if s := (r.Dx() - 2) / hexWidth; s < scale {
+		scale = s
+	}
+	return scale
+}
+
+// drawHex writes the hex code of col centered on x, at the bottom of the
+// rectangle r, magnified by scale.
+func drawHex(img *image.NRGBA, r image.Rectangle, x, scale int, col Color) {
+	text := col.Clamped().Hex()
+	w := hexWidth
+	if scale < 1 {
+		return
+	}
+
+	r8, g8, b8 := BestTextColor(col).RGB255()
+	ink := color.NRGBA{r8, g8, b8, 255}
+	x0 := x - w*scale/2
+	if x0 < r.Min.X+1 {
+		x0 = r.Min.X + 1
+	} else if x0+w*scale > r.Max.X-1 {
+		x0 = r.Max.X - 1 - w*scale
+	}
+	y0 := r.Max.Y - 6*scale
+	for i := 0; i < len(text); i++ {
+		glyph := hexFont[text[i]]
+		for gy, row := range glyph {
+			for gx := 0; gx < 3; gx++ {
+				if row&(4>>gx) == 0 {
+					continue
+				}
+				px := x0 + (4*i+gx)*scale
+				py := y0 + gy*scale
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						img.SetNRGBA(px+dx, py+dy, ink)
+					}
+				}
+			}
+		}
+	}
+}
+
+// RenderPalette renders the colors side by side into an image, each as a
+// rectangle of the configured size.
+func RenderPalette(colors []Color, opts SwatchOptions) *image.NRGBA {
+	w, h := opts.Width, opts.Height
+	if w <= 0 {
+		w = 64
+	}
+	if h <= 0 {
+		h = 64
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, w*len(colors), h))
+	for i, col := range colors {
+		r8, g8, b8 := col.Clamped().RGB255()
+		c := color.NRGBA{r8, g8, b8, 255}
+		rect := image.Rect(i*w, 0, (i+1)*w, h)
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				img.SetNRGBA(x, y, c)
+			}
+		}
+		if opts.Labels {
+			drawHex(img, rect, (rect.Min.X+rect.Max.X)/2, hexScale(rect), col)
+		}
+	}
+	return img
+}
+
+// RenderGradient renders the gradient from its first to its last stop into
+// an image of the configured size, left to right.
+func RenderGradient(g Gradient, opts SwatchOptions) *image.NRGBA {
+	w, h := opts.Width, opts.Height
+	if w <= 0 {
+		w = 512
+	}
+	if h <= 0 {
+		h = 64
+	}
+	if len(g.Stops) == 0 {
+		panic("colorful: gradient has no stops")
+	}
+
+	first, last := g.Stops[0].Pos, g.Stops[len(g.Stops)-1].Pos
+	pos := func(x int) float64 {
+		if w == 1 {
+			return first
+		}
+		return first + (last-first)*float64(x)/float64(w-1)
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		r8, g8, b8 := g.At(pos(x)).RGB255()
+		c := color.NRGBA{r8, g8, b8, 255}
+		for y := 0; y < h; y++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	if opts.Labels && last > first {
+		// Give each label the room halfway to the neighboring stops, and all
+		// of them the size of the one with the least room.
+		xs := make([]int, len(g.Stops))
+		for i, s := range g.Stops {
+			xs[i] = int((s.Pos - first) / (last - first) * float64(w-1))
+		}
+		rects := make([]image.Rectangle, len(g.Stops))
+		scale := h
+		for i, x := range xs {
+			x0, x1 := 0, w
+			if i > 0 {
+				x0 = (x + xs[i-1]) / 2
+			}
+			if i < len(xs)-1 {
+				x1 = (x + xs[i+1]) / 2
+			}
+			rects[i] = image.Rect(x0, 0, x1, h)
+			if s := hexScale(rects[i]); s < scale {
+				scale = s
+			}
+		}
+		for i, s := range g.Stops {
+			drawHex(img, rects[i], xs[i], scale, s.Col)
+		}
+	}
+	return img
+}
@@ -0,0 +1,73 @@
+package colorful
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestRenderPalette(t *testing.T) {
+	colors := []Color{MustHex("#3a7bd5"), MustHex("#f0e68c"), MustHex("#102030")}
+	img := RenderPalette(colors, SwatchOptions{Width: 100, Height: 60})
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 60 {
+		t.Fatalf("RenderPalette => %v, want 300x60", b)
+	}
+	for i, c := range colors {
+		r, g, b := c.RGB255()
+		if got := img.NRGBAAt(i*100+50, 30); got != (color.NRGBA{r, g, b, 255}) {
+			t.Errorf("RenderPalette => %v in swatch %v, want %v", got, i, c.Hex())
+		}
+	}
+
+	// Labels are black on light colors and white on dark ones.
+	labeled := RenderPalette(colors, SwatchOptions{Width: 100, Height: 60, Labels: true})
+	ink := map[int]color.NRGBA{1: {0, 0, 0, 255}, 2: {255, 255, 255, 255}}
+	for i, want := range ink {
+		found := false
+		for y := 0; y < 60; y++ {
+			for x := i * 100; x < (i+1)*100; x++ {
+				found = found || labeled.NRGBAAt(x, y) == want
+			}
+		}
+		if !found {
+			t.Errorf("RenderPalette with labels => no %v label in swatch %v", want, i)
+		}
+	}
+
+	// Too small swatches get no labels.
+	small := RenderPalette(colors, SwatchOptions{Width: 10, Height: 10, Labels: true})
+	plain := RenderPalette(colors, SwatchOptions{Width: 10, Height: 10})
+	for i := range small.Pix {
+		if small.Pix[i] != plain.Pix[i] {
+			t.Errorf("RenderPalette of small swatches => labels drawn")
+			break
+		}
+	}
+}
+
+func TestRenderGradient(t *testing.T) {
+	g := NewGradient(MustHex("#000080"), MustHex("#ff8800"), MustHex("#ffffe0"))
+	img := RenderGradient(g, SwatchOptions{})
+	if b := img.Bounds(); b.Dx() != 512 || b.Dy() != 64 {
+		t.Fatalf("RenderGradient => %v, want 512x64", b)
+	}
+	for _, x := range []int{0, 200, 511} {
+		r, gr, b := g.At(float64(x) / 511).RGB255()
+		if got := img.NRGBAAt(x, 10); got != (color.NRGBA{r, gr, b, 255}) {
+			t.Errorf("RenderGradient => %v at %v, want %v", got, x, color.NRGBA{r, gr, b, 255})
+		}
+	}
+
+	labeled := RenderGradient(g, SwatchOptions{Labels: true})
+	if labeled.NRGBAAt(256, 10) != img.NRGBAAt(256, 10) {
+		t.Errorf("RenderGradient with labels => label drawn at the top")
+	}
+	changed := 0
+	for i := range img.Pix {
+		if img.Pix[i] != labeled.Pix[i] {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Errorf("RenderGradient with labels => no labels drawn")
+	}
+}
@@ -0,0 +1,156 @@
+// Color temperature, following the Planckian locus of black body radiators,
+// and the temperature and tint adjustments of photo editors built upon it.
+
+package colorful
+
+import (
+	"image"
+	"math"
+)
+
+// planckianXy returns the chromaticity of a black body radiator of the given
+// temperature, clamped to [1667..25000] kelvin, using the cubic spline
+// approximation of Kim et al.
+// https://en.wikipedia.org/wiki/Planckian_locus#Approximation
+func planckianXy(kelvin float64) (x, y float64) {
+	t := math.Max(1667, math.Min(kelvin, 25000))
+	t2, t3 := t*t, t*t*t
+	if t <= 4000 {
+		x = -0.2661239e9/t3 - 0.2343589e6/t2 + 0.8776956e3/t + 0.179910
+	} else {
+		x = -3.0258469e9/t3 + 2.1070379e6/t2 + 0.2226347e3/t + 0.240390
+	}
+	x2, x3 := x*x, x*x*x
+	switch {
+	case t <= 2222:
+		y = -1.1063814*x3 - 1.34811020*x2 + 2.18555832*x - 0.20219683
+	case t <= 4000:
+		y = -0.9549476*x3 - 1.37418593*x2 + 2.09137015*x - 0.16748867
+	default:
+		y = 3.0817580*x3 - 5.87338670*x2 + 3.75112997*x - 0.37001483
+	}
+	return
+}
+
+// xyToUv and uvToXy convert between the CIE 1931 xy and CIE 1960 UCS uv
+// chromaticities, the latter being the space tint is measured in.
+func xyToUv(x, y float64) (u, v float64) {
+	d := -2*x + 12*y + 3
+	return 4 * x / d, 6 * y / d
+}
+
+func uvToXy(u, v float64) (x, y float64) {
+	d := 2*u - 8*v + 4
+	return 3 * u / d, 2 * v / d
+}
+
+// whitePointXy returns the white point of Y = 1 with the chromaticity xy.
+func whitePointXy(x, y float64) [3]float64 {
+	X, Y, Z := XyyToXyz(x, y, 1)
+	return [3]float64{X, Y, Z}
+}
+
+// PlanckianWhitePoint returns the white point of a black body radiator, such
+// as an incandescent lamp, of the given temperature in kelvin. Temperatures
+// outside of [1667..25000] are clamped.
+func PlanckianWhitePoint(kelvin float64) [3]float64 {
+	return whitePointXy(planckianXy(kelvin))
+}
+
+// planckianUvOffset returns the uv chromaticity at the given temperature,
+// moved by duv perpendicularly to the Planckian locus, positive towards green.
+func planckianUvOffset(kelvin, duv float64) (u, v float64) {
+	u, v = xyToUv(planckianXy(kelvin))
+	if duv == 0 {
+		return
+	}
+	u1, v1 := xyToUv(planckianXy(kelvin - 1))
+	u2, v2 := xyToUv(planckianXy(kelvin + 1))
+	du, dv := u2-u1, v2-v1
+	n := math.Hypot(du, dv)
+	nu, nv := -dv/n, du/n
+	if nv < 0 {
+		nu, nv = -nu, -nv
+	}
+	return u + duv*nu, v + duv*nv
+}
+
+// The temperature of the Planckian white closest to D65, which the
+// adjustments are relative to.
+const neutralKelvin = 6504
+
+// temperatureTint returns the adaptation on linear RGB values making colors
+// warmer by dk kelvin and more magenta by dt.
+func temperatureTint(dk, dt float64) mat3 {
+	src := whitePointXy(uvToXy(planckianUvOffset(neutralKelvin, 0)))
+	dst := whitePointXy(uvToXy(planckianUvOffset(neutralKelvin-dk, -dt)))
+	return linearRgbAdaptation(src, dst)
+}
+
+// AdjustTemperature makes the color warmer (more yellow) for positive delta
+// and cooler (bluer) for negative delta, like the temperature slider of photo
+// editors. It adapts the color from the white of a 6504 K black body, close
+// to D65, to the one of 6504 K - delta, so 500 makes it look like lit by a
+// 6004 K light.
+func (col Color) AdjustTemperature(delta float64) Color {
+	m := temperatureTint(delta, 0)
+	return LinearRgb(m.mul(col.LinearRgb()))
+}
+
+// AdjustTint makes the color more magenta for positive delta and greener for
+// negative delta, by moving the white point perpendicularly off the Planckian
+// locus by delta in CIE 1960 uv units. Amounts around 0.01 are noticeable,
+// and lights rarely are more than 0.02 off the locus.
+func (col Color) AdjustTint(delta float64) Color {
+	m := temperatureTint(0, delta)
+	return LinearRgb(m.mul(col.LinearRgb()))
+}
+
+// AdjustTemperatureImage returns a copy of img with both AdjustTemperature
+// and AdjustTint applied to every pixel.
+func AdjustTemperatureImage(img image.Image, delta, tint float64) *image.NRGBA {
+	return applyLinearRgb(img, temperatureTint(delta, tint))
+}
+
+/// Warm and cool colors ///
+////////////////////////////
+
+// The OkLch hue of the warmest colors, an orange, whose opposite is a cyan
+// blue, the coolest.
+const warmHue = 55.0
+
+// The warmth below which colors are neither warm nor cool.
+const neutralWarmth = 0.02
+
+// warmAxis is the unit vector in the OkLab a, b plane pointing to warmHue.
+var warmAxis = [2]float64{math.Cos(warmHue * math.Pi / 180), math.Sin(warmHue * math.Pi / 180)}
+
+// Warmth returns how warm the color is, positive for warm colors like reds,
+// oranges and yellows and negative for cool ones like greens, cyans and
+// blues. It is the projection of the color's OkLab a and b onto the axis from
+// cyan blue to orange, so it fades out smoothly for grays and for hues
+// halfway between, such as magentas and yellowish greens, rather than
+// jumping at a hue threshold.
+func (col Color) Warmth() float64 {
+	_, a, b := col.OkLab()
+	return a*warmAxis[0] + b*warmAxis[1]
+}
+
+// IsWarm reports whether the color is noticeably warm, see Warmth.
+func (col Color) IsWarm() bool {
+	return col.Warmth() > neutralWarmth
+}
+
+// IsCool reports whether the color is noticeably cool, see Warmth.
+func (col Color) IsCool() bool {
+	return col.Warmth() < -neutralWarmth
+}
+
+// ShiftWarmth moves the color along the warm-cool axis of Warmth by amount,
+// making it warmer for positive and cooler for negative amounts, keeping its
+// OkLab lightness. Amounts around 0.02 are noticeable. Colors leaving the
+// gamut are brought back by reducing chroma.
+func (col Color) ShiftWarmth(amount float64) Color {
+	l, a, b := col.OkLab()
+	return OkLab(l, a+amount*warmAxis[0], b+amount*warmAxis[1]).ClampedOkLch()
+}
@@ -0,0 +1,98 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestPlanckianWhitePoint(t *testing.T) {
+	tests := []struct {
+		kelvin float64
+		x, y   float64
+	}{
+		{2856, 0.4476, 0.4074}, // Illuminant A.
+		{5000, 0.3451, 0.3516},
+		{6504, 0.3135, 0.3237},
+	}
+	for i, tt := range tests {
+		wp := PlanckianWhitePoint(tt.kelvin)
+		x, y, Y := XyzToXyy(wp[0], wp[1], wp[2])
+		if math.Abs(x-tt.x) > 1e-3 || math.Abs(y-tt.y) > 1e-3 || !almosteq(Y, 1) {
+			t.Errorf("%v. PlanckianWhitePoint(%v) => xyY (%v, %v, %v), want (%v, %v, 1)", i, tt.kelvin, x, y, Y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestAdjustTemperature(t *testing.T) {
+	for _, c := range randomColors(20) {
+		if got := c.AdjustTemperature(0); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.AdjustTemperature(0) => %v, want it unchanged", c, got)
+		}
+		if got := c.AdjustTint(0); !got.AlmostEqualRgb(c) {
+			t.Errorf("%v.AdjustTint(0) => %v, want it unchanged", c, got)
+		}
+	}
+
+	gray := Color{0.5, 0.5, 0.5}
+	if _, _, b := gray.AdjustTemperature(500).Lab(); b <= 0.01 {
+		t.Errorf("gray.AdjustTemperature(500) => b* %v, want yellower", b)
+	}
+	if _, _, b := gray.AdjustTemperature(-500).Lab(); b >= -0.01 {
+		t.Errorf("gray.AdjustTemperature(-500) => b* %v, want bluer", b)
+	}
+	if _, a, _ := gray.AdjustTint(0.01).Lab(); a <= 0.01 {
+		t.Errorf("gray.AdjustTint(0.01) => a* %v, want more magenta", a)
+	}
+	if _, a, _ := gray.AdjustTint(-0.01).Lab(); a >= -0.01 {
+		t.Errorf("gray.AdjustTint(-0.01) => a* %v, want greener", a)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.NRGBA{128, 128, 128, 255})
+	want := Color{128 / 255.0, 128 / 255.0, 128 / 255.0}.AdjustTemperature(800).AdjustTint(0.005)
+	if c, _ := MakeColor(AdjustTemperatureImage(img, 800, 0.005).At(0, 0)); c.DistanceRgb(want) > 2/255.0 {
+		t.Errorf("AdjustTemperatureImage => %v, want %v", c, want)
+	}
+}
+
+func TestWarmth(t *testing.T) {
+	tests := []struct {
+		hex        string
+		warm, cool bool
+	}{
+		{"#ff0000", true, false},
+		{"#ff8800", true, false},
+		{"#ffd700", true, false},
+		{"#8b4513", true, false},
+		{"#0000ff", false, true},
+		{"#00bfff", false, true},
+		{"#008080", false, true},
+		{"#808080", false, false},
+		{"#ffffff", false, false},
+	}
+	for i, tt := range tests {
+		c := MustHex(tt.hex)
+		if warm, cool := c.IsWarm(), c.IsCool(); warm != tt.warm || cool != tt.cool {
+			t.Errorf("%v. %v: IsWarm() => %v, IsCool() => %v, want %v and %v (warmth %v)", i, tt.hex, warm, cool, tt.warm, tt.cool, c.Warmth())
+		}
+	}
+
+	// Warmth changes smoothly through the magentas rather than jumping.
+	prev := OkLch(0.6, 0.15, 290).Warmth()
+	for h := 291.0; h <= 360; h++ {
+		w := OkLch(0.6, 0.15, h).Warmth()
+		if w < prev || w-prev > 0.01 {
+			t.Errorf("Warmth at OkLch hue %v => %v after %v", h, w, prev)
+		}
+		prev = w
+	}
+
+	col := Hcl(200, 0.2, 0.6)
+	l0, _, _ := col.OkLab()
+	shifted := col.ShiftWarmth(0.05)
+	if l, _, _ := shifted.OkLab(); math.Abs(l-l0) > 1e-6 || !almosteq_eps(shifted.Warmth(), col.Warmth()+0.05, 1e-6) {
+		t.Errorf("%v.ShiftWarmth(0.05) => %v, lightness %v and warmth %v, want %v and %v", col, shifted, l, shifted.Warmth(), l0, col.Warmth()+0.05)
+	}
+}
@@ -0,0 +1,52 @@
+// Text representation of colors, as used by YAML and similar formats.
+
+package colorful
+
+import "strconv"
+
+// MarshalText implements encoding.TextMarshaler. Colors with 8 bits per
+// channel are written in the hex form #rrggbb, all others, including those
+// outside of the gamut, as color(srgb r g b) with as many digits as needed
+// for UnmarshalText to restore the exact same color.
+func (col Color) MarshalText() ([]byte, error) {
+	return []byte(col.text()), nil
+}
+
+func (col Color) text() string {
+	hex := col.FormatHex(1.0, HexOptions{})
+	if c, err := Hex(hex); err == nil && c == col {
+		return hex
+	}
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return "color(srgb " + f(col.R) + " " + f(col.G) + " " + f(col.B) + ")"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any color
+// Parse understands.
+func (col *Color) UnmarshalText(text []byte) error {
+	c, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*col = c
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, writing the color as a string like MarshalText.
+func (col Color) MarshalYAML() (interface{}, error) {
+	return col.text(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 supports as well. It accepts any color Parse
+// understands, given as a string.
+func (col *Color) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return col.UnmarshalText([]byte(s))
+}
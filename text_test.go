@@ -0,0 +1,105 @@
+package colorful
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	col := Color{1, 128.0 / 255.0, 0}
+	text, err := col.MarshalText()
+	if err != nil || string(text) != "#ff8000" {
+		t.Errorf("MarshalText() => (%s, %v), want #ff8000", text, err)
+	}
+
+	for i, s := range []string{"#ff8000", "ff8000", "rgb(255 128 0)"} {
+		var got Color
+		if err := got.UnmarshalText([]byte(s)); err != nil || got != (Color{1, 128.0 / 255.0, 0}) {
+			t.Errorf("%v. UnmarshalText(%q) => (%v, %v)", i, s, got, err)
+		}
+	}
+
+	var c Color
+	if err := c.UnmarshalText([]byte("nope")); err == nil {
+		t.Errorf("UnmarshalText(nope) should have failed")
+	}
+
+	// Text marshaling is used for map keys, while the values keep using MarshalJSON.
+	data, err := json.Marshal(map[Color]Color{{1, 0, 0}: {0, 0, 1}})
+	if err != nil || string(data) != `{"#ff0000":{"R":0,"G":0,"B":1}}` {
+		t.Errorf("json.Marshal(map) => (%s, %v)", data, err)
+	}
+}
+
+func TestTextLossless(t *testing.T) {
+	cols := append(randomColors(100), Color{0.1, 0.2, 1.5}, Color{-1e-17, 0.5, 1}, Color{0, 0, 1})
+	for i, col := range cols {
+		text, _ := col.MarshalText()
+		var got Color
+		if err := got.UnmarshalText(text); err != nil || got != col {
+			t.Errorf("%v. UnmarshalText(%v.MarshalText() = %s) => (%v, %v)", i, col, text, got, err)
+		}
+	}
+	if text, _ := (Color{0.1, 0.2, 1.5}).MarshalText(); string(text) != "color(srgb 0.1 0.2 1.5)" {
+		t.Errorf("MarshalText() of an out-of-gamut color => %s", text)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	v, err := Color{0, 0, 1}.MarshalYAML()
+	if err != nil || v != "#0000ff" {
+		t.Errorf("MarshalYAML() => (%v, %v), want #0000ff", v, err)
+	}
+
+	var col Color
+	unmarshal := func(out interface{}) error {
+		*out.(*string) = "oklch(0.452 0.3132 264.05)"
+		return nil
+	}
+	if err := col.UnmarshalYAML(unmarshal); err != nil || !col.AlmostEqualRgb(Color{0, 0, 1}) {
+		t.Errorf("UnmarshalYAML() => (%v, %v), want %v", col, err, Color{0, 0, 1})
+	}
+}
+
+func TestGob(t *testing.T) {
+	type cached struct {
+		Col   Color
+		Any   interface{}
+		Other int
+	}
+	in := cached{Color{0.1, 0.2, 1.5}, Color{0.3, 0.4, 0.5}, 42}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode => %v", err)
+	}
+	var out cached
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode => %v", err)
+	}
+	if out != in {
+		t.Errorf("gob round trip => %v, want %v", out, in)
+	}
+}
+
+// Colors gob-encoded field by field, before Color implemented
+// encoding.BinaryMarshaler, decode as described in the CHANGELOG.
+func TestGobLegacy(t *testing.T) {
+	type fields struct{ R, G, B float64 }
+	in := Color{0.1, 0.2, 1.5}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(fields(in)); err != nil {
+		t.Fatalf("Encode => %v", err)
+	}
+	data := buf.Bytes()
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(new(Color)); err == nil {
+		t.Errorf("Decode into a Color => nil, want a type mismatch")
+	}
+	var old struct{ R, G, B float64 }
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&old); err != nil || Color(old) != in {
+		t.Errorf("Decode into the fields => (%v, %v), want %v", old, err, in)
+	}
+}
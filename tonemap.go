@@ -0,0 +1,81 @@
+// Tone mapping, which compresses the unbounded linear light values of high
+// dynamic range colors into the displayable range.
+
+package colorful
+
+import (
+	"image"
+	"math"
+)
+
+// A ToneMap maps linear RGB values of any non-negative magnitude, where 1 is
+// the diffuse white, to displayable linear RGB values. Those are in [0..1],
+// except that saturated colors can exceed it in some of the channels when
+// the operator works on the luminance; Color and Image clip them.
+type ToneMap func(r, g, b float64) (float64, float64, float64)
+
+// luminance is the relative luminance Y of linear sRGB values.
+func luminance(r, g, b float64) float64 {
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// scaleLuminance scales the values such that their luminance becomes the one
+// f maps it to, keeping the hue and saturation.
+func scaleLuminance(r, g, b float64, f func(float64) float64) (float64, float64, float64) {
+	l := luminance(r, g, b)
+	if l <= 0 {
+		return 0, 0, 0
+	}
+	s := f(l) / l
+	return r * s, g * s, b * s
+}
+
+// ReinhardToneMap is the simple Reinhard operator L / (1 + L), applied to the
+// luminance. It never reaches white, which is what ReinhardExtendedToneMap
+// fixes.
+func ReinhardToneMap(r, g, b float64) (float64, float64, float64) {
+	return scaleLuminance(r, g, b, func(l float64) float64 {
+		return l / (1 + l)
+	})
+}
+
+// ReinhardExtendedToneMap returns the extended Reinhard operator, which maps
+// the luminance white, the brightest one in the scene, to 1. Anything
+// brighter than white is clipped.
+func ReinhardExtendedToneMap(white float64) ToneMap {
+	w2 := white * white
+	return func(r, g, b float64) (float64, float64, float64) {
+		return scaleLuminance(r, g, b, func(l float64) float64 {
+			return math.Min(1, l*(1+l/w2)/(1+l))
+		})
+	}
+}
+
+// AcesFilmicToneMap is Krzysztof Narkowicz's fit of the ACES filmic curve,
+// applied to each channel. It adds contrast and desaturates highlights like
+// film does, and maps 1 to about 0.8.
+// https://knarkowicz.wordpress.com/2016/01/06/aces-filmic-tone-mapping-curve/
+func AcesFilmicToneMap(r, g, b float64) (float64, float64, float64) {
+	return acesFilmic(r), acesFilmic(g), acesFilmic(b)
+}
+
+func acesFilmic(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return clamp01((x * (2.51*x + 0.03)) / (x*(2.43*x+0.59) + 0.14))
+}
+
+// Color tone maps the linear RGB values into a color of the sRGB gamut.
+func (tm ToneMap) Color(r, g, b float64) Color {
+	return LinearRgb(tm(r, g, b)).Clamped()
+}
+
+// Image tone maps planes of linear RGB values, as those of ImageToLinearRgb
+// or a PlanarImage, into an image of the given bounds.
+func (tm ToneMap) Image(planes [3][]float32, r image.Rectangle) *image.NRGBA {
+	return planesToImage(planes, r, func(v vec3[float32]) vec3[float32] {
+		r, g, b := tm(float64(v[0]), float64(v[1]), float64(v[2]))
+		return vec3[float32]{float32(r), float32(g), float32(b)}
+	})
+}
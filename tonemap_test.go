@@ -0,0 +1,62 @@
+package colorful
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestToneMaps(t *testing.T) {
+	tests := []struct {
+		name string
+		tm   ToneMap
+	}{
+		{"Reinhard", ReinhardToneMap},
+		{"ReinhardExtended", ReinhardExtendedToneMap(16)},
+		{"AcesFilmic", AcesFilmicToneMap},
+	}
+	for _, tt := range tests {
+		prev := -1.0
+		for _, v := range []float64{0, 0.01, 0.1, 0.5, 1, 2, 4, 16, 1000} {
+			r, g, b := tt.tm(v, v, v)
+			if r != g || g != b {
+				t.Errorf("%v(%v) => (%v, %v, %v), want gray", tt.name, v, r, g, b)
+			}
+			if r < 0 || r > 1 || r < prev {
+				t.Errorf("%v(%v) => %v, want a monotonic value in [0..1]", tt.name, v, r)
+			}
+			prev = r
+		}
+		if c := tt.tm.Color(50, 20, 5); c.R < 0 || c.R > 1 || c.G < 0 || c.G > 1 || c.B < 0 || c.B > 1 {
+			t.Errorf("%v.Color(50, 20, 5) => %v, want a valid color", tt.name, c)
+		}
+	}
+
+	if r, _, _ := ReinhardToneMap(1, 1, 1); !almosteq(r, 0.5) {
+		t.Errorf("ReinhardToneMap(1) => %v, want 0.5", r)
+	}
+	if r, _, _ := ReinhardExtendedToneMap(16)(16, 16, 16); !almosteq(r, 1) {
+		t.Errorf("ReinhardExtendedToneMap(16)(16) => %v, want 1", r)
+	}
+	if r, _, _ := AcesFilmicToneMap(1, 1, 1); math.Abs(r-0.8) > 0.01 {
+		t.Errorf("AcesFilmicToneMap(1) => %v, want 0.8", r)
+	}
+
+	// The Reinhard operators keep the chromaticity.
+	r, g, b := ReinhardToneMap(4, 2, 1)
+	if !almosteq(r/g, 2) || !almosteq(g/b, 2) {
+		t.Errorf("ReinhardToneMap(4, 2, 1) => (%v, %v, %v), want the same ratios", r, g, b)
+	}
+}
+
+func TestToneMapImage(t *testing.T) {
+	rect := image.Rect(0, 0, 2, 1)
+	planes := [3][]float32{{0, 100}, {0, 100}, {0, 100}}
+	img := ToneMap(ReinhardToneMap).Image(planes, rect)
+	if p := img.NRGBAAt(0, 0); p.R != 0 || p.A != 255 {
+		t.Errorf("ToneMap.Image black => %v", p)
+	}
+	if p := img.NRGBAAt(1, 0); p.R < 250 {
+		t.Errorf("ToneMap.Image bright => %v, want almost white", p)
+	}
+}
@@ -0,0 +1,102 @@
+// Color transforms behind a common interface, and pipelines chaining them.
+
+package colorful
+
+import "image"
+
+// A Transform maps colors to colors. CLUTs, Curves, ColorMatrices and
+// ToneMaps are Transforms, other functions can be used as one with
+// TransformFunc, and Pipelines chain several of them.
+type Transform interface {
+	Apply(col Color) Color
+}
+
+// TransformFunc adapts a function, such as Color.Complement, to a Transform.
+type TransformFunc func(col Color) Color
+
+func (f TransformFunc) Apply(col Color) Color {
+	return f(col)
+}
+
+// Apply is At, which makes CLUTs Transforms.
+func (l *CLUT) Apply(col Color) Color {
+	return l.At(col)
+}
+
+// Apply tone maps the linear RGB values of the color, which may exceed 1 for
+// high dynamic range colors, which makes ToneMaps Transforms.
+func (tm ToneMap) Apply(col Color) Color {
+	return tm.Color(col.LinearRgb())
+}
+
+// ConvertSpace returns the Transform converting the values of colors in the
+// RGB space from to those in the space to, such as from Display P3 values to
+// sRGB ones. Colors out of the gamut of to aren't clipped.
+func ConvertSpace(from, to *RGBSpace) Transform {
+	return TransformFunc(func(col Color) Color {
+		r, g, b := to.Values(from.Color(col.R, col.G, col.B))
+		return Color{r, g, b}
+	})
+}
+
+// A Pipeline applies several Transforms one after another. It is itself a
+// Transform, so pipelines can be nested.
+type Pipeline struct {
+	steps []Transform
+}
+
+// NewPipeline returns the pipeline applying the transforms in order. Adjacent
+// ColorMatrices working on the same values, linear or sRGB, are fused into
+// one, as are adjacent Curves, which is faster but skips the clamping in
+// between, see ColorMatrix.Then.
+func NewPipeline(transforms ...Transform) *Pipeline {
+	p := &Pipeline{}
+	for _, t := range transforms {
+		if len(p.steps) > 0 {
+			last := &p.steps[len(p.steps)-1]
+			switch t := t.(type) {
+			case ColorMatrix:
+				if cm, ok := (*last).(ColorMatrix); ok && cm.Linear == t.Linear {
+					*last = cm.Then(t)
+					continue
+				}
+			case *Curve:
+				if c, ok := (*last).(*Curve); ok {
+					*last = c.Then(t)
+					continue
+				}
+			}
+		}
+		p.steps = append(p.steps, t)
+	}
+	return p
+}
+
+// Steps returns the transforms the pipeline applies, after fusing.
+func (p *Pipeline) Steps() []Transform {
+	return p.steps
+}
+
+// Apply applies all transforms of the pipeline to the color.
+func (p *Pipeline) Apply(col Color) Color {
+	for _, t := range p.steps {
+		col = t.Apply(col)
+	}
+	return col
+}
+
+// ApplySlice applies the pipeline to all colors of src into dst, which needs
+// to have the same length. dst and src may be the same slice.
+func (p *Pipeline) ApplySlice(dst, src []Color) {
+	checkSliceLens(len(dst), len(src))
+	src = src[:len(dst)]
+	for i := range dst {
+		dst[i] = p.Apply(src[i])
+	}
+}
+
+// Image returns a copy of img with the pipeline applied to every pixel,
+// keeping the alpha channel.
+func (p *Pipeline) Image(img image.Image) *image.NRGBA {
+	return MapImage(img, p.Apply)
+}
@@ -0,0 +1,54 @@
+package colorful
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	gamma := CurveFromFunc(func(v float64) float64 { return v * v }, 0)
+	p := NewPipeline(
+		SepiaMatrix(0.5),
+		ContrastMatrix(0.9),
+		gamma,
+		gamma,
+		TransformFunc(Color.Complement),
+		BrightnessMatrix(0.8),
+	)
+	if n := len(p.Steps()); n != 4 {
+		t.Errorf("NewPipeline => %v steps, want the matrices and curves fused into 4", n)
+	}
+
+	for _, c := range randomColors(20) {
+		c = c.BlendRgb(Color{0.5, 0.5, 0.5}, 0.5)
+		want := BrightnessMatrix(0.8).Apply(gamma.Apply(gamma.Apply(ContrastMatrix(0.9).Apply(SepiaMatrix(0.5).Apply(c)))).Complement())
+		if got := p.Apply(c); !got.AlmostEqualRgb(want) {
+			t.Errorf("Pipeline.Apply(%v) => %v, want %v", c, got, want)
+		}
+	}
+
+	// Nesting and the other kinds of transforms.
+	lin := IdentityMatrix()
+	lin.Linear = true
+	nested := NewPipeline(p, NewCLUT(2, nil), ToneMap(ReinhardToneMap), lin, ConvertSpace(SRGB, SRGB))
+	if n := len(nested.Steps()); n != 5 {
+		t.Errorf("nested pipeline => %v steps, want 5", n)
+	}
+
+	colors := randomColors(10)
+	dst := make([]Color, len(colors))
+	nested.ApplySlice(dst, colors)
+	for i, c := range colors {
+		want := ToneMap(ReinhardToneMap).Apply(p.Apply(c))
+		if !dst[i].AlmostEqualRgb(want) {
+			t.Errorf("ApplySlice => %v at %v, want %v", dst[i], i, want)
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{255, 255, 255, 100})
+	if got := NewPipeline(InvertMatrix(1)).Image(img).NRGBAAt(0, 0); got != (color.NRGBA{0, 0, 0, 100}) {
+		t.Errorf("Pipeline.Image => %v, want black with alpha 100", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Generic building blocks of the color conversions for the float32 types like
+// Color32 and the buffers. The float64 conversions of Color are written out
+// by hand in colors.go, which the compiler optimizes considerably better.
+
+package colorful
+
+import "math"
+
+// float constrains the precisions the conversions are available in.
+type float interface {
+	~float32 | ~float64
+}
+
+// A vec3 holds the three values of a color in any of the color spaces.
+type vec3[T float] [3]T
+
+func (v vec3[T]) split() (T, T, T) {
+	return v[0], v[1], v[2]
+}
+
+// apply applies f to each of the values.
+func (v vec3[T]) apply(f func(T) T) vec3[T] {
+	return vec3[T]{f(v[0]), f(v[1]), f(v[2])}
+}
+
+// lerp interpolates linearly, t == 0 results in v, t == 1 results in w.
+func (v vec3[T]) lerp(w vec3[T], t T) vec3[T] {
+	return vec3[T]{v[0] + t*(w[0]-v[0]), v[1] + t*(w[1]-v[1]), v[2] + t*(w[2]-v[2])}
+}
+
+// dist is the Euclidean distance.
+func (v vec3[T]) dist(w vec3[T]) T {
+	d0, d1, d2 := v[0]-w[0], v[1]-w[1], v[2]-w[2]
+	return T(math.Sqrt(float64(d0*d0 + d1*d1 + d2*d2)))
+}
+
+// mulVec multiplies the matrix with the vector v. The matrices are always
+// float64, so that float64 results are exactly those of writing the formulas
+// out by hand.
+func mulVec[T float](m *mat3, v vec3[T]) vec3[T] {
+	return vec3[T]{
+		T(m[0][0])*v[0] + T(m[0][1])*v[1] + T(m[0][2])*v[2],
+		T(m[1][0])*v[0] + T(m[1][1])*v[1] + T(m[1][2])*v[2],
+		T(m[2][0])*v[0] + T(m[2][1])*v[1] + T(m[2][2])*v[2],
+	}
+}
+
+// The conversions of colors.go, generic in their precision. Only the float32
+// instances are used, float64 code calls the hand-written versions.
+
+func linearizeT[T float](v T) T {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return T(math.Pow(float64((v+0.055)/1.055), 2.4))
+}
+
+func linearizeFastT[T float](v T) T {
+	v1 := v - 0.5
+	v2 := v1 * v1
+	v3 := v2 * v1
+	v4 := v2 * v2
+	return -0.248750514614486 + 0.925583310193438*v + 1.16740237321695*v2 + 0.280457026598666*v3 - 0.0757991963780179*v4
+}
+
+func delinearizeT[T float](v T) T {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*T(math.Pow(float64(v), 1.0/2.4)) - 0.055
+}
+
+func labFT[T float](t T) T {
+	if t > 6.0/29.0*6.0/29.0*6.0/29.0 {
+		return T(math.Cbrt(float64(t)))
+	}
+	return t/3.0*29.0/6.0*29.0/6.0 + 4.0/29.0
+}
+
+func labFinvT[T float](t T) T {
+	if t > 6.0/29.0 {
+		return t * t * t
+	}
+	return 3.0 * 6.0 / 29.0 * 6.0 / 29.0 * (t - 4.0/29.0)
+}
+
+func xyzToLab[T float](xyz vec3[T], wref [3]float64) vec3[T] {
+	fy := labFT(xyz[1] / T(wref[1]))
+	return vec3[T]{
+		1.16*fy - 0.16,
+		5.0 * (labFT(xyz[0]/T(wref[0])) - fy),
+		2.0 * (fy - labFT(xyz[2]/T(wref[2]))),
+	}
+}
+
+func labToXyz[T float](lab vec3[T], wref [3]float64) vec3[T] {
+	l2 := (lab[0] + 0.16) / 1.16
+	return vec3[T]{
+		T(wref[0]) * labFinvT(l2+lab[1]/5.0),
+		T(wref[1]) * labFinvT(l2),
+		T(wref[2]) * labFinvT(l2-lab[2]/2.0),
+	}
+}
+
+func linearRgbToOkLab[T float](rgb vec3[T]) vec3[T] {
+	lms := mulVec(&okLabLmsMat, rgb).apply(func(v T) T {
+		return T(math.Cbrt(float64(v)))
+	})
+	return mulVec(&okLabLabMat, lms)
+}
+
+func okLabToLinearRgb[T float](lab vec3[T]) vec3[T] {
+	lms := mulVec(&okLabLabInvMat, lab).apply(func(v T) T {
+		return v * v * v
+	})
+	return mulVec(&okLabLmsInvMat, lms)
+}
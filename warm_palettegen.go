@@ -9,17 +9,32 @@ import (
 // If you've got time to spare, use Lab (the non-fast below).
 func FastWarmPalette(colorsCount int) (colors []Color) {
 	colors = make([]Color, colorsCount)
+	FastWarmPaletteInto(colors)
+	return
+}
 
-	for i := 0; i < colorsCount; i++ {
-		colors[i] = Hsv(float64(i)*(360.0/float64(colorsCount)), 0.55+rand.Float64()*0.2, 0.35+rand.Float64()*0.2)
+// FastWarmPaletteInto is like FastWarmPalette, but fills dst instead of
+// allocating a new palette.
+func FastWarmPaletteInto(dst []Color) {
+	for i := range dst {
+		dst[i] = Hsv(float64(i)*(360.0/float64(len(dst))), 0.55+rand.Float64()*0.2, 0.35+rand.Float64()*0.2)
 	}
-	return
 }
 
 func WarmPalette(colorsCount int) ([]Color, error) {
+	return SoftPaletteEx(colorsCount, warmPaletteSettings())
+}
+
+// NewWarmPaletteGenerator creates a generator of the palettes WarmPalette
+// returns, which doesn't allocate for every palette.
+func NewWarmPaletteGenerator() *SoftPaletteGenerator {
+	return NewSoftPaletteGenerator(warmPaletteSettings())
+}
+
+func warmPaletteSettings() SoftPaletteSettings {
 	warmy := func(l, a, b float64) bool {
 		_, c, _ := LabToHcl(l, a, b)
 		return 0.1 <= c && c <= 0.4 && 0.2 <= l && l <= 0.5
 	}
-	return SoftPaletteEx(colorsCount, SoftPaletteSettings{warmy, 50, true})
+	return SoftPaletteSettings{warmy, 50, true}
 }